@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"path/filepath"
+
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog"
@@ -11,6 +14,7 @@ import (
 	customMiddleware "github.com/yourusername/sns-backend/internal/middleware"
 	"github.com/yourusername/sns-backend/internal/models"
 	"github.com/yourusername/sns-backend/internal/routes"
+	"github.com/yourusername/sns-backend/internal/services"
 	"gorm.io/gorm"
 
 	_ "github.com/yourusername/sns-backend/docs" // Swagger生成ファイルをインポート
@@ -89,22 +93,76 @@ func main() {
 		// Phase 2
 		&models.Hashtag{},
 		&models.PostHashtag{},
+		&models.BookmarkCollection{},
 		&models.Bookmark{},
 		&models.PasswordResetToken{},
 		&models.EmailVerificationToken{},
 		// 管理画面用
 		&models.PasswordResetRequest{},
 		&models.AdminLog{},
+		// 通知
+		&models.Notification{},
+		// メンション
+		&models.PostMention{},
+		// ブロック・ミュート
+		&models.Block{},
+		&models.Mute{},
+		// フォローリクエスト（鍵アカウント）
+		&models.FollowRequest{},
+		// ダイレクトメッセージ
+		&models.Conversation{},
+		&models.ConversationMember{},
+		&models.Message{},
+		// 分割アップロード
+		&models.UploadSession{},
+		// 投稿の編集履歴
+		&models.PostRevision{},
+		// アンケート
+		&models.Poll{},
+		&models.PollOption{},
+		&models.PollVote{},
 	); err != nil {
 		log.Fatal().Err(err).Msg("Failed to migrate database")
 	}
+	// メディアのURLをオブジェクトパスへ移行（URLは表示時に署名）
+	if err := database.MigrateMediaObjectPaths(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to migrate media object paths")
+	}
+	// 同じ投稿の重複リポストを防ぐ一意インデックス
+	if err := database.EnsureRepostUniqueIndex(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to create repost unique index")
+	}
+	// 既存の1対1の会話に参加者ペアの一意キーを設定
+	if err := database.MigrateConversationDirectKeys(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to migrate conversation direct keys")
+	}
+	// 既存のブックマークを既定のコレクションへ移行
+	if err := database.MigrateBookmarkCollections(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to migrate bookmark collections")
+	}
 	log.Info().Msg("Database migrations completed")
 
+	// 検索用インデックス
+	if err := database.EnsureSearchIndexes(db); err != nil {
+		log.Error().Err(err).Msg("Failed to create search indexes")
+	}
+
 	// 管理者アカウントのシード
 	if err := seedAdminUser(db, log); err != nil {
 		log.Error().Err(err).Msg("Failed to seed admin user")
 	}
 
+	// 孤立したメディアオブジェクトの定期掃除
+	if err := services.StartMediaSweeper(context.Background(), cfg.MediaSweepInterval); err != nil {
+		log.Error().Err(err).Msg("Failed to start media sweeper")
+	}
+
+	// 期限切れの分割アップロードセッションの定期削除
+	services.StartUploadSessionCleanup(context.Background(), cfg.MediaSweepInterval)
+
+	// 予約投稿の定期公開
+	services.StartPostScheduler(context.Background(), cfg.PostSchedulerInterval)
+
 	// Echoインスタンスを作成
 	e := echo.New()
 
@@ -136,6 +194,12 @@ func main() {
 		})
 	})
 
+	// ローカルストレージのメディアを静的ファイルとして配信
+	if cfg.StorageDriver == services.StorageDriverLocal {
+		e.Static("/"+services.UploadObjectPrefix, filepath.Join(cfg.LocalStorageRoot, services.UploadObjectPrefix))
+		log.Info().Str("root", cfg.LocalStorageRoot).Msg("Serving media from local storage")
+	}
+
 	// ルート設定
 	routes.SetupRoutes(e)
 	routes.SetupAdminRoutes(e)
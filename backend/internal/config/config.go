@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -23,6 +25,25 @@ type Config struct {
 	ResendAPIKey              string
 	FrontendURL               string
 	FromEmail                 string
+
+	// メディアストレージ（firebase / local / s3）
+	StorageDriver             string
+	LocalStorageRoot          string // ローカルドライバーの保存先ディレクトリ
+	LocalStorageBaseURL       string // ローカルドライバーの配信URL（Echoの静的ファイル配信）
+	S3Endpoint                string // S3互換エンドポイント（例: localhost:9000）
+	S3Region                  string
+	S3Bucket                  string
+	S3AccessKeyID             string
+	S3SecretAccessKey         string
+	S3UseSSL                  bool
+	S3PublicBaseURL           string // 公開バケット・CDNのURL（未設定の場合は署名付きURL）
+	MediaSweepInterval        time.Duration // 孤立オブジェクト掃除の間隔（0で無効）
+	MediaCDNBaseURL           string // メディア配信CDNのURL（設定時は署名付きURLを生成しない）
+	UploadTempDir             string // 分割アップロードのチャンクを保存する一時ディレクトリ
+	MediaMaxVideoDuration     time.Duration // 動画の再生時間の上限（0で無制限）
+	MediaMaxAudioDuration     time.Duration // 音声の再生時間の上限（0で無制限）
+	PostEditWindow            time.Duration // 投稿後に編集できる期間（0で無制限）
+	PostSchedulerInterval     time.Duration // 予約投稿を公開する間隔（0で無効）
 }
 
 var AppConfig *Config
@@ -71,6 +92,44 @@ func LoadConfig() *Config {
 		log.Println("⚠️  Running in TEST MODE - using test database:", dbName)
 	}
 
+	// ストレージドライバー（未指定の場合はFirebase設定の有無で判定）
+	firebaseStorageBucket := getEnv("FIREBASE_STORAGE_BUCKET", "")
+	defaultStorageDriver := "local"
+	if firebaseStorageBucket != "" {
+		defaultStorageDriver = "firebase"
+	}
+	port := getEnv("PORT", "8080")
+
+	mediaSweepInterval, err := time.ParseDuration(getEnv("MEDIA_SWEEP_INTERVAL", "6h"))
+	if err != nil {
+		log.Println("Warning: invalid MEDIA_SWEEP_INTERVAL, using default 6h")
+		mediaSweepInterval = 6 * time.Hour
+	}
+
+	mediaMaxVideoDuration, err := time.ParseDuration(getEnv("MEDIA_MAX_VIDEO_DURATION", "3m"))
+	if err != nil {
+		log.Println("Warning: invalid MEDIA_MAX_VIDEO_DURATION, using default 3m")
+		mediaMaxVideoDuration = 3 * time.Minute
+	}
+
+	mediaMaxAudioDuration, err := time.ParseDuration(getEnv("MEDIA_MAX_AUDIO_DURATION", "10m"))
+	if err != nil {
+		log.Println("Warning: invalid MEDIA_MAX_AUDIO_DURATION, using default 10m")
+		mediaMaxAudioDuration = 10 * time.Minute
+	}
+
+	postEditWindow, err := time.ParseDuration(getEnv("POST_EDIT_WINDOW", "0"))
+	if err != nil {
+		log.Println("Warning: invalid POST_EDIT_WINDOW, editing is not time-limited")
+		postEditWindow = 0
+	}
+
+	postSchedulerInterval, err := time.ParseDuration(getEnv("POST_SCHEDULER_INTERVAL", "1m"))
+	if err != nil {
+		log.Println("Warning: invalid POST_SCHEDULER_INTERVAL, using default 1m")
+		postSchedulerInterval = time.Minute
+	}
+
 	config := &Config{
 		DBHost:                    dbHost,
 		DBPort:                    dbPort,
@@ -78,14 +137,31 @@ func LoadConfig() *Config {
 		DBPassword:                dbPassword,
 		DBName:                    dbName,
 		JWTSecret:                 jwtSecret,
-		Port:                      getEnv("PORT", "8080"),
+		Port:                      port,
 		Env:                       env,
 		IsTestMode:                isTestMode,
 		FirebaseCredentialsPath:   getEnv("FIREBASE_CREDENTIALS_PATH", "./service_account_key.json"),
-		FirebaseStorageBucket:     getEnv("FIREBASE_STORAGE_BUCKET", ""),
+		FirebaseStorageBucket:     firebaseStorageBucket,
 		ResendAPIKey:              getEnv("RESEND_API_KEY", ""),
 		FrontendURL:               getEnv("FRONTEND_URL", "http://localhost:5173"),
 		FromEmail:                 getEnv("FROM_EMAIL", "noreply@example.com"),
+		StorageDriver:             getEnv("STORAGE_DRIVER", defaultStorageDriver),
+		LocalStorageRoot:          getEnv("LOCAL_STORAGE_ROOT", "."),
+		LocalStorageBaseURL:       getEnv("LOCAL_STORAGE_BASE_URL", "http://localhost:"+port),
+		S3Endpoint:                getEnv("S3_ENDPOINT", ""),
+		S3Region:                  getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                  getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:             getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:         getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3UseSSL:                  getEnv("S3_USE_SSL", "true") == "true",
+		S3PublicBaseURL:           getEnv("S3_PUBLIC_BASE_URL", ""),
+		MediaSweepInterval:        mediaSweepInterval,
+		MediaCDNBaseURL:           getEnv("MEDIA_CDN_BASE_URL", ""),
+		MediaMaxVideoDuration:     mediaMaxVideoDuration,
+		MediaMaxAudioDuration:     mediaMaxAudioDuration,
+		UploadTempDir:             getEnv("UPLOAD_TEMP_DIR", filepath.Join(os.TempDir(), "sns-uploads")),
+		PostEditWindow:            postEditWindow,
+		PostSchedulerInterval:     postSchedulerInterval,
 	}
 
 	AppConfig = config
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/yourusername/sns-backend/internal/config"
+	"github.com/yourusername/sns-backend/internal/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -60,3 +61,97 @@ func Connect(cfg *config.Config) (*gorm.DB, error) {
 func GetDB() *gorm.DB {
 	return DB
 }
+
+// EnsureSearchIndexes - 投稿検索用のトライグラムインデックスを作成
+// 日本語は単語の区切りがないため、形態素解析ではなくpg_trgmによる部分一致検索を使用する
+func EnsureSearchIndexes(db *gorm.DB) error {
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_posts_content_trgm ON posts USING gin (content gin_trgm_ops)",
+	}
+
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnsureRepostUniqueIndex - 同じ投稿を重複してリポストできないよう一意インデックスを作成
+// 既に重複しているリポストは最も古いもの以外を論理削除する（何度実行しても結果は変わらない）
+func EnsureRepostUniqueIndex(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE posts SET deleted_at = NOW()
+			WHERE posts.repost_of_id IS NOT NULL AND posts.deleted_at IS NULL
+			AND EXISTS (SELECT 1 FROM posts AS kept
+				WHERE kept.user_id = posts.user_id AND kept.repost_of_id = posts.repost_of_id
+				AND kept.deleted_at IS NULL AND kept.id < posts.id)`).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_user_repost_unique
+			ON posts (user_id, repost_of_id)
+			WHERE repost_of_id IS NOT NULL AND deleted_at IS NULL`).Error
+	})
+}
+
+// MigrateMediaObjectPaths - mediaテーブルのURLをオブジェクトパスに移行し、media_urlカラムを削除
+// 表示用URLは署名付きURLとして表示時に生成するため、DBにはオブジェクトパスのみ保存する
+// パスに変換できない外部URLはそのままobject_pathに保存する（表示時にそのまま返す）
+func MigrateMediaObjectPaths(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("media", "media_url") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE media
+			SET object_path = COALESCE(substring(media_url from 'uploads/[^?#]+'), media_url)
+			WHERE object_path IS NULL OR object_path = ''`).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("ALTER TABLE media DROP COLUMN media_url").Error
+	})
+}
+
+// MigrateConversationDirectKeys - 既存の1対1の会話に参加者ペアの一意キーを設定
+// 同じペアの会話が既に複数ある場合は最も古い会話にのみ設定する（何度実行しても結果は変わらない）
+func MigrateConversationDirectKeys(db *gorm.DB) error {
+	return db.Exec(`UPDATE conversations SET direct_key = pairs.direct_key
+		FROM (
+			SELECT DISTINCT ON (direct_key) conversation_id, direct_key FROM (
+				SELECT conversation_members.conversation_id,
+					MIN(conversation_members.user_id) || ':' || MAX(conversation_members.user_id) AS direct_key
+				FROM conversation_members
+				INNER JOIN conversations ON conversations.id = conversation_members.conversation_id
+				WHERE conversations.is_group = false AND conversations.direct_key IS NULL
+				GROUP BY conversation_members.conversation_id
+				HAVING COUNT(*) = 2
+			) AS keyed
+			ORDER BY direct_key, conversation_id
+		) AS pairs
+		WHERE conversations.id = pairs.conversation_id
+		AND NOT EXISTS (SELECT 1 FROM conversations AS existing WHERE existing.direct_key = pairs.direct_key)`).Error
+}
+
+// MigrateBookmarkCollections - コレクションに属していないブックマークを各ユーザーの既定のコレクションへ移行
+// 既定のコレクションがないユーザーには作成する（何度実行しても結果は変わらない）
+func MigrateBookmarkCollections(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`INSERT INTO bookmark_collections (user_id, name, is_default, created_at, updated_at)
+			SELECT DISTINCT bookmarks.user_id, ?, true, NOW(), NOW() FROM bookmarks
+			WHERE bookmarks.collection_id IS NULL
+			AND NOT EXISTS (SELECT 1 FROM bookmark_collections
+				WHERE bookmark_collections.user_id = bookmarks.user_id AND bookmark_collections.is_default)`,
+			models.DefaultBookmarkCollectionName).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`UPDATE bookmarks SET collection_id = bookmark_collections.id
+			FROM bookmark_collections
+			WHERE bookmark_collections.user_id = bookmarks.user_id AND bookmark_collections.is_default
+			AND bookmarks.collection_id IS NULL`).Error
+	})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// BlockHandler ブロック・ミュートハンドラー
+type BlockHandler struct {
+	blockService *services.BlockService
+}
+
+// NewBlockHandler BlockHandlerのコンストラクタ
+func NewBlockHandler() *BlockHandler {
+	return &BlockHandler{
+		blockService: services.NewBlockService(),
+	}
+}
+
+// BlockUser ユーザーをブロック
+// @Summary ユーザーをブロック
+// @Description 指定されたユーザーをブロックします（双方向のフォローは解除されます）
+// @Tags ブロック・ミュート
+// @Accept json
+// @Produce json
+// @Param username path string true "ブロックするユーザーのユーザー名"
+// @Success 204 "ブロック成功"
+// @Failure 400 {object} map[string]interface{} "自分自身をブロックすることはできません"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
+// @Failure 409 {object} map[string]interface{} "既にブロック済み"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /users/{username}/block [post]
+func (h *BlockHandler) BlockUser(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.blockService.BlockUser(c.Request().Context(), userID, c.Param("username")); err != nil {
+		switch err.Error() {
+		case "user not found":
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case "cannot block yourself":
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case "already blocked":
+			return utils.ErrorResponse(c, http.StatusConflict, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to block user")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UnblockUser ブロックを解除
+// @Summary ブロック解除
+// @Description 指定されたユーザーのブロックを解除します
+// @Tags ブロック・ミュート
+// @Accept json
+// @Produce json
+// @Param username path string true "ブロック解除するユーザーのユーザー名"
+// @Success 204 "ブロック解除成功"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません / ブロックしていません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /users/{username}/block [delete]
+func (h *BlockHandler) UnblockUser(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.blockService.UnblockUser(c.Request().Context(), userID, c.Param("username")); err != nil {
+		if err.Error() == "user not found" || err.Error() == "block not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unblock user")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// MuteUser ユーザーをミュート
+// @Summary ユーザーをミュート
+// @Description 指定されたユーザーの投稿・コメント・いいねを自分のフィードから非表示にします
+// @Tags ブロック・ミュート
+// @Accept json
+// @Produce json
+// @Param username path string true "ミュートするユーザーのユーザー名"
+// @Success 204 "ミュート成功"
+// @Failure 400 {object} map[string]interface{} "自分自身をミュートすることはできません"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
+// @Failure 409 {object} map[string]interface{} "既にミュート済み"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /users/{username}/mute [post]
+func (h *BlockHandler) MuteUser(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.blockService.MuteUser(c.Request().Context(), userID, c.Param("username")); err != nil {
+		switch err.Error() {
+		case "user not found":
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case "cannot mute yourself":
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case "already muted":
+			return utils.ErrorResponse(c, http.StatusConflict, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to mute user")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UnmuteUser ミュートを解除
+// @Summary ミュート解除
+// @Description 指定されたユーザーのミュートを解除します
+// @Tags ブロック・ミュート
+// @Accept json
+// @Produce json
+// @Param username path string true "ミュート解除するユーザーのユーザー名"
+// @Success 204 "ミュート解除成功"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません / ミュートしていません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /users/{username}/mute [delete]
+func (h *BlockHandler) UnmuteUser(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.blockService.UnmuteUser(c.Request().Context(), userID, c.Param("username")); err != nil {
+		if err.Error() == "user not found" || err.Error() == "mute not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unmute user")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
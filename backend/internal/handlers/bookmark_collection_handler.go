@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// BookmarkCollectionRequest コレクション作成・名前変更リクエスト
+type BookmarkCollectionRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// MoveBookmarkRequest ブックマーク移動リクエスト
+type MoveBookmarkRequest struct {
+	CollectionID uint `json:"collection_id" validate:"required"`
+}
+
+// GetCollections コレクション一覧取得
+// @Summary ブックマークコレクション一覧取得
+// @Description 自分のコレクションをブックマーク数とともに取得します（既定のコレクションが先頭）
+// @Tags bookmarks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "data: []BookmarkCollection"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/collections [get]
+func (h *BookmarkHandler) GetCollections(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	collections, err := h.bookmarkService.ListCollections(c.Request().Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get bookmark collections")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, collections)
+}
+
+// CreateCollection コレクション作成
+// @Summary ブックマークコレクション作成
+// @Description 名前を指定してコレクションを作成します（同じ名前のコレクションは作成できません）
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BookmarkCollectionRequest true "コレクション名"
+// @Success 201 {object} map[string]interface{} "data: BookmarkCollection"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "同じ名前のコレクションが存在します"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/collections [post]
+func (h *BookmarkHandler) CreateCollection(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req BookmarkCollectionRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	collection, err := h.bookmarkService.CreateCollection(c.Request().Context(), userID, utils.SanitizeText(req.Name))
+	if err != nil {
+		return collectionErrorResponse(c, err, "Failed to create bookmark collection")
+	}
+
+	return utils.SuccessResponse(c, http.StatusCreated, collection)
+}
+
+// RenameCollection コレクション名変更
+// @Summary ブックマークコレクション名変更
+// @Description コレクションの名前を変更します（既定のコレクションは変更できません）
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "コレクションID"
+// @Param request body BookmarkCollectionRequest true "新しいコレクション名"
+// @Success 200 {object} map[string]interface{} "data: BookmarkCollection"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー・既定のコレクション"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "コレクションが見つかりません"
+// @Failure 409 {object} map[string]interface{} "同じ名前のコレクションが存在します"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/collections/{id} [put]
+func (h *BookmarkHandler) RenameCollection(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	collectionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid collection ID")
+	}
+
+	var req BookmarkCollectionRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	collection, err := h.bookmarkService.RenameCollection(c.Request().Context(), userID, uint(collectionID), utils.SanitizeText(req.Name))
+	if err != nil {
+		return collectionErrorResponse(c, err, "Failed to rename bookmark collection")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, collection)
+}
+
+// DeleteCollection コレクション削除
+// @Summary ブックマークコレクション削除
+// @Description コレクションを削除します（含まれていたブックマークは既定のコレクションへ移動します）
+// @Tags bookmarks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "コレクションID"
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "無効なID・既定のコレクション"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "コレクションが見つかりません"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/collections/{id} [delete]
+func (h *BookmarkHandler) DeleteCollection(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	collectionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid collection ID")
+	}
+
+	if err := h.bookmarkService.DeleteCollection(c.Request().Context(), userID, uint(collectionID)); err != nil {
+		return collectionErrorResponse(c, err, "Failed to delete bookmark collection")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Bookmark collection deleted successfully",
+	})
+}
+
+// MoveBookmark ブックマークをコレクションへ移動
+// @Summary ブックマークの移動
+// @Description ブックマークを指定したコレクションへ移動します
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Param request body MoveBookmarkRequest true "移動先のコレクション"
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ブックマーク・コレクションが見つかりません"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /posts/{id}/bookmark [put]
+func (h *BookmarkHandler) MoveBookmark(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	var req MoveBookmarkRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.bookmarkService.MoveBookmark(c.Request().Context(), userID, uint(postID), req.CollectionID); err != nil {
+		if err.Error() == "bookmark not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Bookmark not found")
+		}
+		return collectionErrorResponse(c, err, "Failed to move bookmark")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Bookmark moved successfully",
+	})
+}
+
+// ShareCollection コレクションの公開リンク発行
+// @Summary ブックマークコレクションの公開リンク発行
+// @Description コレクションを公開リンク（share_token）で共有します（発行済みの場合は同じリンクを返します）
+// @Tags bookmarks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "コレクションID"
+// @Success 200 {object} map[string]interface{} "data: BookmarkCollection"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "コレクションが見つかりません"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/collections/{id}/share [post]
+func (h *BookmarkHandler) ShareCollection(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	collectionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid collection ID")
+	}
+
+	collection, err := h.bookmarkService.ShareCollection(c.Request().Context(), userID, uint(collectionID))
+	if err != nil {
+		return collectionErrorResponse(c, err, "Failed to share bookmark collection")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, collection)
+}
+
+// UnshareCollection コレクションの公開リンク無効化
+// @Summary ブックマークコレクションの公開リンク無効化
+// @Description 発行済みの公開リンクを無効化します
+// @Tags bookmarks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "コレクションID"
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "コレクションが見つかりません"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/collections/{id}/share [delete]
+func (h *BookmarkHandler) UnshareCollection(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	collectionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid collection ID")
+	}
+
+	if err := h.bookmarkService.UnshareCollection(c.Request().Context(), userID, uint(collectionID)); err != nil {
+		return collectionErrorResponse(c, err, "Failed to unshare bookmark collection")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Bookmark collection unshared successfully",
+	})
+}
+
+// GetSharedCollection 公開リンクからコレクション取得
+// @Summary 共有されたブックマークコレクション取得
+// @Description 公開リンクのトークンからコレクションと投稿を取得します（閲覧者が見られない投稿は含まれません）
+// @Tags bookmarks
+// @Produce json
+// @Param token path string true "公開リンク用のトークン"
+// @Param limit query int false "取得件数（デフォルト: 20）"
+// @Param cursor query string false "ページネーション用カーソル"
+// @Success 200 {object} map[string]interface{} "collection, owner, posts, pagination"
+// @Failure 404 {object} map[string]interface{} "コレクションが見つかりません（公開リンクの無効化を含む）"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /bookmarks/shared/{token} [get]
+func (h *BookmarkHandler) GetSharedCollection(c echo.Context) error {
+	// 認証は任意
+	var viewerID *uint
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		viewerID = &userID
+	}
+
+	limit := 20
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	var cursor *string
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor = &cursorStr
+	}
+
+	collection, owner, posts, hasMore, nextCursor, err := h.bookmarkService.GetSharedCollection(c.Request().Context(), c.Param("token"), viewerID, limit, cursor)
+	if err != nil {
+		if err.Error() == "collection not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Collection not found")
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get shared collection")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"collection": collection,
+		"owner":      owner.ToPublicUser(viewerID),
+		"posts":      posts,
+		"pagination": map[string]interface{}{
+			"has_more":    hasMore,
+			"next_cursor": nextCursor,
+		},
+	})
+}
+
+// collectionErrorResponse コレクション操作のエラーをHTTPステータスに変換
+func collectionErrorResponse(c echo.Context, err error, fallback string) error {
+	switch err.Error() {
+	case "collection not found":
+		return utils.ErrorResponse(c, http.StatusNotFound, "Collection not found")
+	case "collection name already exists":
+		return utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case "collection name is required", "collection name is too long", "cannot modify default collection":
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	return utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+}
@@ -107,12 +107,14 @@ func (h *BookmarkHandler) UnbookmarkPost(c echo.Context) error {
 // @Tags bookmarks
 // @Accept json
 // @Produce json
+// @Param collection query int false "コレクションID（省略時はすべてのコレクション）"
 // @Param limit query int false "取得件数（デフォルト: 20）"
 // @Param cursor query string false "ページネーション用カーソル"
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{} "Success"
+// @Success 200 {object} map[string]interface{} "posts, pagination, collections（コレクションごとのブックマーク数を含む）"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "コレクションが見つかりません"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /bookmarks [get]
 func (h *BookmarkHandler) GetBookmarks(c echo.Context) error {
@@ -135,17 +137,37 @@ func (h *BookmarkHandler) GetBookmarks(c echo.Context) error {
 		cursor = &cursorStr
 	}
 
+	var collectionID *uint
+	if collectionStr := c.QueryParam("collection"); collectionStr != "" {
+		parsedID, err := strconv.ParseUint(collectionStr, 10, 32)
+		if err != nil {
+			return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid collection ID")
+		}
+		id := uint(parsedID)
+		collectionID = &id
+	}
+
 	// ブックマーク一覧取得
-	posts, hasMore, nextCursor, err := h.bookmarkService.GetBookmarks(c.Request().Context(), userID, limit, cursor)
+	posts, hasMore, nextCursor, err := h.bookmarkService.GetBookmarks(c.Request().Context(), userID, collectionID, limit, cursor)
 	if err != nil {
+		if err.Error() == "collection not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Collection not found")
+		}
 		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get bookmarks")
 	}
 
+	// コレクションごとのブックマーク数
+	collections, err := h.bookmarkService.ListCollections(c.Request().Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get bookmark collections")
+	}
+
 	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
 		"posts": posts,
 		"pagination": map[string]interface{}{
 			"has_more":    hasMore,
 			"next_cursor": nextCursor,
 		},
+		"collections": collections,
 	})
 }
@@ -47,7 +47,13 @@ func GetComments(c echo.Context) error {
 		cursorPtr = &cursor
 	}
 
-	comments, hasMore, nextCursor, err := services.GetCommentsByPostID(uint(postID), limit, cursorPtr)
+	// 現在のユーザーID取得（任意、ブロック・ミュートの除外に使用）
+	var currentUserIDPtr *uint
+	if currentUserID, ok := c.Get("user_id").(uint); ok {
+		currentUserIDPtr = &currentUserID
+	}
+
+	comments, hasMore, nextCursor, err := services.GetCommentsByPostID(uint(postID), currentUserIDPtr, limit, cursorPtr)
 	if err != nil {
 		if err.Error() == "post not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
@@ -70,6 +76,7 @@ func GetComments(c echo.Context) error {
 // @Success 201 {object} map[string]interface{} "data: Comment"
 // @Failure 400 {object} map[string]interface{} "バリデーションエラー"
 // @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるためコメントできません"
 // @Failure 404 {object} map[string]interface{} "投稿が見つかりません"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /posts/{id}/comments [post]
@@ -102,12 +109,123 @@ func CreateComment(c echo.Context) error {
 		if err.Error() == "post not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
 		}
+		if err.Error() == "blocked" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to create comment")
 	}
 
 	return utils.SuccessResponse(c, 201, comment)
 }
 
+// GetReplies - 返信一覧取得ハンドラー
+// @Summary 返信一覧取得
+// @Description 指定されたコメントへの返信一覧を古い順に取得します
+// @Tags コメント
+// @Accept json
+// @Produce json
+// @Param id path int true "コメントID"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーションカーソル"
+// @Success 200 {object} map[string]interface{} "data: []Comment, pagination: {has_more, next_cursor, limit}"
+// @Failure 400 {object} map[string]interface{} "無効なコメントID"
+// @Failure 404 {object} map[string]interface{} "コメントが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Router /comments/{id}/replies [get]
+func GetReplies(c echo.Context) error {
+	commentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, 400, "Invalid comment ID")
+	}
+
+	limitStr := c.QueryParam("limit")
+	limit := 20
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	cursor := c.QueryParam("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	// 現在のユーザーID取得（任意、ブロック・ミュートの除外に使用）
+	var currentUserIDPtr *uint
+	if currentUserID, ok := c.Get("user_id").(uint); ok {
+		currentUserIDPtr = &currentUserID
+	}
+
+	replies, hasMore, nextCursor, err := services.GetRepliesByCommentID(uint(commentID), currentUserIDPtr, limit, cursorPtr)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			return utils.ErrorResponse(c, 404, err.Error())
+		}
+		return utils.ErrorResponse(c, 500, "Failed to get replies")
+	}
+
+	return utils.PaginationResponse(c, replies, hasMore, nextCursor, limit)
+}
+
+// CreateReply - 返信作成ハンドラー
+// @Summary 返信作成
+// @Description コメントに返信します（ネストの深さには上限があります）
+// @Tags コメント
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "返信先コメントID"
+// @Param request body CreateCommentRequest true "返信内容"
+// @Success 201 {object} map[string]interface{} "data: Comment"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー / ネストの深さ上限"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるため返信できません"
+// @Failure 404 {object} map[string]interface{} "コメントが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Router /comments/{id}/replies [post]
+func CreateReply(c echo.Context) error {
+	commentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, 400, "Invalid comment ID")
+	}
+
+	var req CreateCommentRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, 400, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, 400, err.Error())
+	}
+
+	// 安全な型アサーション
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, 401, "Unauthorized")
+	}
+
+	// XSS対策: コンテンツをサニタイズ
+	sanitizedContent := utils.SanitizeText(req.Content)
+
+	reply, err := services.CreateReply(userID, uint(commentID), sanitizedContent)
+	if err != nil {
+		if err.Error() == "comment not found" || err.Error() == "post not found" {
+			return utils.ErrorResponse(c, 404, err.Error())
+		}
+		if err.Error() == "reply depth limit exceeded" {
+			return utils.ErrorResponse(c, 400, err.Error())
+		}
+		if err.Error() == "blocked" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
+		return utils.ErrorResponse(c, 500, "Failed to create reply")
+	}
+
+	return utils.SuccessResponse(c, 201, reply)
+}
+
 // DeleteComment - コメント削除ハンドラー
 // @Summary コメント削除
 // @Description 自分のコメントを削除します（論理削除）
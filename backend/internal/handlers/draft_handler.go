@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// DraftHandler 下書き・予約投稿ハンドラー
+type DraftHandler struct {
+	scheduledPostService *services.ScheduledPostService
+}
+
+// NewDraftHandler DraftHandlerのコンストラクタ
+func NewDraftHandler() *DraftHandler {
+	return &DraftHandler{
+		scheduledPostService: services.NewScheduledPostService(),
+	}
+}
+
+// CreateDraftRequest 下書き・予約投稿作成リクエスト
+type CreateDraftRequest struct {
+	Content   string     `json:"content" form:"content" validate:"required,max=280"`
+	PublishAt *time.Time `json:"publish_at" form:"publish_at"` // 公開日時（RFC3339、省略時は下書き）
+	MediaIDs  []uint     `json:"media_ids" form:"media_ids"`   // 添付するアップロード済みの下書きメディアID（任意）
+}
+
+// UpdateDraftRequest 下書き・予約投稿更新リクエスト
+type UpdateDraftRequest struct {
+	Content   string     `json:"content" validate:"required,max=280"`
+	PublishAt *time.Time `json:"publish_at"` // 公開日時（省略時は下書きに戻す）
+}
+
+// CreateDraft 下書き・予約投稿作成
+// @Summary 下書き・予約投稿作成
+// @Description publish_atを指定すると予約投稿、省略すると下書きを作成します（公開までフィードには表示されません）
+// @Description multipart/form-dataでfilesを送信するか、media_idsで下書きメディアを指定するとメディア付き（合計最大4つ）
+// @Tags drafts
+// @Accept json,mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateDraftRequest true "投稿内容"
+// @Param files formData file false "メディアファイル（最大4つ）"
+// @Success 201 {object} map[string]interface{} "data: Post"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー・公開日時が過去"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /drafts [post]
+func (h *DraftHandler) CreateDraft(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req CreateDraftRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	// 添付メディア（ファイルはmultipart/form-dataの場合のみ）
+	media := services.PostMediaInput{MediaIDs: req.MediaIDs}
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			return utils.ErrorResponse(c, http.StatusBadRequest, "Failed to parse multipart form")
+		}
+		media.Files = form.File["files"]
+	}
+	if len(media.Files)+len(media.MediaIDs) > 4 {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Maximum 4 files allowed")
+	}
+
+	// XSS対策: コンテンツをサニタイズ
+	sanitizedContent := utils.SanitizeText(req.Content)
+
+	post, err := h.scheduledPostService.CreateDraft(c.Request().Context(), userID, sanitizedContent, req.PublishAt, media)
+	if err != nil {
+		var validationErr *services.MediaValidationError
+		if errors.As(err, &validationErr) {
+			return mediaValidationErrorResponse(c, validationErr)
+		}
+		if err.Error() == "media not found" {
+			return utils.ErrorResponse(c, http.StatusBadRequest, "Media not found or already attached")
+		}
+		if err.Error() == "publish_at must be in the future" {
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create draft")
+	}
+
+	return utils.SuccessResponse(c, http.StatusCreated, post)
+}
+
+// GetDrafts 下書き・予約投稿一覧取得
+// @Summary 下書き・予約投稿一覧取得
+// @Description 自分の下書き・予約投稿を取得します（予約投稿を公開日時の早い順、続けて下書きを新しい順）
+// @Tags drafts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "data: []Post"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /drafts [get]
+func (h *DraftHandler) GetDrafts(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	posts, err := h.scheduledPostService.ListDrafts(c.Request().Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get drafts")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, posts)
+}
+
+// GetDraft 下書き・予約投稿取得
+// @Summary 下書き・予約投稿取得
+// @Description 自分の下書き・予約投稿を取得します
+// @Tags drafts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Success 200 {object} map[string]interface{} "data: Post"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /drafts/{id} [get]
+func (h *DraftHandler) GetDraft(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	post, err := h.scheduledPostService.GetDraft(c.Request().Context(), userID, uint(postID))
+	if err != nil {
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Draft not found")
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get draft")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, post)
+}
+
+// UpdateDraft 下書き・予約投稿更新
+// @Summary 下書き・予約投稿更新
+// @Description 本文と公開日時を更新します（publish_atを省略すると下書きに戻します）
+// @Tags drafts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Param request body UpdateDraftRequest true "更新内容"
+// @Success 200 {object} map[string]interface{} "data: Post"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー・公開日時が過去"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "下書きが見つかりません（公開済みを含む）"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /drafts/{id} [put]
+func (h *DraftHandler) UpdateDraft(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	var req UpdateDraftRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	// XSS対策: コンテンツをサニタイズ
+	sanitizedContent := utils.SanitizeText(req.Content)
+
+	post, err := h.scheduledPostService.UpdateDraft(c.Request().Context(), userID, uint(postID), sanitizedContent, req.PublishAt)
+	if err != nil {
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Draft not found")
+		}
+		if err.Error() == "publish_at must be in the future" {
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update draft")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, post)
+}
+
+// CancelDraft 下書き・予約投稿取り消し
+// @Summary 下書き・予約投稿取り消し
+// @Description 下書き・予約投稿を削除します（添付メディアも削除されます）
+// @Tags drafts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "下書きが見つかりません（公開済みを含む）"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /drafts/{id} [delete]
+func (h *DraftHandler) CancelDraft(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	if err := h.scheduledPostService.CancelDraft(c.Request().Context(), userID, uint(postID)); err != nil {
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Draft not found")
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel draft")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Draft cancelled successfully",
+	})
+}
+
+// PublishDraft 下書き・予約投稿の即時公開
+// @Summary 下書き・予約投稿の即時公開
+// @Description 下書き・予約投稿をすぐに公開します
+// @Tags drafts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Success 200 {object} map[string]interface{} "data: Post"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "下書きが見つかりません（公開済みを含む）"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /drafts/{id}/publish [post]
+func (h *DraftHandler) PublishDraft(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	post, err := h.scheduledPostService.PublishDraft(c.Request().Context(), userID, uint(postID))
+	if err != nil {
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Draft not found")
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to publish draft")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, post)
+}
@@ -8,36 +8,49 @@ import (
 
 // FollowUser - フォローハンドラー
 // @Summary ユーザーをフォロー
-// @Description 指定されたユーザーをフォローします
+// @Description 指定されたユーザーをフォローします（鍵アカウントの場合はフォローリクエストを送信します）
 // @Tags フォロー
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param username path string true "フォローするユーザーのユーザー名"
 // @Success 204 "フォロー成功"
+// @Success 202 {object} map[string]interface{} "フォローリクエスト送信（鍵アカウント）"
 // @Failure 400 {object} map[string]interface{} "自分自身をフォローすることはできません"
 // @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるためフォローできません"
 // @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
-// @Failure 409 {object} map[string]interface{} "既にフォロー済み"
+// @Failure 409 {object} map[string]interface{} "既にフォロー済み / フォローリクエスト送信済み"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /users/{username}/follow [post]
 func FollowUser(c echo.Context) error {
 	username := c.Param("username")
 	userID := c.Get("user_id").(uint)
 
-	if err := services.FollowUser(userID, username); err != nil {
+	requested, err := services.FollowUserOrRequest(userID, username)
+	if err != nil {
 		if err.Error() == "user not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
 		}
 		if err.Error() == "cannot follow yourself" {
 			return utils.ErrorResponse(c, 400, err.Error())
 		}
-		if err.Error() == "already following" {
+		if err.Error() == "already following" || err.Error() == "follow request already sent" {
 			return utils.ErrorResponse(c, 409, err.Error())
 		}
+		if err.Error() == "blocked" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to follow user")
 	}
 
+	// 鍵アカウントの場合は承認待ち
+	if requested {
+		return utils.SuccessResponse(c, 202, map[string]interface{}{
+			"follow_requested": true,
+		})
+	}
+
 	return c.NoContent(204)
 }
 
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// FollowRequestHandler フォローリクエストハンドラー（鍵アカウント用）
+type FollowRequestHandler struct {
+	followRequestService *services.FollowRequestService
+}
+
+// NewFollowRequestHandler FollowRequestHandlerのコンストラクタ
+func NewFollowRequestHandler() *FollowRequestHandler {
+	return &FollowRequestHandler{
+		followRequestService: services.NewFollowRequestService(),
+	}
+}
+
+// GetFollowRequests 自分宛ての保留中フォローリクエスト一覧を取得
+// @Summary フォローリクエスト一覧取得
+// @Description 自分宛ての保留中フォローリクエストを新しい順に取得します
+// @Tags フォロー
+// @Accept json
+// @Produce json
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーションカーソル"
+// @Success 200 {object} map[string]interface{} "data: []FollowRequest, pagination: {has_more, next_cursor, limit}"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /follow-requests [get]
+func (h *FollowRequestHandler) GetFollowRequests(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	var cursor *string
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor = &cursorStr
+	}
+
+	requests, hasMore, nextCursor, err := h.followRequestService.GetPendingRequests(c.Request().Context(), userID, limit, cursor)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get follow requests")
+	}
+
+	return utils.PaginationResponse(c, requests, hasMore, nextCursor, limit)
+}
+
+// ApproveFollowRequest フォローリクエストを承認
+// @Summary フォローリクエスト承認
+// @Description 自分宛てのフォローリクエストを承認し、フォロー関係を作成します
+// @Tags フォロー
+// @Accept json
+// @Produce json
+// @Param id path int true "フォローリクエストID"
+// @Success 204 "承認成功"
+// @Failure 400 {object} map[string]interface{} "不正なリクエストID"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "フォローリクエストが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /follow-requests/{id}/approve [post]
+func (h *FollowRequestHandler) ApproveFollowRequest(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	requestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid follow request ID")
+	}
+
+	if err := h.followRequestService.ApproveRequest(c.Request().Context(), userID, uint(requestID)); err != nil {
+		if err.Error() == "follow request not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to approve follow request")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RejectFollowRequest フォローリクエストを拒否
+// @Summary フォローリクエスト拒否
+// @Description 自分宛てのフォローリクエストを拒否します
+// @Tags フォロー
+// @Accept json
+// @Produce json
+// @Param id path int true "フォローリクエストID"
+// @Success 204 "拒否成功"
+// @Failure 400 {object} map[string]interface{} "不正なリクエストID"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "フォローリクエストが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /follow-requests/{id}/reject [post]
+func (h *FollowRequestHandler) RejectFollowRequest(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	requestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid follow request ID")
+	}
+
+	if err := h.followRequestService.RejectRequest(c.Request().Context(), userID, uint(requestID)); err != nil {
+		if err.Error() == "follow request not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to reject follow request")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
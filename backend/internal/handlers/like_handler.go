@@ -19,6 +19,7 @@ import (
 // @Success 204 "いいね成功"
 // @Failure 400 {object} map[string]interface{} "無効な投稿ID"
 // @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるためいいねできません"
 // @Failure 404 {object} map[string]interface{} "投稿が見つかりません"
 // @Failure 409 {object} map[string]interface{} "既にいいね済み"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
@@ -38,6 +39,9 @@ func LikePost(c echo.Context) error {
 		if err.Error() == "already liked" {
 			return utils.ErrorResponse(c, 409, err.Error())
 		}
+		if err.Error() == "blocked" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to like post")
 	}
 
@@ -110,7 +114,13 @@ func GetLikes(c echo.Context) error {
 		cursorPtr = &cursor
 	}
 
-	users, hasMore, nextCursor, err := services.GetLikesByPostID(uint(postID), limit, cursorPtr)
+	// 現在のユーザーID取得（任意、ブロック・ミュートの除外に使用）
+	var currentUserIDPtr *uint
+	if currentUserID, ok := c.Get("user_id").(uint); ok {
+		currentUserIDPtr = &currentUserID
+	}
+
+	users, hasMore, nextCursor, err := services.GetLikesByPostID(uint(postID), currentUserIDPtr, limit, cursorPtr)
 	if err != nil {
 		if err.Error() == "post not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
@@ -1,38 +1,52 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/models"
 	"github.com/yourusername/sns-backend/internal/services"
 	"github.com/yourusername/sns-backend/internal/utils"
 )
 
 // MediaHandler メディアハンドラー
 type MediaHandler struct {
-	mediaService *services.MediaService
+	mediaService         *services.MediaService
+	uploadSessionService *services.UploadSessionService
+}
+
+// CreateUploadSessionRequest 分割アップロード開始リクエスト
+type CreateUploadSessionRequest struct {
+	Filename string `json:"filename" validate:"required,max=255"`
+	Size     int64  `json:"size" validate:"required,min=1"`
+	PostID   *uint  `json:"post_id"` // 完了時に紐付ける投稿ID（省略時は下書き）
 }
 
 // NewMediaHandler MediaHandlerのコンストラクタ
 func NewMediaHandler() *MediaHandler {
 	return &MediaHandler{
-		mediaService: services.NewMediaService(),
+		mediaService:         services.NewMediaService(),
+		uploadSessionService: services.NewUploadSessionService(),
 	}
 }
 
 // UploadMedia メディアをアップロード
 // @Summary メディアアップロード
 // @Description 投稿に画像/動画/音声をアップロード（最大4ファイル）
+// @Description post_idを省略すると下書きメディアとして保存し、投稿作成時にmedia_idsで紐付ける
 // @Tags media
 // @Accept multipart/form-data
 // @Produce json
-// @Param post_id formData int true "投稿ID"
+// @Param post_id formData int false "投稿ID（省略時は下書き）"
 // @Param files formData file true "メディアファイル（最大4つ）"
 // @Security BearerAuth
 // @Success 200 {object} map[string]interface{} "Success"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 413 {object} map[string]interface{} "File too large (code: file_too_large)"
+// @Failure 415 {object} map[string]interface{} "Unsupported or mismatched content (code: unsupported_format, unrecognized_content, content_mismatch)"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /media/upload [post]
 func (h *MediaHandler) UploadMedia(c echo.Context) error {
@@ -42,23 +56,26 @@ func (h *MediaHandler) UploadMedia(c echo.Context) error {
 		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
 	}
 
-	// 投稿ID取得
-	postIDStr := c.FormValue("post_id")
-	postIDUint64, err := strconv.ParseUint(postIDStr, 10, 32)
-	if err != nil {
-		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
-	}
-	postID := uint(postIDUint64)
+	// 投稿ID取得（省略時は下書きとしてアップロード）
+	var postID *uint
+	if postIDStr := c.FormValue("post_id"); postIDStr != "" {
+		postIDUint64, err := strconv.ParseUint(postIDStr, 10, 32)
+		if err != nil {
+			return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+		}
+		id := uint(postIDUint64)
+		postID = &id
 
-	// 投稿の所有者確認
-	postService := services.NewPostService()
-	post, err := postService.GetPostByID(c.Request().Context(), postID, &userID)
-	if err != nil {
-		return utils.ErrorResponse(c, http.StatusNotFound, "Post not found")
-	}
+		// 投稿の所有者確認
+		postService := services.NewPostService()
+		post, err := postService.GetPostByID(c.Request().Context(), id, &userID)
+		if err != nil {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Post not found")
+		}
 
-	if post.UserID != userID {
-		return utils.ErrorResponse(c, http.StatusForbidden, "You can only upload media to your own posts")
+		if post.UserID != userID {
+			return utils.ErrorResponse(c, http.StatusForbidden, "You can only upload media to your own posts")
+		}
 	}
 
 	// ファイル取得（複数）
@@ -77,8 +94,17 @@ func (h *MediaHandler) UploadMedia(c echo.Context) error {
 	}
 
 	// メディアアップロード
-	mediaList, err := h.mediaService.UploadMultipleMedia(c.Request().Context(), files, postID)
+	var mediaList []models.Media
+	if postID != nil {
+		mediaList, err = h.mediaService.UploadMultipleMedia(c.Request().Context(), files, *postID)
+	} else {
+		mediaList, err = h.mediaService.UploadDraftMedia(c.Request().Context(), userID, files)
+	}
 	if err != nil {
+		var validationErr *services.MediaValidationError
+		if errors.As(err, &validationErr) {
+			return mediaValidationErrorResponse(c, validationErr)
+		}
 		// エラーをログに記録（内部詳細は含まない）
 		c.Logger().Error(err)
 		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to upload media")
@@ -92,7 +118,7 @@ func (h *MediaHandler) UploadMedia(c echo.Context) error {
 
 // DeleteMedia メディアを削除
 // @Summary メディア削除
-// @Description メディアを削除
+// @Description 自分の投稿・メッセージ・下書きのメディアを削除（管理者は他人のメディアも削除可能）
 // @Tags media
 // @Accept json
 // @Produce json
@@ -101,12 +127,13 @@ func (h *MediaHandler) UploadMedia(c echo.Context) error {
 // @Success 200 {object} map[string]interface{} "Success"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
 // @Failure 404 {object} map[string]interface{} "Not Found"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /media/{id} [delete]
 func (h *MediaHandler) DeleteMedia(c echo.Context) error {
 	// 認証済みユーザーID取得
-	_, err := utils.GetUserIDFromContext(c)
+	userID, err := utils.GetUserIDFromContext(c)
 	if err != nil {
 		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
 	}
@@ -120,10 +147,13 @@ func (h *MediaHandler) DeleteMedia(c echo.Context) error {
 	mediaID := uint(mediaIDUint64)
 
 	// メディア削除
-	if err := h.mediaService.DeleteMedia(c.Request().Context(), mediaID); err != nil {
+	if err := h.mediaService.DeleteMedia(c.Request().Context(), mediaID, userID); err != nil {
 		if err.Error() == "media not found" {
 			return utils.ErrorResponse(c, http.StatusNotFound, "Media not found")
 		}
+		if err.Error() == "unauthorized" {
+			return utils.ErrorResponse(c, http.StatusForbidden, "You can only delete your own media")
+		}
 		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete media")
 	}
 
@@ -131,3 +161,251 @@ func (h *MediaHandler) DeleteMedia(c echo.Context) error {
 		"message": "Media deleted successfully",
 	})
 }
+
+// CreateUploadSession 分割アップロードを開始
+// @Summary 分割アップロード開始
+// @Description 再開可能な分割アップロードのセッションを作成します。PATCHでチャンクを送信し、completeで完了します
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param request body CreateUploadSessionRequest true "ファイル名・サイズ・紐付ける投稿ID"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "data: UploadSession（offsetは受信済みバイト数、chunk_sizeは1回の最大サイズ）"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Post not found"
+// @Failure 413 {object} map[string]interface{} "File too large (code: file_too_large)"
+// @Failure 415 {object} map[string]interface{} "Unsupported format (code: unsupported_format)"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /media/uploads [post]
+func (h *MediaHandler) CreateUploadSession(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req CreateUploadSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	session, err := h.uploadSessionService.CreateSession(c.Request().Context(), userID, req.Filename, req.Size, req.PostID)
+	if err != nil {
+		return uploadSessionErrorResponse(c, err)
+	}
+
+	return utils.SuccessResponse(c, http.StatusCreated, uploadSessionResponse(session))
+}
+
+// GetUploadSession 分割アップロードの状態を取得
+// @Summary 分割アップロードの状態取得
+// @Description 受信済みのオフセットを返します（切断後の再開に使用）
+// @Tags media
+// @Produce json
+// @Param id path int true "セッションID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "data: UploadSession"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Upload session not found"
+// @Router /media/uploads/{id} [get]
+func (h *MediaHandler) GetUploadSession(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid upload session ID")
+	}
+
+	session, err := h.uploadSessionService.GetSession(c.Request().Context(), userID, uint(sessionID))
+	if err != nil {
+		return uploadSessionErrorResponse(c, err)
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, uploadSessionResponse(session))
+}
+
+// UploadChunk チャンクを送信
+// @Summary チャンク送信
+// @Description リクエストボディ（application/offset+octet-stream）のバイト列をUpload-Offsetの位置に追記します
+// @Description Upload-Offsetが受信済みのバイト数と一致しない場合は409と現在のオフセットを返します
+// @Tags media
+// @Accept octet-stream
+// @Produce json
+// @Param id path int true "セッションID"
+// @Param Upload-Offset header int true "チャンクの開始位置"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "data: UploadSession"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Upload session not found"
+// @Failure 409 {object} map[string]interface{} "Offset mismatch (code: offset_mismatch)"
+// @Failure 413 {object} map[string]interface{} "Chunk too large (code: chunk_too_large)"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /media/uploads/{id} [patch]
+func (h *MediaHandler) UploadChunk(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid upload session ID")
+	}
+
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid Upload-Offset header")
+	}
+
+	session, err := h.uploadSessionService.AppendChunk(c.Request().Context(), userID, uint(sessionID), offset, c.Request().Body)
+	if err != nil {
+		if err.Error() == "offset mismatch" && session != nil {
+			// クライアントが正しい位置から再送できるよう現在のオフセットを返す
+			c.Response().Header().Set("Upload-Offset", strconv.FormatInt(session.ReceivedSize, 10))
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"error": map[string]interface{}{
+					"code":    "offset_mismatch",
+					"message": "Upload-Offset does not match received size",
+					"offset":  session.ReceivedSize,
+				},
+			})
+		}
+		return uploadSessionErrorResponse(c, err)
+	}
+
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(session.ReceivedSize, 10))
+	return utils.SuccessResponse(c, http.StatusOK, uploadSessionResponse(session))
+}
+
+// CompleteUploadSession 分割アップロードを完了
+// @Summary 分割アップロード完了
+// @Description 受信したファイルを検証してストレージに保存し、メディアを作成します（再送時は作成済みのメディアを返します）
+// @Tags media
+// @Produce json
+// @Param id path int true "セッションID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "data: Media"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Upload session not found"
+// @Failure 409 {object} map[string]interface{} "Upload incomplete (code: upload_incomplete)"
+// @Failure 413 {object} map[string]interface{} "File too large (code: file_too_large)"
+// @Failure 415 {object} map[string]interface{} "Unsupported or mismatched content"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /media/uploads/{id}/complete [post]
+func (h *MediaHandler) CompleteUploadSession(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid upload session ID")
+	}
+
+	media, err := h.uploadSessionService.CompleteSession(c.Request().Context(), userID, uint(sessionID))
+	if err != nil {
+		return uploadSessionErrorResponse(c, err)
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, media)
+}
+
+// CancelUploadSession 分割アップロードを中止
+// @Summary 分割アップロード中止
+// @Description セッションと受信済みのデータを削除します
+// @Tags media
+// @Produce json
+// @Param id path int true "セッションID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Upload session not found"
+// @Router /media/uploads/{id} [delete]
+func (h *MediaHandler) CancelUploadSession(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid upload session ID")
+	}
+
+	if err := h.uploadSessionService.CancelSession(c.Request().Context(), userID, uint(sessionID)); err != nil {
+		return uploadSessionErrorResponse(c, err)
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Upload cancelled successfully",
+	})
+}
+
+// uploadSessionResponse セッションにチャンクの最大サイズを付けて返す
+func uploadSessionResponse(session *models.UploadSession) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         session.ID,
+		"post_id":    session.PostID,
+		"filename":   session.Filename,
+		"total_size": session.TotalSize,
+		"offset":     session.ReceivedSize,
+		"status":     session.Status,
+		"media_id":   session.MediaID,
+		"expires_at": session.ExpiresAt,
+		"chunk_size": services.UploadChunkMaxSize,
+	}
+}
+
+// uploadSessionErrorResponse 分割アップロードのエラーをステータスコードに変換
+func uploadSessionErrorResponse(c echo.Context, err error) error {
+	var validationErr *services.MediaValidationError
+	if errors.As(err, &validationErr) {
+		return mediaValidationErrorResponse(c, validationErr)
+	}
+
+	switch err.Error() {
+	case "upload session not found":
+		return utils.ErrorResponse(c, http.StatusNotFound, "Upload session not found")
+	case "post not found":
+		return utils.ErrorResponse(c, http.StatusNotFound, "Post not found")
+	case "forbidden":
+		return utils.ErrorResponse(c, http.StatusForbidden, "You can only upload media to your own posts")
+	case "invalid file size", "empty chunk", "maximum 4 media files allowed":
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case "chunk too large":
+		return utils.ErrorResponseWithCode(c, http.StatusRequestEntityTooLarge, "chunk_too_large", "Chunk exceeds the maximum chunk size or the remaining file size")
+	case "upload incomplete":
+		return utils.ErrorResponseWithCode(c, http.StatusConflict, "upload_incomplete", "Upload is not complete")
+	case "upload already completed":
+		return utils.ErrorResponseWithCode(c, http.StatusConflict, "upload_completed", "Upload is already completed")
+	}
+
+	// エラーをログに記録（内部詳細は含まない）
+	c.Logger().Error(err)
+	return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to process upload")
+}
+
+// mediaValidationErrorResponse メディア検証エラーを理由ごとのエラーコード付きで返す
+func mediaValidationErrorResponse(c echo.Context, err *services.MediaValidationError) error {
+	status := http.StatusBadRequest
+	switch err.Code {
+	case services.MediaErrorFileTooLarge:
+		status = http.StatusRequestEntityTooLarge
+	case services.MediaErrorUnsupportedFormat, services.MediaErrorUnrecognizedContent, services.MediaErrorContentMismatch:
+		status = http.StatusUnsupportedMediaType
+	}
+	return utils.ErrorResponseWithCode(c, status, err.Code, err.Message)
+}
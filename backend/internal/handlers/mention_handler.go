@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// MentionHandler メンションハンドラー
+type MentionHandler struct {
+	mentionService *services.MentionService
+}
+
+// NewMentionHandler MentionHandlerのコンストラクタ
+func NewMentionHandler() *MentionHandler {
+	return &MentionHandler{
+		mentionService: services.NewMentionService(),
+	}
+}
+
+// GetMentionedPosts ユーザーがメンションされた投稿一覧
+// @Summary メンション投稿一覧取得
+// @Description 指定されたユーザーをメンションしている投稿一覧を取得します
+// @Tags ユーザー
+// @Accept json
+// @Produce json
+// @Param username path string true "ユーザー名"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーションカーソル"
+// @Success 200 {object} map[string]interface{} "data: []Post, pagination: {has_more, next_cursor, limit}"
+// @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /users/{username}/mentions [get]
+func (h *MentionHandler) GetMentionedPosts(c echo.Context) error {
+	username := c.Param("username")
+
+	limit := 20
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	cursor := c.QueryParam("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	// 現在のユーザーID取得（任意）
+	var userIDPtr *uint
+	if userID, ok := c.Get("user_id").(uint); ok {
+		userIDPtr = &userID
+	}
+
+	posts, hasMore, nextCursor, err := h.mentionService.GetMentionedPosts(c.Request().Context(), username, userIDPtr, limit, cursorPtr)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get mentioned posts")
+	}
+
+	return utils.PaginationResponse(c, posts, hasMore, nextCursor, limit)
+}
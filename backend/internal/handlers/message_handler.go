@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// CreateConversationRequest - 会話開始リクエスト
+type CreateConversationRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+// SendMessageRequest - メッセージ送信リクエスト（multipart/form-dataの場合はfilesでメディアを添付）
+type SendMessageRequest struct {
+	Content string `json:"content" form:"content"`
+}
+
+// MessageHandler ダイレクトメッセージハンドラー
+type MessageHandler struct {
+	messageService *services.MessageService
+}
+
+// NewMessageHandler MessageHandlerのコンストラクタ
+func NewMessageHandler() *MessageHandler {
+	return &MessageHandler{
+		messageService: services.NewMessageService(),
+	}
+}
+
+// GetConversations 会話一覧を取得
+// @Summary 会話一覧取得
+// @Description 参加している会話を最新メッセージの新しい順に取得します（最新メッセージ・未読数付き）
+// @Tags ダイレクトメッセージ
+// @Accept json
+// @Produce json
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーションカーソル"
+// @Success 200 {object} map[string]interface{} "data: []Conversation, pagination: {has_more, next_cursor, limit}"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /conversations [get]
+func (h *MessageHandler) GetConversations(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	limit, cursor := parseMessagePagination(c)
+
+	conversations, hasMore, nextCursor, err := h.messageService.GetConversations(c.Request().Context(), userID, limit, cursor)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get conversations")
+	}
+
+	return utils.PaginationResponse(c, conversations, hasMore, nextCursor, limit)
+}
+
+// CreateConversation 1対1の会話を開始（既存の会話があればそれを返す）
+// @Summary 会話開始
+// @Description 指定ユーザーとの1対1の会話を取得または作成します
+// @Tags ダイレクトメッセージ
+// @Accept json
+// @Produce json
+// @Param request body CreateConversationRequest true "相手のユーザー名"
+// @Success 200 {object} map[string]interface{} "data: Conversation"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー / 自分自身とは会話できません"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるため会話できません"
+// @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /conversations [post]
+func (h *MessageHandler) CreateConversation(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req CreateConversationRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	conversation, err := h.messageService.GetOrCreateConversation(c.Request().Context(), userID, req.Username)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case "cannot message yourself":
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case "blocked":
+			return utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create conversation")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, conversation)
+}
+
+// GetMessages メッセージ履歴を取得
+// @Summary メッセージ履歴取得
+// @Description 会話のメッセージを新しい順に取得します
+// @Tags ダイレクトメッセージ
+// @Accept json
+// @Produce json
+// @Param id path int true "会話ID"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーションカーソル"
+// @Success 200 {object} map[string]interface{} "data: []Message, pagination: {has_more, next_cursor, limit}"
+// @Failure 400 {object} map[string]interface{} "不正な会話ID"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "会話が見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /conversations/{id}/messages [get]
+func (h *MessageHandler) GetMessages(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+	}
+
+	limit, cursor := parseMessagePagination(c)
+
+	messages, hasMore, nextCursor, err := h.messageService.GetMessages(c.Request().Context(), userID, uint(conversationID), limit, cursor)
+	if err != nil {
+		if err.Error() == "conversation not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get messages")
+	}
+
+	return utils.PaginationResponse(c, messages, hasMore, nextCursor, limit)
+}
+
+// SendMessage メッセージを送信
+// @Summary メッセージ送信
+// @Description 会話にメッセージを送信します（multipart/form-dataの場合はfilesで最大4つのメディアを添付可能）
+// @Tags ダイレクトメッセージ
+// @Accept json,mpfd
+// @Produce json
+// @Param id path int true "会話ID"
+// @Param content formData string false "本文（メディア添付時は省略可）"
+// @Param files formData file false "メディアファイル（最大4つ）"
+// @Success 201 {object} map[string]interface{} "data: Message"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるため送信できません"
+// @Failure 404 {object} map[string]interface{} "会話が見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /conversations/{id}/messages [post]
+func (h *MessageHandler) SendMessage(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+	}
+
+	var req SendMessageRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	// 添付ファイル（multipart/form-dataの場合のみ）
+	var files []*multipart.FileHeader
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			return utils.ErrorResponse(c, http.StatusBadRequest, "Failed to parse multipart form")
+		}
+		files = form.File["files"]
+	}
+
+	message, err := h.messageService.SendMessage(c.Request().Context(), userID, uint(conversationID), req.Content, files)
+	if err != nil {
+		var validationErr *services.MediaValidationError
+		if errors.As(err, &validationErr) {
+			return mediaValidationErrorResponse(c, validationErr)
+		}
+		switch {
+		case err.Error() == "conversation not found":
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case err.Error() == "blocked":
+			return utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, utils.ErrEmptyContent),
+			strings.HasPrefix(err.Error(), "message content is too long"),
+			err.Error() == "maximum 4 media files allowed":
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		c.Logger().Error(err)
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to send message")
+	}
+
+	return utils.SuccessResponse(c, http.StatusCreated, message)
+}
+
+// MarkConversationAsRead 会話を既読にする
+// @Summary 会話を既読
+// @Description 会話の最新メッセージまでを既読にします
+// @Tags ダイレクトメッセージ
+// @Accept json
+// @Produce json
+// @Param id path int true "会話ID"
+// @Success 204 "既読成功"
+// @Failure 400 {object} map[string]interface{} "不正な会話ID"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "会話が見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /conversations/{id}/read [put]
+func (h *MessageHandler) MarkConversationAsRead(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+	}
+
+	if err := h.messageService.MarkAsRead(c.Request().Context(), userID, uint(conversationID)); err != nil {
+		if err.Error() == "conversation not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark conversation as read")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseMessagePagination limit・cursorクエリパラメータを取得
+func parseMessagePagination(c echo.Context) (int, *string) {
+	limit := 20
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	var cursor *string
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor = &cursorStr
+	}
+
+	return limit, cursor
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// NotificationHandler 通知ハンドラー
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler NotificationHandlerのコンストラクタ
+func NewNotificationHandler() *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: services.NewNotificationService(),
+	}
+}
+
+// GetNotifications 通知一覧取得
+// @Summary 通知一覧取得
+// @Description いいね・フォロー・コメント・メンションの通知を取得（同種の通知はまとめて返す）
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーション用カーソル"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "notifications, unread_count, pagination"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c echo.Context) error {
+	// 認証済みユーザーID取得
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	// クエリパラメータ取得
+	limit := 20
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
+			limit = parsedLimit
+		}
+	}
+
+	var cursor *string
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor = &cursorStr
+	}
+
+	ctx := c.Request().Context()
+	notifications, hasMore, nextCursor, err := h.notificationService.GetNotifications(ctx, userID, limit, cursor)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get notifications")
+	}
+
+	unreadCount, err := h.notificationService.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get unread count")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"notifications": notifications,
+		"unread_count":  unreadCount,
+		"pagination": map[string]interface{}{
+			"has_more":    hasMore,
+			"next_cursor": nextCursor,
+			"limit":       limit,
+		},
+	})
+}
+
+// GetUnreadCount 未読通知数取得
+// @Summary 未読通知数取得
+// @Description 未読の通知数を取得（まとめられた通知は1件として数える）
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "unread_count"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c echo.Context) error {
+	// 認証済みユーザーID取得
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	unreadCount, err := h.notificationService.GetUnreadCount(c.Request().Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get unread count")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"unread_count": unreadCount,
+	})
+}
+
+// MarkAsRead 通知を既読にする
+// @Summary 通知既読化
+// @Description 通知を既読にする（同じグループの通知もまとめて既読になる）
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "通知ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /notifications/{id}/read [put]
+func (h *NotificationHandler) MarkAsRead(c echo.Context) error {
+	// 認証済みユーザーID取得
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	// 通知ID取得
+	notificationIDUint64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid notification ID")
+	}
+
+	if err := h.notificationService.MarkAsRead(c.Request().Context(), userID, uint(notificationIDUint64)); err != nil {
+		if err.Error() == "notification not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, "Notification not found")
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark notification as read")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Notification marked as read",
+	})
+}
+
+// MarkAllAsRead すべての通知を既読にする
+// @Summary 全通知既読化
+// @Description すべての通知を既読にする
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /notifications/read-all [put]
+func (h *NotificationHandler) MarkAllAsRead(c echo.Context) error {
+	// 認証済みユーザーID取得
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.notificationService.MarkAllAsRead(c.Request().Context(), userID); err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark notifications as read")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "All notifications marked as read",
+	})
+}
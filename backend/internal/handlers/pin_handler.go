@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// PinHandler 投稿固定ハンドラー
+type PinHandler struct {
+	pinService *services.PinService
+}
+
+// NewPinHandler PinHandlerのコンストラクタ
+func NewPinHandler() *PinHandler {
+	return &PinHandler{
+		pinService: services.NewPinService(),
+	}
+}
+
+// PinPost 投稿をプロフィールに固定
+// @Summary 投稿の固定
+// @Description 自分の投稿をプロフィールに固定します（固定できるのは1件のみで、既に固定している投稿は置き換えられます）
+// @Tags 投稿
+// @Produce json
+// @Param id path int true "投稿ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "無効な投稿ID・リポストは固定不可"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "他人の投稿は固定できません"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /posts/{id}/pin [post]
+func (h *PinHandler) PinPost(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	if err := h.pinService.PinPost(c.Request().Context(), userID, uint(postID)); err != nil {
+		switch err.Error() {
+		case "post not found":
+			return utils.ErrorResponse(c, http.StatusNotFound, "Post not found")
+		case "unauthorized":
+			return utils.ErrorResponse(c, http.StatusForbidden, "You can only pin your own posts")
+		case "cannot pin repost":
+			return utils.ErrorResponse(c, http.StatusBadRequest, "Reposts cannot be pinned")
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to pin post")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Post pinned successfully",
+	})
+}
+
+// UnpinPost 投稿の固定を解除
+// @Summary 投稿の固定解除
+// @Description プロフィールに固定した投稿の固定を解除します
+// @Tags 投稿
+// @Produce json
+// @Param id path int true "投稿ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Success"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /posts/{id}/pin [delete]
+func (h *PinHandler) UnpinPost(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	if err := h.pinService.UnpinPost(c.Request().Context(), userID, uint(postID)); err != nil {
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unpin post")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"message": "Post unpinned successfully",
+	})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// PollHandler アンケートハンドラー
+type PollHandler struct {
+	pollService *services.PollService
+}
+
+// NewPollHandler PollHandlerのコンストラクタ
+func NewPollHandler() *PollHandler {
+	return &PollHandler{
+		pollService: services.NewPollService(),
+	}
+}
+
+// VotePollRequest アンケート投票リクエスト
+type VotePollRequest struct {
+	OptionIDs []uint `json:"option_ids" validate:"required,min=1"` // 選択肢ID（単一選択の場合は1つ）
+}
+
+// Vote アンケートに投票
+// @Summary アンケートに投票
+// @Description 投稿のアンケートに投票します（締切前であれば投票し直せます）。投票後は結果を含むアンケートを返します
+// @Tags polls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Param request body VotePollRequest true "選択肢"
+// @Success 200 {object} map[string]interface{} "data: Poll"
+// @Failure 400 {object} map[string]interface{} "選択肢が不正・締切済み"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にあるため投票できません"
+// @Failure 404 {object} map[string]interface{} "投稿・アンケートが見つかりません"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /posts/{id}/poll/votes [post]
+func (h *PollHandler) Vote(c echo.Context) error {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	var req VotePollRequest
+	if err := c.Bind(&req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	poll, err := h.pollService.Vote(c.Request().Context(), userID, uint(postID), req.OptionIDs)
+	if err != nil {
+		switch err.Error() {
+		case "post not found", "poll not found":
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case "blocked":
+			return utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		case "poll closed", "poll choice is required", "poll choice is invalid", "poll allows only one choice":
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to vote")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, poll)
+}
@@ -1,16 +1,31 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/models"
 	"github.com/yourusername/sns-backend/internal/services"
 	"github.com/yourusername/sns-backend/internal/utils"
 )
 
 // CreatePostRequest - 投稿作成リクエスト
 type CreatePostRequest struct {
-	Content string `json:"content" validate:"required,max=280"`
+	Content     string             `json:"content" form:"content" validate:"required,max=280"`
+	QuotePostID *uint              `json:"quote_post_id" form:"quote_post_id"`                                                 // 引用する投稿ID（任意）
+	MediaIDs    []uint             `json:"media_ids" form:"media_ids"`                                                         // 添付するアップロード済みの下書きメディアID（任意）
+	Poll        *CreatePollRequest `json:"poll"`                                                                               // アンケート（任意、JSONのみ。メディア・引用とは併用不可）
+	Visibility  string             `json:"visibility" form:"visibility" validate:"omitempty,oneof=public followers mentioned"` // 公開範囲（省略時はpublic）
+}
+
+// CreatePollRequest - アンケート作成リクエスト
+type CreatePollRequest struct {
+	Options        []string  `json:"options"` // 選択肢（2〜4つ）
+	MultipleChoice bool      `json:"multiple_choice"`
+	ClosesAt       time.Time `json:"closes_at"` // 締切日時（最大7日後）
 }
 
 // UpdatePostRequest - 投稿更新リクエスト
@@ -105,15 +120,24 @@ func GetPostByID(c echo.Context) error {
 
 // CreatePost - 投稿作成ハンドラー
 // @Summary 投稿作成
-// @Description 新しい投稿を作成します
+// @Description 新しい投稿を作成します（quote_post_idを指定すると引用投稿）
+// @Description multipart/form-dataでfilesを送信するか、media_idsで下書きメディアを指定するとメディア付き投稿（合計最大4つ）
+// @Description 投稿とメディアは同一トランザクションで保存され、失敗時はどちらも作成されません
+// @Description pollを指定するとアンケート付き投稿（選択肢2〜4つ、締切は最大7日後、メディア・引用とは併用不可）
+// @Description visibilityで公開範囲を指定（public: 全員、followers: フォロワーのみ、mentioned: メンションしたユーザーのみ）
 // @Tags 投稿
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security BearerAuth
 // @Param request body CreatePostRequest true "投稿内容"
+// @Param files formData file false "メディアファイル（最大4つ）"
 // @Success 201 {object} map[string]interface{} "data: Post"
-// @Failure 400 {object} map[string]interface{} "バリデーションエラー"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー・下書きメディアが見つかりません"
 // @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にある・引用元が全員に公開されていないため引用できません"
+// @Failure 404 {object} map[string]interface{} "引用元の投稿が見つかりません"
+// @Failure 413 {object} map[string]interface{} "ファイルサイズ超過（code: file_too_large）"
+// @Failure 415 {object} map[string]interface{} "非対応の形式（code: unsupported_format, unrecognized_content, content_mismatch）"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /posts [post]
 func CreatePost(c echo.Context) error {
@@ -132,11 +156,55 @@ func CreatePost(c echo.Context) error {
 		return utils.ErrorResponse(c, 401, "Unauthorized")
 	}
 
+	// 添付メディア（ファイルはmultipart/form-dataの場合のみ）
+	media := services.PostMediaInput{MediaIDs: req.MediaIDs}
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			return utils.ErrorResponse(c, 400, "Failed to parse multipart form")
+		}
+		media.Files = form.File["files"]
+	}
+	if len(media.Files)+len(media.MediaIDs) > 4 {
+		return utils.ErrorResponse(c, 400, "Maximum 4 files allowed")
+	}
+
 	// XSS対策: コンテンツをサニタイズ
 	sanitizedContent := utils.SanitizeText(req.Content)
 
-	post, err := services.CreatePost(userID, sanitizedContent)
+	var post *models.Post
+	if req.Poll != nil {
+		if req.QuotePostID != nil || !media.IsEmpty() {
+			return utils.ErrorResponse(c, 400, "Poll cannot be combined with media or quote")
+		}
+		poll := services.PollInput{
+			Options:        req.Poll.Options,
+			MultipleChoice: req.Poll.MultipleChoice,
+			ClosesAt:       req.Poll.ClosesAt,
+		}
+		if err := poll.Validate(); err != nil {
+			return utils.ErrorResponse(c, 400, err.Error())
+		}
+		post, err = services.CreatePostWithPoll(userID, sanitizedContent, poll, req.Visibility)
+	} else if req.QuotePostID != nil {
+		post, err = services.CreateQuotePostWithMedia(userID, *req.QuotePostID, sanitizedContent, media, req.Visibility)
+	} else {
+		post, err = services.CreatePostWithMedia(userID, sanitizedContent, media, req.Visibility)
+	}
 	if err != nil {
+		var validationErr *services.MediaValidationError
+		if errors.As(err, &validationErr) {
+			return mediaValidationErrorResponse(c, validationErr)
+		}
+		if err.Error() == "media not found" {
+			return utils.ErrorResponse(c, 400, "Media not found or already attached")
+		}
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, 404, "Quoted post not found")
+		}
+		if err.Error() == "blocked" || err.Error() == "cannot quote non-public post" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to create post")
 	}
 
@@ -145,7 +213,7 @@ func CreatePost(c echo.Context) error {
 
 // UpdatePost - 投稿更新ハンドラー
 // @Summary 投稿更新
-// @Description 自分の投稿を更新します
+// @Description 自分の投稿を更新します（変更前の本文は編集履歴に保存されます）
 // @Tags 投稿
 // @Accept json
 // @Produce json
@@ -153,10 +221,11 @@ func CreatePost(c echo.Context) error {
 // @Param id path int true "投稿ID"
 // @Param request body UpdatePostRequest true "更新内容"
 // @Success 200 {object} map[string]interface{} "data: Post"
-// @Failure 400 {object} map[string]interface{} "バリデーションエラー"
+// @Failure 400 {object} map[string]interface{} "バリデーションエラー（リポスト・下書き・予約投稿は編集できません）"
 // @Failure 401 {object} map[string]interface{} "認証エラー"
-// @Failure 403 {object} map[string]interface{} "権限エラー（他人の投稿は更新できません）"
+// @Failure 403 {object} map[string]interface{} "権限エラー（他人の投稿・編集可能期間を過ぎた投稿は更新できません）"
 // @Failure 404 {object} map[string]interface{} "投稿が見つかりません"
+// @Failure 409 {object} map[string]interface{} "同時に編集されました"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /posts/{id} [put]
 func UpdatePost(c echo.Context) error {
@@ -191,12 +260,56 @@ func UpdatePost(c echo.Context) error {
 		if err.Error() == "unauthorized" {
 			return utils.ErrorResponse(c, 403, err.Error())
 		}
+		if err.Error() == "cannot edit repost" || err.Error() == "cannot edit unpublished post" {
+			return utils.ErrorResponse(c, 400, err.Error())
+		}
+		if err.Error() == "edit window expired" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
+		if err.Error() == "post was modified concurrently" {
+			return utils.ErrorResponse(c, 409, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to update post")
 	}
 
 	return utils.SuccessResponse(c, 200, post)
 }
 
+// GetPostHistory - 投稿の編集履歴取得ハンドラー
+// @Summary 投稿の編集履歴取得
+// @Description 投稿の過去の版を新しい順に取得します（現在の本文は含みません）
+// @Tags 投稿
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "投稿ID"
+// @Success 200 {object} map[string]interface{} "data: PostRevision[]"
+// @Failure 400 {object} map[string]interface{} "無効な投稿ID"
+// @Failure 404 {object} map[string]interface{} "投稿が見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Router /posts/{id}/history [get]
+func GetPostHistory(c echo.Context) error {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, 400, "Invalid post ID")
+	}
+
+	// ユーザーID取得（任意）
+	var userIDPtr *uint
+	if userID, ok := c.Get("user_id").(uint); ok {
+		userIDPtr = &userID
+	}
+
+	revisions, err := services.GetPostHistory(uint(postID), userIDPtr)
+	if err != nil {
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, 404, err.Error())
+		}
+		return utils.ErrorResponse(c, 500, "Failed to get post history")
+	}
+
+	return utils.SuccessResponse(c, 200, revisions)
+}
+
 // DeletePost - 投稿削除ハンドラー
 // @Summary 投稿削除
 // @Description 自分の投稿を削除します（論理削除）
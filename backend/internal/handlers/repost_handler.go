@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// RepostHandler リポストハンドラー
+type RepostHandler struct {
+	repostService *services.RepostService
+}
+
+// NewRepostHandler RepostHandlerのコンストラクタ
+func NewRepostHandler() *RepostHandler {
+	return &RepostHandler{
+		repostService: services.NewRepostService(),
+	}
+}
+
+// Repost 投稿をリポスト
+// @Summary リポスト
+// @Description 投稿をリポストします（リポストを指定した場合は元投稿をリポスト）
+// @Tags 投稿
+// @Accept json
+// @Produce json
+// @Param id path int true "投稿ID"
+// @Success 201 {object} map[string]interface{} "data: Post"
+// @Failure 400 {object} map[string]interface{} "無効な投稿ID"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 403 {object} map[string]interface{} "ブロック関係にある / 鍵アカウント・全員に公開されていない投稿のためリポストできません"
+// @Failure 404 {object} map[string]interface{} "投稿が見つかりません"
+// @Failure 409 {object} map[string]interface{} "既にリポスト済み"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /posts/{id}/repost [post]
+func (h *RepostHandler) Repost(c echo.Context) error {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	repost, err := h.repostService.Repost(c.Request().Context(), userID, uint(postID))
+	if err != nil {
+		if err.Error() == "post not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		if err.Error() == "already reposted" {
+			return utils.ErrorResponse(c, http.StatusConflict, err.Error())
+		}
+		if err.Error() == "blocked" || err.Error() == "cannot repost protected post" || err.Error() == "cannot repost non-public post" {
+			return utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to repost")
+	}
+
+	return utils.SuccessResponse(c, http.StatusCreated, repost)
+}
+
+// Unrepost リポストを取り消す
+// @Summary リポスト取り消し
+// @Description 自分のリポストを取り消します
+// @Tags 投稿
+// @Accept json
+// @Produce json
+// @Param id path int true "リポスト元の投稿ID"
+// @Success 204 "取り消し成功"
+// @Failure 400 {object} map[string]interface{} "無効な投稿ID"
+// @Failure 401 {object} map[string]interface{} "認証エラー"
+// @Failure 404 {object} map[string]interface{} "リポストが見つかりません"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /posts/{id}/repost [delete]
+func (h *RepostHandler) Unrepost(c echo.Context) error {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusBadRequest, "Invalid post ID")
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.repostService.Unrepost(c.Request().Context(), userID, uint(postID)); err != nil {
+		if err.Error() == "repost not found" {
+			return utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unrepost")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/services"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+// SearchHandler 検索ハンドラー
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+// NewSearchHandler SearchHandlerのコンストラクタ
+func NewSearchHandler() *SearchHandler {
+	return &SearchHandler{
+		searchService: services.NewSearchService(),
+	}
+}
+
+// SearchPosts 投稿検索
+// @Summary 投稿検索
+// @Description 投稿本文を部分一致で検索します。"フレーズ"、from:username、#tag、since:YYYY-MM-DD、until:YYYY-MM-DD に対応
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "検索クエリ"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param cursor query string false "ページネーションカーソル"
+// @Success 200 {object} map[string]interface{} "data: []Post, pagination: {has_more, next_cursor, limit}"
+// @Failure 400 {object} map[string]interface{} "検索クエリが空"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /search/posts [get]
+func (h *SearchHandler) SearchPosts(c echo.Context) error {
+	limit := 20
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
+			limit = parsedLimit
+		}
+	}
+
+	var cursor *string
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor = &cursorStr
+	}
+
+	// ユーザーID取得（任意）
+	var userIDPtr *uint
+	if userID, ok := c.Get("user_id").(uint); ok {
+		userIDPtr = &userID
+	}
+
+	posts, hasMore, nextCursor, err := h.searchService.SearchPosts(c.Request().Context(), c.QueryParam("q"), userIDPtr, limit, cursor)
+	if err != nil {
+		if err.Error() == "search query is required" {
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to search posts")
+	}
+
+	return utils.PaginationResponse(c, posts, hasMore, nextCursor, limit)
+}
+
+// SearchUsers ユーザー検索（前方一致、メンション補完用）
+// @Summary ユーザー検索
+// @Description ユーザー名・表示名の前方一致でユーザーを検索します（フォロー中 → フォロワー数の多い順）
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "検索クエリ（先頭の@は無視）"
+// @Param limit query int false "取得件数（最大20）" default(10)
+// @Success 200 {object} map[string]interface{} "data: []PublicUser"
+// @Failure 400 {object} map[string]interface{} "検索クエリが空"
+// @Failure 500 {object} map[string]interface{} "サーバーエラー"
+// @Security BearerAuth
+// @Router /search/users [get]
+func (h *SearchHandler) SearchUsers(c echo.Context) error {
+	limit := 10
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 20 {
+			limit = parsedLimit
+		}
+	}
+
+	// ユーザーID取得（任意）
+	var userIDPtr *uint
+	if userID, ok := c.Get("user_id").(uint); ok {
+		userIDPtr = &userID
+	}
+
+	users, err := h.searchService.SearchUsers(c.Request().Context(), c.QueryParam("q"), userIDPtr, limit)
+	if err != nil {
+		if err.Error() == "search query is required" {
+			return utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to search users")
+	}
+
+	return utils.SuccessResponse(c, http.StatusOK, users)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yourusername/sns-backend/internal/realtime"
+	"github.com/yourusername/sns-backend/internal/utils"
+)
+
+const (
+	// 接続維持のためのハートビート間隔（プロキシのアイドルタイムアウト対策）
+	streamHeartbeatInterval = 25 * time.Second
+	// 接続の最大維持時間（アクセストークンの有効期限に合わせて再接続・再認証させる）
+	streamMaxLifetime = time.Hour
+)
+
+// StreamHandler リアルタイム配信ハンドラー
+type StreamHandler struct {
+	hub *realtime.Hub
+}
+
+// NewStreamHandler StreamHandlerのコンストラクタ
+func NewStreamHandler() *StreamHandler {
+	return &StreamHandler{
+		hub: realtime.GetHub(),
+	}
+}
+
+// Stream Server-Sent Eventsでイベントを配信
+// @Summary リアルタイムイベント配信（SSE）
+// @Description フォロー中ユーザーの新規投稿、自分の投稿へのいいね・コメントをServer-Sent Eventsで配信します
+// @Tags stream
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {string} string "event-stream"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /stream [get]
+func (h *StreamHandler) Stream(c echo.Context) error {
+	// 認証済みユーザーID取得
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // リバースプロキシのバッファリングを無効化
+	res.WriteHeader(http.StatusOK)
+
+	sub := h.hub.Subscribe(userID)
+	defer h.hub.Unsubscribe(sub)
+
+	// 接続確立を通知
+	fmt.Fprint(res, "retry: 3000\n\n")
+	res.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	lifetime := time.NewTimer(streamMaxLifetime)
+	defer lifetime.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-lifetime.C:
+			return nil
+		case <-heartbeat.C:
+			// コメント行はクライアントで無視される
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				c.Logger().Error(err)
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
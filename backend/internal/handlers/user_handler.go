@@ -18,6 +18,7 @@ type UpdateProfileRequest struct {
 	Website     *string `json:"website"`
 	BirthDate   *string `json:"birth_date"`
 	Occupation  *string `json:"occupation"`
+	IsProtected *bool   `json:"is_protected"` // 鍵アカウント設定
 }
 
 // GetUserByUsername - ユーザー名でユーザーを取得ハンドラー
@@ -101,6 +102,9 @@ func UpdateProfile(c echo.Context) error {
 	if req.Occupation != nil {
 		updates["occupation"] = req.Occupation
 	}
+	if req.IsProtected != nil {
+		updates["is_protected"] = *req.IsProtected
+	}
 
 	user, err := services.UpdateProfile(userID, updates)
 	if err != nil {
@@ -124,6 +128,7 @@ func UpdateProfile(c echo.Context) error {
 // @Param limit query int false "取得件数（最大100）" default(20)
 // @Param cursor query string false "ページネーションカーソル"
 // @Success 200 {object} map[string]interface{} "data: []Post, pagination: {has_more, next_cursor, limit}"
+// @Failure 403 {object} map[string]interface{} "鍵アカウントのため閲覧できません"
 // @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /users/{username}/posts [get]
@@ -144,11 +149,20 @@ func GetUserPosts(c echo.Context) error {
 		cursorPtr = &cursor
 	}
 
-	posts, hasMore, nextCursor, err := services.GetUserPosts(username, limit, cursorPtr)
+	// 現在のユーザーID取得（任意、鍵アカウントの閲覧判定に使用）
+	var currentUserIDPtr *uint
+	if currentUserID, ok := c.Get("user_id").(uint); ok {
+		currentUserIDPtr = &currentUserID
+	}
+
+	posts, hasMore, nextCursor, err := services.GetUserPosts(username, currentUserIDPtr, limit, cursorPtr)
 	if err != nil {
 		if err.Error() == "user not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
 		}
+		if err.Error() == "account is protected" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to get user posts")
 	}
 
@@ -165,6 +179,7 @@ func GetUserPosts(c echo.Context) error {
 // @Param limit query int false "取得件数（最大100）" default(20)
 // @Param cursor query string false "ページネーションカーソル"
 // @Success 200 {object} map[string]interface{} "data: []User, pagination: {has_more, next_cursor, limit}"
+// @Failure 403 {object} map[string]interface{} "鍵アカウントのため閲覧できません"
 // @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /users/{username}/followers [get]
@@ -185,20 +200,23 @@ func GetFollowers(c echo.Context) error {
 		cursorPtr = &cursor
 	}
 
-	users, hasMore, nextCursor, err := services.GetFollowers(username, limit, cursorPtr)
+	// 現在のユーザーID取得（任意、鍵アカウントの閲覧判定に使用）
+	var currentUserIDPtr *uint
+	if currentUserID, ok := c.Get("user_id").(uint); ok {
+		currentUserIDPtr = &currentUserID
+	}
+
+	users, hasMore, nextCursor, err := services.GetFollowers(username, currentUserIDPtr, limit, cursorPtr)
 	if err != nil {
 		if err.Error() == "user not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
 		}
+		if err.Error() == "account is protected" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to get followers")
 	}
 
-	// 現在のユーザーID取得（任意）
-	var currentUserIDPtr *uint
-	if userID, ok := c.Get("user_id").(uint); ok {
-		currentUserIDPtr = &userID
-	}
-
 	// PublicUser形式に変換（メールアドレスを適切に処理）
 	publicUsers := make([]*models.PublicUser, len(users))
 	for i, user := range users {
@@ -218,6 +236,7 @@ func GetFollowers(c echo.Context) error {
 // @Param limit query int false "取得件数（最大100）" default(20)
 // @Param cursor query string false "ページネーションカーソル"
 // @Success 200 {object} map[string]interface{} "data: []User, pagination: {has_more, next_cursor, limit}"
+// @Failure 403 {object} map[string]interface{} "鍵アカウントのため閲覧できません"
 // @Failure 404 {object} map[string]interface{} "ユーザーが見つかりません"
 // @Failure 500 {object} map[string]interface{} "サーバーエラー"
 // @Router /users/{username}/following [get]
@@ -238,20 +257,23 @@ func GetFollowing(c echo.Context) error {
 		cursorPtr = &cursor
 	}
 
-	users, hasMore, nextCursor, err := services.GetFollowing(username, limit, cursorPtr)
+	// 現在のユーザーID取得（任意、鍵アカウントの閲覧判定に使用）
+	var currentUserIDPtr *uint
+	if currentUserID, ok := c.Get("user_id").(uint); ok {
+		currentUserIDPtr = &currentUserID
+	}
+
+	users, hasMore, nextCursor, err := services.GetFollowing(username, currentUserIDPtr, limit, cursorPtr)
 	if err != nil {
 		if err.Error() == "user not found" {
 			return utils.ErrorResponse(c, 404, err.Error())
 		}
+		if err.Error() == "account is protected" {
+			return utils.ErrorResponse(c, 403, err.Error())
+		}
 		return utils.ErrorResponse(c, 500, "Failed to get following")
 	}
 
-	// 現在のユーザーID取得（任意）
-	var currentUserIDPtr *uint
-	if userID, ok := c.Get("user_id").(uint); ok {
-		currentUserIDPtr = &userID
-	}
-
 	// PublicUser形式に変換（メールアドレスを適切に処理）
 	publicUsers := make([]*models.PublicUser, len(users))
 	for i, user := range users {
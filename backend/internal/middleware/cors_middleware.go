@@ -18,7 +18,8 @@ func CORS() echo.MiddlewareFunc {
 			"https://udemy-sns-b9e40.firebaseapp.com",
 		},
 		AllowMethods:     []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.PATCH, echo.OPTIONS},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
-		AllowCredentials: true, // Cookie送信を許可
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "Upload-Offset"},
+		ExposeHeaders:    []string{"Upload-Offset"}, // 分割アップロードの再開位置
+		AllowCredentials: true,                      // Cookie送信を許可
 	})
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// Block ブロック関係（ブロック中は双方向にフォロー・いいね・コメントができない）
+type Block struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	BlockerID uint      `gorm:"not null;index;uniqueIndex:idx_blocker_blocked" json:"blocker_id"` // ブロックする側
+	BlockedID uint      `gorm:"not null;index;uniqueIndex:idx_blocker_blocked" json:"blocked_id"` // ブロックされる側
+	CreatedAt time.Time `json:"created_at"`
+
+	// リレーション
+	Blocker User `gorm:"foreignKey:BlockerID;constraint:OnDelete:CASCADE" json:"-"`
+	Blocked User `gorm:"foreignKey:BlockedID;constraint:OnDelete:CASCADE" json:"-"`
+}
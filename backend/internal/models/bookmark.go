@@ -2,14 +2,37 @@ package models
 
 import "time"
 
+// DefaultBookmarkCollectionName 既定のブックマークコレクション名（ユーザーが作成するコレクションには使用できない）
+const DefaultBookmarkCollectionName = "ブックマーク"
+
 // Bookmark ブックマークモデル
 type Bookmark struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	UserID    uint      `gorm:"not null;index:idx_user_post,unique" json:"user_id"`
-	PostID    uint      `gorm:"not null;index:idx_user_post,unique;index:idx_bookmarks_post" json:"post_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;index:idx_user_post,unique" json:"user_id"`
+	PostID       uint      `gorm:"not null;index:idx_user_post,unique;index:idx_bookmarks_post" json:"post_id"`
+	CollectionID *uint     `gorm:"index" json:"collection_id"` // 所属するコレクション（既存のブックマークは移行時に既定のコレクションへ移す）
+	CreatedAt    time.Time `json:"created_at"`
+
+	// リレーション
+	User       User               `gorm:"foreignKey:UserID" json:"-"`
+	Post       Post               `gorm:"foreignKey:PostID" json:"-"`
+	Collection BookmarkCollection `gorm:"foreignKey:CollectionID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// BookmarkCollection ブックマークのコレクション
+// ユーザーごとに既定のコレクションを1つ持ち、コレクションを指定しないブックマークはそこに入る
+type BookmarkCollection struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_bookmark_collections_user_name" json:"user_id"`
+	Name       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_bookmark_collections_user_name" json:"name"`
+	IsDefault  bool      `gorm:"not null;default:false" json:"is_default"`        // 既定のコレクション（名前変更・削除不可）
+	ShareToken *string   `gorm:"type:varchar(64);uniqueIndex" json:"share_token"` // 公開リンク用のトークン（nilの場合は非公開）
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	// 集計フィールド（DBには保存しない）
+	BookmarksCount int64 `gorm:"-" json:"bookmarks_count"`
 
 	// リレーション
-	User User `gorm:"foreignKey:UserID" json:"-"`
-	Post Post `gorm:"foreignKey:PostID" json:"-"`
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
 }
@@ -6,16 +6,25 @@ import (
 	"gorm.io/gorm"
 )
 
+// MaxCommentDepth - 返信の最大ネスト深さ（トップレベルコメントは0）
+const MaxCommentDepth = 3
+
 type Comment struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
 	PostID    uint           `gorm:"not null;index" json:"post_id"`
 	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	ParentID  *uint          `gorm:"index" json:"parent_id"`          // 返信先コメントID（トップレベルはnull）
+	Depth     int            `gorm:"not null;default:0" json:"depth"` // ネストの深さ
 	Content   string         `gorm:"type:text;not null" json:"content" validate:"required,max=280"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// リレーション
-	Post Post `gorm:"foreignKey:PostID" json:"post,omitempty"`
-	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Post   Post     `gorm:"foreignKey:PostID" json:"post,omitempty"`
+	User   User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Parent *Comment `gorm:"foreignKey:ParentID" json:"-"`
+
+	// 集計フィールド（DBには保存しない）
+	RepliesCount int64 `gorm:"-" json:"replies_count"`
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// Conversation ダイレクトメッセージの会話（現在は1対1のみ、将来的にグループに対応）
+type Conversation struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	IsGroup       bool      `gorm:"default:false;not null" json:"is_group"`
+	DirectKey     *string   `gorm:"type:varchar(50);uniqueIndex" json:"-"` // 1対1の会話の参加者ペア（"小さいID:大きいID"、グループの場合はnil）
+	LastMessageID *uint     `gorm:"index" json:"-"`                        // 会話一覧の並び順・カーソルに使用
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	// リレーション
+	Members []ConversationMember `gorm:"foreignKey:ConversationID" json:"-"`
+
+	// 集計フィールド（DBには保存しない）
+	Participants []*PublicUser `gorm:"-" json:"participants"`           // 自分以外の参加者
+	LastMessage  *Message      `gorm:"-" json:"last_message,omitempty"` // 最新メッセージ
+	UnreadCount  int64         `gorm:"-" json:"unread_count"`           // 未読メッセージ数
+}
+
+// ConversationMember 会話の参加者
+type ConversationMember struct {
+	ID                uint      `gorm:"primarykey" json:"id"`
+	ConversationID    uint      `gorm:"not null;index;uniqueIndex:idx_conversation_member" json:"conversation_id"`
+	UserID            uint      `gorm:"not null;index;uniqueIndex:idx_conversation_member" json:"user_id"`
+	LastReadMessageID *uint     `json:"last_read_message_id"` // 既読にした最新のメッセージID
+	CreatedAt         time.Time `json:"created_at"`
+
+	// リレーション
+	Conversation Conversation `gorm:"foreignKey:ConversationID;constraint:OnDelete:CASCADE" json:"-"`
+	User         User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
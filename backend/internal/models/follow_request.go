@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// FollowRequest 鍵アカウントへのフォローリクエスト（承認されるとFollowに変換して削除する）
+type FollowRequest struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	RequesterID uint      `gorm:"not null;index;uniqueIndex:idx_requester_target" json:"requester_id"` // リクエストする側
+	TargetID    uint      `gorm:"not null;index;uniqueIndex:idx_requester_target" json:"target_id"`    // リクエストされる側（鍵アカウント）
+	CreatedAt   time.Time `json:"created_at"`
+
+	// リレーション
+	Requester User `gorm:"foreignKey:RequesterID;constraint:OnDelete:CASCADE" json:"requester,omitempty"`
+	Target    User `gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -6,18 +6,47 @@ import (
 
 type Media struct {
 	ID         uint      `gorm:"primarykey" json:"id"`
-	PostID     uint      `gorm:"not null;index" json:"post_id"`
+	PostID     *uint     `gorm:"index" json:"post_id"`                        // 投稿の添付メディア
+	MessageID  *uint     `gorm:"index" json:"message_id,omitempty"`           // ダイレクトメッセージの添付メディア
+	UserID     *uint     `gorm:"index" json:"-"`                              // アップロードしたユーザー（下書きメディアの所有者確認に使用）
 	MediaType  string    `gorm:"type:varchar(20);not null" json:"media_type"` // image, video, audio
-	MediaURL   string    `gorm:"type:varchar(2000);not null" json:"media_url"` // 署名付きURL対応
+	ObjectPath string    `gorm:"type:varchar(500);index" json:"-"`            // ストレージ上のオブジェクトパス（DBにはURLではなくパスのみ保存）
+	MediaURL   string    `gorm:"-" json:"media_url"`                          // 表示時に生成する署名付きURL
 	FileSize   int64     `gorm:"not null" json:"file_size"`
-	Duration   *int      `json:"duration"` // 動画・音声の長さ（秒）
+	Duration   *int      `json:"duration"`                                // 動画・音声の長さ（秒、コンテナのメタデータから取得）
+	Width      *int      `json:"width"`                                   // 画像・動画の幅（px、画像はEXIFの向きを反映済み）
+	Height     *int      `json:"height"`                                  // 画像・動画の高さ（px）
+	Codec      string    `gorm:"type:varchar(20)" json:"codec,omitempty"` // 動画・音声のコーデック（h264, aac, mp3など）
 	OrderIndex int       `gorm:"default:0" json:"order_index"`
 	CreatedAt  time.Time `json:"created_at"`
 
+	// 画像のサイズ別バリアント（オリジナルはObjectPath）
+	ThumbnailObjectPath string         `gorm:"type:varchar(500);index" json:"-"`
+	FeedObjectPath      string         `gorm:"type:varchar(500);index" json:"-"`
+	Variants            *MediaVariants `gorm:"-" json:"variants,omitempty"` // 表示時に生成するバリアントのURL
+
 	// リレーション
 	Post Post `gorm:"foreignKey:PostID" json:"-"`
 }
 
+// ObjectPaths - ストレージ上のオブジェクトパス一覧（バリアントを含む）
+func (m *Media) ObjectPaths() []string {
+	paths := make([]string, 0, 3)
+	for _, path := range []string{m.ObjectPath, m.FeedObjectPath, m.ThumbnailObjectPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// MediaVariants - 画像バリアントの表示用URL
+type MediaVariants struct {
+	Thumbnail string `json:"thumbnail"` // 一覧・プレビュー用（長辺320px）
+	Feed      string `json:"feed"`      // タイムライン表示用（長辺1080px）
+	Original  string `json:"original"`  // オリジナル（メタデータ除去済み、長辺4096px）
+}
+
 // ValidMediaTypes - 許可されているメディアタイプ
 var ValidMediaTypes = []string{"image", "video", "audio"}
 
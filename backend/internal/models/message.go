@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// Message ダイレクトメッセージ
+type Message struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	ConversationID uint      `gorm:"not null;index" json:"conversation_id"`
+	SenderID       uint      `gorm:"not null;index" json:"sender_id"`
+	Content        string    `gorm:"type:text" json:"content"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// リレーション
+	Conversation Conversation `gorm:"foreignKey:ConversationID;constraint:OnDelete:CASCADE" json:"-"`
+	Sender       User         `gorm:"foreignKey:SenderID" json:"sender"`
+	Media        []Media      `gorm:"foreignKey:MessageID" json:"media,omitempty"`
+}
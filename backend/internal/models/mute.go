@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// Mute ミュート関係（ミュートした側のフィードからのみ非表示にする）
+type Mute struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	MuterID   uint      `gorm:"not null;index;uniqueIndex:idx_muter_muted" json:"muter_id"` // ミュートする側
+	MutedID   uint      `gorm:"not null;index;uniqueIndex:idx_muter_muted" json:"muted_id"` // ミュートされる側
+	CreatedAt time.Time `json:"created_at"`
+
+	// リレーション
+	Muter User `gorm:"foreignKey:MuterID;constraint:OnDelete:CASCADE" json:"-"`
+	Muted User `gorm:"foreignKey:MutedID;constraint:OnDelete:CASCADE" json:"-"`
+}
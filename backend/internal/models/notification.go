@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// 通知タイプ
+const (
+	NotificationTypeLike    = "like"
+	NotificationTypeFollow  = "follow"
+	NotificationTypeComment = "comment"
+	NotificationTypeReply   = "reply"
+	NotificationTypeMention = "mention"
+	NotificationTypeRepost  = "repost"
+	NotificationTypeQuote   = "quote"
+
+	NotificationTypeFollowRequest  = "follow_request"
+	NotificationTypeFollowAccepted = "follow_accepted"
+)
+
+// Notification 通知モデル（1イベント1レコード、表示時にGroupKeyでまとめる）
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_group" json:"user_id"` // 通知を受け取るユーザー
+	ActorID   uint       `gorm:"not null;index" json:"actor_id"`                             // 通知の原因となったユーザー
+	Type      string     `gorm:"type:varchar(20);not null" json:"type"`                      // like, follow, comment, reply, mention, repost, quote, follow_request, follow_accepted
+	PostID    *uint      `gorm:"index" json:"post_id,omitempty"`
+	CommentID *uint      `json:"comment_id,omitempty"`
+	GroupKey  string     `gorm:"type:varchar(100);not null;index:idx_notifications_user_group" json:"-"` // 例: like:post:12
+	ReadAt    *time.Time `gorm:"index" json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// リレーション
+	User  User `gorm:"foreignKey:UserID" json:"-"`
+	Actor User `gorm:"foreignKey:ActorID" json:"-"`
+}
+
+// NotificationGroup - まとめられた通知（レスポンス用、例: 「Aさん他3人がいいねしました」）
+type NotificationGroup struct {
+	ID          uint          `json:"id"` // グループ内で最新の通知ID（既読化・カーソルに使用）
+	Type        string        `json:"type"`
+	PostID      *uint         `json:"post_id,omitempty"`
+	CommentID   *uint         `json:"comment_id,omitempty"`
+	Actors      []*PublicUser `json:"actors"`       // 最新の通知者（最大3人）
+	ActorsCount int64         `json:"actors_count"` // 通知者の総数
+	OthersCount int64         `json:"others_count"` // 先頭の通知者以外の人数
+	IsRead      bool          `json:"is_read"`
+	CreatedAt   time.Time     `json:"created_at"` // グループ内で最新の通知日時
+}
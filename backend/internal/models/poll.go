@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// Poll 投稿に添付するアンケート
+// 投票数は投票時に加算して保持し、投票済みまたは締切後の閲覧者にのみ返す
+type Poll struct {
+	ID             uint         `gorm:"primarykey" json:"id"`
+	PostID         uint         `gorm:"not null;uniqueIndex" json:"post_id"`
+	MultipleChoice bool         `gorm:"not null;default:false" json:"multiple_choice"` // 複数選択可能か
+	ClosesAt       time.Time    `gorm:"not null" json:"closes_at"`
+	VotersCount    int64        `gorm:"not null;default:0" json:"-"` // 投票したユーザー数
+	CreatedAt      time.Time    `json:"created_at"`
+	Options        []PollOption `gorm:"foreignKey:PollID" json:"options"`
+
+	// 閲覧者ごとの状態（DBには保存しない）
+	IsClosed bool   `gorm:"-" json:"is_closed"`
+	HasVoted bool   `gorm:"-" json:"has_voted"`    // 現在のユーザーが投票済みか
+	Voters   *int64 `gorm:"-" json:"voters_count"` // 結果を閲覧できない場合はnull
+
+	// リレーション
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// PollOption アンケートの選択肢
+type PollOption struct {
+	ID         uint   `gorm:"primarykey" json:"id"`
+	PollID     uint   `gorm:"not null;index" json:"-"`
+	Position   int    `gorm:"not null" json:"position"`
+	Text       string `gorm:"type:varchar(100);not null" json:"text"`
+	VotesCount int64  `gorm:"not null;default:0" json:"-"`
+
+	// 閲覧者ごとの状態（DBには保存しない）
+	Votes   *int64 `gorm:"-" json:"votes_count"` // 結果を閲覧できない場合はnull
+	IsVoted bool   `gorm:"-" json:"is_voted"`    // 現在のユーザーが選択したか
+
+	// リレーション
+	Poll Poll `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// PollVote アンケートへの投票（複数選択の場合は選択肢ごとに1件）
+type PollVote struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	PollID    uint      `gorm:"not null;index;uniqueIndex:idx_poll_votes_poll_user_option" json:"poll_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_poll_votes_poll_user_option" json:"user_id"`
+	OptionID  uint      `gorm:"not null;index;uniqueIndex:idx_poll_votes_poll_user_option" json:"option_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// リレーション
+	Poll   Poll       `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE" json:"-"`
+	User   User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Option PollOption `gorm:"foreignKey:OptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -6,6 +6,20 @@ import (
 	"gorm.io/gorm"
 )
 
+// 投稿の公開状態
+const (
+	PostStatusPublished = "published" // 公開済み
+	PostStatusDraft     = "draft"     // 下書き（本人のみ閲覧可能）
+	PostStatusScheduled = "scheduled" // 予約投稿（PublishAtに公開）
+)
+
+// 投稿の公開範囲（投稿者本人とメンションされたユーザーは常に閲覧可能）
+const (
+	PostVisibilityPublic    = "public"    // 全員（未ログインを含む）
+	PostVisibilityFollowers = "followers" // フォロワーのみ
+	PostVisibilityMentioned = "mentioned" // メンションしたユーザーのみ
+)
+
 type Post struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
 	UserID    uint           `gorm:"not null;index" json:"user_id"`
@@ -14,12 +28,28 @@ type Post struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// 公開状態（下書き・予約投稿はフィードに表示しない）
+	Status    string     `gorm:"type:varchar(20);not null;default:'published';index" json:"status"`
+	PublishAt *time.Time `gorm:"index" json:"publish_at,omitempty"` // 予約投稿の公開日時
+
+	// 公開範囲（リポスト・引用できるのは全員に公開された投稿のみ）
+	Visibility string `gorm:"type:varchar(20);not null;default:'public';index" json:"visibility"`
+
+	// 編集履歴（過去の本文はPostRevisionに保存）
+	EditedAt  *time.Time `json:"edited_at"`
+	EditCount int        `gorm:"not null;default:0" json:"edit_count"`
+
+	// リポスト・引用（どちらも元投稿を参照する。リポストは本文なし）
+	RepostOfID *uint `gorm:"index" json:"repost_of_id,omitempty"`
+	QuoteOfID  *uint `gorm:"index" json:"quote_of_id,omitempty"`
+
 	// リレーション
 	User      User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Media     []Media    `gorm:"foreignKey:PostID" json:"media,omitempty"`
 	Comments  []Comment  `gorm:"foreignKey:PostID" json:"comments,omitempty"`
 	PostLikes []PostLike `gorm:"foreignKey:PostID" json:"-"`
 	Hashtags  []Hashtag  `gorm:"many2many:post_hashtags;" json:"hashtags,omitempty"`
+	Poll      *Poll      `gorm:"foreignKey:PostID" json:"poll,omitempty"`
 
 	// 集計フィールド（DBには保存しない）
 	LikesCount    int64    `gorm:"-" json:"likes_count"`
@@ -27,6 +57,19 @@ type Post struct {
 	IsLiked       bool     `gorm:"-" json:"is_liked"` // 現在のユーザーがいいねしているか
 	IsBookmarked  bool     `gorm:"-" json:"is_bookmarked"` // 現在のユーザーがブックマークしているか
 	HashtagNames  []string `gorm:"-" json:"hashtag_names,omitempty"` // ハッシュタグ名のリスト
+	Mentions      []MentionEntity `gorm:"-" json:"mentions,omitempty"` // 解決済みメンション
+	RepostsCount  int64    `gorm:"-" json:"reposts_count"`
+	QuotesCount   int64    `gorm:"-" json:"quotes_count"`
+	IsReposted    bool     `gorm:"-" json:"is_reposted"` // 現在のユーザーがリポストしているか
+	RepostOf      *Post    `gorm:"-" json:"repost_of,omitempty"`   // リポスト元の投稿
+	QuotedPost    *Post    `gorm:"-" json:"quoted_post,omitempty"` // 引用元の投稿
+	Unavailable   bool     `gorm:"-" json:"unavailable,omitempty"` // 元投稿が削除されている場合のスタブ
+	IsPinned      bool     `gorm:"-" json:"is_pinned,omitempty"`   // プロフィールに固定表示されている投稿か（ユーザーの投稿一覧のみ）
+}
+
+// IsPublished 公開済みの投稿か（Statusが未設定の場合はDBのデフォルトと同じく公開済み）
+func (p *Post) IsPublished() bool {
+	return p.Status == "" || p.Status == PostStatusPublished
 }
 
 // PostWithCounts - いいね数・コメント数を含むレスポンス用構造体
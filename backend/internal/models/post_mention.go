@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PostMention 投稿とメンションされたユーザーの中間テーブル
+type PostMention struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	PostID    uint      `gorm:"not null;uniqueIndex:idx_post_mention" json:"post_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_post_mention;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// リレーション
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName テーブル名を指定
+func (PostMention) TableName() string {
+	return "post_mentions"
+}
+
+// MentionEntity 投稿JSONに含める解決済みメンション（ユーザー名→ユーザーID）
+type MentionEntity struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+}
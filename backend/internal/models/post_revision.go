@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// PostRevision 投稿の編集履歴
+// 編集のたびに、置き換えられる直前の本文を1件保存する（現在の本文はPost側に持つ）
+type PostRevision struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	PostID     uint      `gorm:"not null;uniqueIndex:idx_post_revisions_post_version" json:"post_id"`
+	Version    int       `gorm:"not null;uniqueIndex:idx_post_revisions_post_version" json:"version"` // 1が最初の投稿
+	Content    string    `gorm:"type:text;not null" json:"content"`
+	CreatedAt  time.Time `json:"created_at"`  // この版が投稿・編集された日時
+	ReplacedAt time.Time `json:"replaced_at"` // 次の版に置き換えられた日時
+
+	// リレーション
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
+}
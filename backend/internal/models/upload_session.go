@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// アップロードセッションの状態
+const (
+	UploadSessionStatusUploading = "uploading" // チャンクを受信中
+	UploadSessionStatusCompleted = "completed" // メディアの作成まで完了
+)
+
+// UploadSession 再開可能な分割アップロードのセッション
+// 受信したチャンクは一時ファイルに追記し、完了時にストレージへ保存してMediaを作成する
+type UploadSession struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	UserID       uint      `gorm:"not null;index" json:"-"`
+	PostID       *uint     `gorm:"index" json:"post_id"` // 完了時に紐付ける投稿（nilの場合は下書きメディア）
+	Filename     string    `gorm:"type:varchar(255);not null" json:"filename"`
+	TotalSize    int64     `gorm:"not null" json:"total_size"`
+	ReceivedSize int64     `gorm:"not null;default:0" json:"offset"` // 受信済みのバイト数（次のチャンクの開始位置）
+	Status       string    `gorm:"type:varchar(20);not null;default:'uploading'" json:"status"`
+	MediaID      *uint     `json:"media_id"` // 完了時に作成されたメディア
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// リレーション
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -23,6 +23,8 @@ type User struct {
 	Approved      bool           `gorm:"default:false" json:"approved"`       // 廃止予定: statusカラムを使用
 	Role          string         `gorm:"type:varchar(20);default:'user';not null" json:"role"`
 	Status        string         `gorm:"type:varchar(20);default:'pending';not null" json:"status"`
+	IsProtected   bool           `gorm:"default:false;not null" json:"is_protected"` // 鍵アカウント（承認済みフォロワーのみ投稿を閲覧可能）
+	PinnedPostID  *uint          `gorm:"index" json:"pinned_post_id"`                // プロフィールに固定表示する投稿（投稿削除時に解除）
 	LastLoginAt   *time.Time     `json:"last_login_at,omitempty"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
@@ -58,27 +60,30 @@ func (u *User) CheckPassword(password string) bool {
 
 // PublicUser - パスワードを含まない公開用のユーザー情報
 type PublicUser struct {
-	ID             uint       `json:"id"`
-	Email          *string    `json:"email,omitempty"` // 本人のみ表示（omitempty）
-	Username       string     `json:"username"`
-	DisplayName    *string    `json:"display_name"`
-	Bio            *string    `json:"bio"`
-	AvatarURL      *string    `json:"avatar_url"`
-	HeaderURL      *string    `json:"header_url"`
-	Website        *string    `json:"website"`
-	BirthDate      *time.Time `json:"birth_date"`
-	Occupation     *string    `json:"occupation"`
-	EmailVerified  bool       `json:"email_verified"` // 廃止予定: 現在は未使用
-	Approved       bool       `json:"approved"`       // 廃止予定: statusカラムを使用
-	Role           string     `json:"role"`
-	Status         string     `json:"status"`
-	LastLoginAt    *time.Time `json:"last_login_at,omitempty"`
-	FollowersCount int        `json:"followers_count"`
-	FollowingCount int        `json:"following_count"`
-	IsFollowing    *bool      `json:"is_following,omitempty"`
-	IsFollowedBy   *bool      `json:"is_followed_by,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	ID              uint       `json:"id"`
+	Email           *string    `json:"email,omitempty"` // 本人のみ表示（omitempty）
+	Username        string     `json:"username"`
+	DisplayName     *string    `json:"display_name"`
+	Bio             *string    `json:"bio"`
+	AvatarURL       *string    `json:"avatar_url"`
+	HeaderURL       *string    `json:"header_url"`
+	Website         *string    `json:"website"`
+	BirthDate       *time.Time `json:"birth_date"`
+	Occupation      *string    `json:"occupation"`
+	EmailVerified   bool       `json:"email_verified"` // 廃止予定: 現在は未使用
+	Approved        bool       `json:"approved"`       // 廃止予定: statusカラムを使用
+	Role            string     `json:"role"`
+	Status          string     `json:"status"`
+	IsProtected     bool       `json:"is_protected"`
+	PinnedPostID    *uint      `json:"pinned_post_id"`
+	LastLoginAt     *time.Time `json:"last_login_at,omitempty"`
+	FollowersCount  int        `json:"followers_count"`
+	FollowingCount  int        `json:"following_count"`
+	IsFollowing     *bool      `json:"is_following,omitempty"`
+	IsFollowedBy    *bool      `json:"is_followed_by,omitempty"`
+	FollowRequested *bool      `json:"follow_requested,omitempty"` // 閲覧者がフォローリクエスト中か
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // ToPublicUser - Userを PublicUserに変換（閲覧者を考慮）
@@ -96,6 +101,8 @@ func (u *User) ToPublicUser(viewerID *uint) *PublicUser {
 		Occupation:    u.Occupation,
 		EmailVerified: u.EmailVerified,
 		Approved:      u.Approved,
+		IsProtected:   u.IsProtected,
+		PinnedPostID:  u.PinnedPostID,
 		CreatedAt:     u.CreatedAt,
 		UpdatedAt:     u.UpdatedAt,
 	}
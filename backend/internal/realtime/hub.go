@@ -0,0 +1,131 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// イベントタイプ
+const (
+	EventPostCreated    = "post.created"
+	EventLikeCreated    = "like.created"
+	EventCommentCreated = "comment.created"
+	EventMessageCreated = "message.created"
+)
+
+// 購読者ごとのバッファサイズ（溢れたイベントは破棄する）
+const subscriberBufferSize = 32
+
+// Event 配信イベント
+type Event struct {
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Subscription 購読
+type Subscription struct {
+	userID uint
+	events chan Event
+}
+
+// Events 受信用チャネルを取得
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Hub プロセス内のPub/Subハブ（ユーザーID単位で配信）
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[*Subscription]struct{}
+}
+
+var defaultHub = NewHub()
+
+// NewHub Hubのコンストラクタ
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[uint]map[*Subscription]struct{}),
+	}
+}
+
+// GetHub シングルトンインスタンスを取得
+func GetHub() *Hub {
+	return defaultHub
+}
+
+// Subscribe ユーザー宛てのイベントを購読（同一ユーザーの複数接続に対応）
+func (h *Hub) Subscribe(userID uint) *Subscription {
+	sub := &Subscription{
+		userID: userID,
+		events: make(chan Event, subscriberBufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+
+	return sub
+}
+
+// Unsubscribe 購読を解除
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subscribers[sub.userID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.userID)
+	}
+	close(sub.events)
+}
+
+// IsSubscribed ユーザーが購読中か確認
+func (h *Hub) IsSubscribed(userID uint) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.subscribers[userID]) > 0
+}
+
+// HasSubscribers 購読者が1人でもいるか確認（配信先の算出を省略するため）
+func (h *Hub) HasSubscribers() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.subscribers) > 0
+}
+
+// Publish 指定ユーザーへイベントを配信（ブロックしない）
+func (h *Hub) Publish(userIDs []uint, eventType string, data interface{}) {
+	event := Event{
+		Type:      eventType,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, userID := range userIDs {
+		for sub := range h.subscribers[userID] {
+			select {
+			case sub.events <- event:
+			default:
+				// 受信が追いつかない購読者のイベントは破棄
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_Publish(t *testing.T) {
+	t.Run("購読中のユーザーにのみ配信される", func(t *testing.T) {
+		hub := NewHub()
+		alice := hub.Subscribe(1)
+		bob := hub.Subscribe(2)
+		defer hub.Unsubscribe(alice)
+		defer hub.Unsubscribe(bob)
+
+		hub.Publish([]uint{1}, EventLikeCreated, map[string]interface{}{"post_id": 10})
+
+		require.Len(t, alice.Events(), 1)
+		event := <-alice.Events()
+		assert.Equal(t, EventLikeCreated, event.Type)
+		assert.Len(t, bob.Events(), 0, "宛先以外には配信されない")
+	})
+
+	t.Run("同一ユーザーの複数接続すべてに配信される", func(t *testing.T) {
+		hub := NewHub()
+		first := hub.Subscribe(1)
+		second := hub.Subscribe(1)
+		defer hub.Unsubscribe(first)
+		defer hub.Unsubscribe(second)
+
+		hub.Publish([]uint{1}, EventPostCreated, nil)
+
+		assert.Len(t, first.Events(), 1)
+		assert.Len(t, second.Events(), 1)
+	})
+
+	t.Run("バッファが溢れてもブロックしない", func(t *testing.T) {
+		hub := NewHub()
+		sub := hub.Subscribe(1)
+		defer hub.Unsubscribe(sub)
+
+		for i := 0; i < subscriberBufferSize*2; i++ {
+			hub.Publish([]uint{1}, EventCommentCreated, i)
+		}
+
+		assert.Len(t, sub.Events(), subscriberBufferSize)
+	})
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	t.Run("購読解除でチャネルが閉じられる", func(t *testing.T) {
+		hub := NewHub()
+		sub := hub.Subscribe(1)
+		assert.True(t, hub.IsSubscribed(1))
+		assert.True(t, hub.HasSubscribers())
+
+		hub.Unsubscribe(sub)
+
+		_, ok := <-sub.Events()
+		assert.False(t, ok, "チャネルは閉じられるべき")
+		assert.False(t, hub.IsSubscribed(1))
+		assert.False(t, hub.HasSubscribers())
+	})
+
+	t.Run("二重の購読解除でパニックしない", func(t *testing.T) {
+		hub := NewHub()
+		sub := hub.Subscribe(1)
+
+		hub.Unsubscribe(sub)
+		assert.NotPanics(t, func() { hub.Unsubscribe(sub) })
+	})
+
+	t.Run("購読解除後の配信は無視される", func(t *testing.T) {
+		hub := NewHub()
+		sub := hub.Subscribe(1)
+		hub.Unsubscribe(sub)
+
+		assert.NotPanics(t, func() { hub.Publish([]uint{1}, EventLikeCreated, nil) })
+	})
+}
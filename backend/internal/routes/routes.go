@@ -27,9 +27,9 @@ func SetupRoutes(e *echo.Echo) {
 	{
 		users.GET("/:username", handlers.GetUserByUsername, middleware.OptionalJWTAuth())
 		users.PUT("/me", handlers.UpdateProfile, middleware.JWTAuth())
-		users.GET("/:username/posts", handlers.GetUserPosts)
-		users.GET("/:username/followers", handlers.GetFollowers)
-		users.GET("/:username/following", handlers.GetFollowing)
+		users.GET("/:username/posts", handlers.GetUserPosts, middleware.OptionalJWTAuth())
+		users.GET("/:username/followers", handlers.GetFollowers, middleware.OptionalJWTAuth())
+		users.GET("/:username/following", handlers.GetFollowing, middleware.OptionalJWTAuth())
 		users.POST("/:username/follow", handlers.FollowUser, middleware.JWTAuth())
 		users.DELETE("/:username/follow", handlers.UnfollowUser, middleware.JWTAuth())
 	}
@@ -42,19 +42,22 @@ func SetupRoutes(e *echo.Echo) {
 		posts.POST("", handlers.CreatePost, middleware.JWTAuth())
 		posts.PUT("/:id", handlers.UpdatePost, middleware.JWTAuth())
 		posts.DELETE("/:id", handlers.DeletePost, middleware.JWTAuth())
+		posts.GET("/:id/history", handlers.GetPostHistory, middleware.OptionalJWTAuth())
 
 		// コメントルート
-		posts.GET("/:id/comments", handlers.GetComments)
+		posts.GET("/:id/comments", handlers.GetComments, middleware.OptionalJWTAuth())
 		posts.POST("/:id/comments", handlers.CreateComment, middleware.JWTAuth())
 
 		// いいねルート
 		posts.POST("/:id/like", handlers.LikePost, middleware.JWTAuth())
 		posts.DELETE("/:id/like", handlers.UnlikePost, middleware.JWTAuth())
-		posts.GET("/:id/likes", handlers.GetLikes)
+		posts.GET("/:id/likes", handlers.GetLikes, middleware.OptionalJWTAuth())
 	}
 
-	// コメント削除ルート
+	// コメント削除・返信ルート
 	api.DELETE("/comments/:id", handlers.DeleteComment, middleware.JWTAuth())
+	api.GET("/comments/:id/replies", handlers.GetReplies, middleware.OptionalJWTAuth())
+	api.POST("/comments/:id/replies", handlers.CreateReply, middleware.JWTAuth())
 
 	// ハッシュタグルート（Phase 2）
 	hashtagHandler := handlers.NewHashtagHandler()
@@ -69,7 +72,42 @@ func SetupRoutes(e *echo.Echo) {
 	{
 		posts.POST("/:id/bookmark", bookmarkHandler.BookmarkPost, middleware.JWTAuth())
 		posts.DELETE("/:id/bookmark", bookmarkHandler.UnbookmarkPost, middleware.JWTAuth())
+		posts.PUT("/:id/bookmark", bookmarkHandler.MoveBookmark, middleware.JWTAuth())
 		api.GET("/bookmarks", bookmarkHandler.GetBookmarks, middleware.JWTAuth())
+
+		// コレクション
+		api.GET("/bookmarks/collections", bookmarkHandler.GetCollections, middleware.JWTAuth())
+		api.POST("/bookmarks/collections", bookmarkHandler.CreateCollection, middleware.JWTAuth())
+		api.PUT("/bookmarks/collections/:id", bookmarkHandler.RenameCollection, middleware.JWTAuth())
+		api.DELETE("/bookmarks/collections/:id", bookmarkHandler.DeleteCollection, middleware.JWTAuth())
+		api.POST("/bookmarks/collections/:id/share", bookmarkHandler.ShareCollection, middleware.JWTAuth())
+		api.DELETE("/bookmarks/collections/:id/share", bookmarkHandler.UnshareCollection, middleware.JWTAuth())
+		api.GET("/bookmarks/shared/:token", bookmarkHandler.GetSharedCollection, middleware.OptionalJWTAuth())
+	}
+
+	// 投稿の固定ルート
+	pinHandler := handlers.NewPinHandler()
+	{
+		posts.POST("/:id/pin", pinHandler.PinPost, middleware.JWTAuth())
+		posts.DELETE("/:id/pin", pinHandler.UnpinPost, middleware.JWTAuth())
+	}
+
+	// アンケートルート
+	pollHandler := handlers.NewPollHandler()
+	{
+		posts.POST("/:id/poll/votes", pollHandler.Vote, middleware.JWTAuth())
+	}
+
+	// 下書き・予約投稿ルート
+	draftHandler := handlers.NewDraftHandler()
+	drafts := api.Group("/drafts", middleware.JWTAuth())
+	{
+		drafts.GET("", draftHandler.GetDrafts)
+		drafts.POST("", draftHandler.CreateDraft)
+		drafts.GET("/:id", draftHandler.GetDraft)
+		drafts.PUT("/:id", draftHandler.UpdateDraft)
+		drafts.DELETE("/:id", draftHandler.CancelDraft)
+		drafts.POST("/:id/publish", draftHandler.PublishDraft)
 	}
 
 	// パスワードリセットルート（Phase 2）
@@ -92,5 +130,74 @@ func SetupRoutes(e *echo.Echo) {
 	{
 		media.POST("/upload", mediaHandler.UploadMedia, middleware.JWTAuth())
 		media.DELETE("/:id", mediaHandler.DeleteMedia, middleware.JWTAuth())
+
+		// 再開可能な分割アップロード
+		media.POST("/uploads", mediaHandler.CreateUploadSession, middleware.JWTAuth())
+		media.GET("/uploads/:id", mediaHandler.GetUploadSession, middleware.JWTAuth())
+		media.PATCH("/uploads/:id", mediaHandler.UploadChunk, middleware.JWTAuth())
+		media.POST("/uploads/:id/complete", mediaHandler.CompleteUploadSession, middleware.JWTAuth())
+		media.DELETE("/uploads/:id", mediaHandler.CancelUploadSession, middleware.JWTAuth())
+	}
+
+	// 通知ルート
+	notificationHandler := handlers.NewNotificationHandler()
+	notifications := api.Group("/notifications", middleware.JWTAuth())
+	{
+		notifications.GET("", notificationHandler.GetNotifications)
+		notifications.GET("/unread-count", notificationHandler.GetUnreadCount)
+		notifications.PUT("/read-all", notificationHandler.MarkAllAsRead)
+		notifications.PUT("/:id/read", notificationHandler.MarkAsRead)
+	}
+
+	// リアルタイム配信ルート（SSE、Cookie認証）
+	streamHandler := handlers.NewStreamHandler()
+	api.GET("/stream", streamHandler.Stream, middleware.JWTAuth())
+
+	// 検索ルート
+	searchHandler := handlers.NewSearchHandler()
+	search := api.Group("/search")
+	{
+		search.GET("/posts", searchHandler.SearchPosts, middleware.OptionalJWTAuth())
+		search.GET("/users", searchHandler.SearchUsers, middleware.OptionalJWTAuth())
+	}
+
+	// リポストルート
+	repostHandler := handlers.NewRepostHandler()
+	{
+		posts.POST("/:id/repost", repostHandler.Repost, middleware.JWTAuth())
+		posts.DELETE("/:id/repost", repostHandler.Unrepost, middleware.JWTAuth())
+	}
+
+	// ブロック・ミュートルート
+	blockHandler := handlers.NewBlockHandler()
+	{
+		users.POST("/:username/block", blockHandler.BlockUser, middleware.JWTAuth())
+		users.DELETE("/:username/block", blockHandler.UnblockUser, middleware.JWTAuth())
+		users.POST("/:username/mute", blockHandler.MuteUser, middleware.JWTAuth())
+		users.DELETE("/:username/mute", blockHandler.UnmuteUser, middleware.JWTAuth())
+	}
+
+	// メンションルート
+	mentionHandler := handlers.NewMentionHandler()
+	users.GET("/:username/mentions", mentionHandler.GetMentionedPosts, middleware.OptionalJWTAuth())
+
+	// フォローリクエストルート（鍵アカウント）
+	followRequestHandler := handlers.NewFollowRequestHandler()
+	followRequests := api.Group("/follow-requests", middleware.JWTAuth())
+	{
+		followRequests.GET("", followRequestHandler.GetFollowRequests)
+		followRequests.POST("/:id/approve", followRequestHandler.ApproveFollowRequest)
+		followRequests.POST("/:id/reject", followRequestHandler.RejectFollowRequest)
+	}
+
+	// ダイレクトメッセージルート
+	messageHandler := handlers.NewMessageHandler()
+	conversations := api.Group("/conversations", middleware.JWTAuth())
+	{
+		conversations.GET("", messageHandler.GetConversations)
+		conversations.POST("", messageHandler.CreateConversation)
+		conversations.GET("/:id/messages", messageHandler.GetMessages)
+		conversations.POST("/:id/messages", messageHandler.SendMessage)
+		conversations.PUT("/:id/read", messageHandler.MarkConversationAsRead)
 	}
 }
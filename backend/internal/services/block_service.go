@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// BlockService ブロック・ミュートサービス
+type BlockService struct {
+	db *gorm.DB
+}
+
+// NewBlockService BlockServiceのコンストラクタ
+func NewBlockService() *BlockService {
+	return &BlockService{
+		db: database.GetDB(),
+	}
+}
+
+// BlockUser ユーザーをブロック（双方向のフォロー関係を削除）
+// @param ctx コンテキスト
+// @param blockerID ブロックするユーザーID
+// @param username ブロック対象のユーザー名
+// @return error
+func (s *BlockService) BlockUser(ctx context.Context, blockerID uint, username string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	target, err := s.findUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if blockerID == target.ID {
+		return errors.New("cannot block yourself")
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 既にブロック済みかチェック
+		var count int64
+		if err := tx.Model(&models.Block{}).
+			Where("blocker_id = ? AND blocked_id = ?", blockerID, target.ID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("already blocked")
+		}
+
+		if err := tx.Create(&models.Block{BlockerID: blockerID, BlockedID: target.ID}).Error; err != nil {
+			return err
+		}
+
+		// 双方向のフォロー関係・フォローリクエストを削除
+		if err := tx.Where("(follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)",
+			blockerID, target.ID, target.ID, blockerID).
+			Delete(&models.Follow{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("(requester_id = ? AND target_id = ?) OR (requester_id = ? AND target_id = ?)",
+			blockerID, target.ID, target.ID, blockerID).
+			Delete(&models.FollowRequest{}).Error
+	})
+}
+
+// UnblockUser ブロックを解除
+// @param ctx コンテキスト
+// @param blockerID ブロックしているユーザーID
+// @param username ブロック解除対象のユーザー名
+// @return error
+func (s *BlockService) UnblockUser(ctx context.Context, blockerID uint, username string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	target, err := s.findUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, target.ID).
+		Delete(&models.Block{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("block not found")
+	}
+
+	return nil
+}
+
+// MuteUser ユーザーをミュート
+// @param ctx コンテキスト
+// @param muterID ミュートするユーザーID
+// @param username ミュート対象のユーザー名
+// @return error
+func (s *BlockService) MuteUser(ctx context.Context, muterID uint, username string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	target, err := s.findUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if muterID == target.ID {
+		return errors.New("cannot mute yourself")
+	}
+
+	// 既にミュート済みかチェック
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Mute{}).
+		Where("muter_id = ? AND muted_id = ?", muterID, target.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("already muted")
+	}
+
+	return s.db.WithContext(ctx).Create(&models.Mute{MuterID: muterID, MutedID: target.ID}).Error
+}
+
+// UnmuteUser ミュートを解除
+// @param ctx コンテキスト
+// @param muterID ミュートしているユーザーID
+// @param username ミュート解除対象のユーザー名
+// @return error
+func (s *BlockService) UnmuteUser(ctx context.Context, muterID uint, username string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	target, err := s.findUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("muter_id = ? AND muted_id = ?", muterID, target.ID).
+		Delete(&models.Mute{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("mute not found")
+	}
+
+	return nil
+}
+
+// findUser ユーザー名でユーザーを取得
+func (s *BlockService) findUser(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// isBlockedBetween - どちらか一方がもう一方をブロックしているかチェック
+func isBlockedBetween(db *gorm.DB, userID, otherUserID uint) (bool, error) {
+	if userID == otherUserID {
+		return false, nil
+	}
+
+	var count int64
+	if err := db.Model(&models.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)",
+			userID, otherUserID, otherUserID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// hiddenUserIDsSubquery - 閲覧者から見て非表示にするユーザーIDのサブクエリ
+// （閲覧者がブロック・ミュートしているユーザー、閲覧者をブロックしているユーザー）
+const hiddenUserIDsSubquery = `SELECT blocked_id FROM blocks WHERE blocker_id = @viewer
+			UNION SELECT blocker_id FROM blocks WHERE blocked_id = @viewer
+			UNION SELECT muted_id FROM mutes WHERE muter_id = @viewer`
+
+// excludeHiddenUsers - 指定カラムのユーザーが閲覧者から非表示の場合は除外する（未ログインの場合は何もしない）
+func excludeHiddenUsers(query *gorm.DB, column string, viewerID *uint) *gorm.DB {
+	if viewerID == nil {
+		return query
+	}
+	return query.Where(column+" NOT IN ("+hiddenUserIDsSubquery+")", map[string]interface{}{"viewer": *viewerID})
+}
+
+// excludeViewersHiding - 指定ユーザーを非表示にしている閲覧者（指定カラム）を除外する
+// リアルタイム配信の宛先など、閲覧者側を絞り込む場合に使う
+func excludeViewersHiding(query *gorm.DB, viewerColumn string, userID uint) *gorm.DB {
+	return query.Where("@user NOT IN ("+hiddenUserIDsSubquery+")",
+		map[string]interface{}{"user": userID, "viewer": gorm.Expr(viewerColumn)})
+}
+
+// isHiddenFrom - 閲覧者から見てユーザーが非表示か（ブロック関係にある、または閲覧者がミュートしている）
+func isHiddenFrom(db *gorm.DB, viewerID, userID uint) (bool, error) {
+	if viewerID == userID {
+		return false, nil
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).
+		Where("users.id = @user AND users.id IN ("+hiddenUserIDsSubquery+")",
+			map[string]interface{}{"user": userID, "viewer": viewerID}).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// excludeHiddenReposts - 非表示ユーザーの投稿をリポストしたものを除外する（未ログインの場合は何もしない）
+func excludeHiddenReposts(query *gorm.DB, viewerID *uint) *gorm.DB {
+	if viewerID == nil {
+		return query
+	}
+	return query.Where(`(posts.repost_of_id IS NULL OR NOT EXISTS (
+			SELECT 1 FROM posts AS originals WHERE originals.id = posts.repost_of_id
+			AND originals.user_id IN (`+hiddenUserIDsSubquery+`)))`, map[string]interface{}{"viewer": *viewerID})
+}
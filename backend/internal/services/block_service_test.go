@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestBlockService_BlockUser(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewBlockService()
+	ctx := context.Background()
+
+	t.Run("Success - Block removes follows in both directions", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+		testutil.CreateTestFollow(t, db, user1.ID, user2.ID)
+		testutil.CreateTestFollow(t, db, user2.ID, user1.ID)
+
+		err := service.BlockUser(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+
+		var count int64
+		db.Model(&models.Follow{}).Count(&count)
+		assert.Equal(t, int64(0), count, "双方向のフォローが削除されるべき")
+	})
+
+	t.Run("Success - Block prevents follow, like and comment both ways", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+		post1 := testutil.CreateTestPost(t, db, user1.ID, "user1の投稿")
+		post2 := testutil.CreateTestPost(t, db, user2.ID, "user2の投稿")
+
+		require.NoError(t, service.BlockUser(ctx, user1.ID, user2.Username))
+
+		// ブロックされた側からの操作
+		assert.EqualError(t, FollowUser(user2.ID, user1.Username), "blocked")
+		assert.EqualError(t, LikePost(user2.ID, post1.ID), "blocked")
+		_, err := CreateComment(user2.ID, post1.ID, "コメント")
+		assert.EqualError(t, err, "blocked")
+
+		// ブロックした側からの操作
+		assert.EqualError(t, FollowUser(user1.ID, user2.Username), "blocked")
+		assert.EqualError(t, LikePost(user1.ID, post2.ID), "blocked")
+		_, err = CreateComment(user1.ID, post2.ID, "コメント")
+		assert.EqualError(t, err, "blocked")
+	})
+
+	t.Run("Success - Unblock allows follow again", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		require.NoError(t, service.BlockUser(ctx, user1.ID, user2.Username))
+		require.NoError(t, service.UnblockUser(ctx, user1.ID, user2.Username))
+
+		assert.NoError(t, FollowUser(user2.ID, user1.Username))
+	})
+
+	t.Run("Error - Cannot block yourself", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+
+		err := service.BlockUser(ctx, user1.ID, user1.Username)
+		assert.EqualError(t, err, "cannot block yourself")
+	})
+
+	t.Run("Error - Already blocked", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		require.NoError(t, service.BlockUser(ctx, user1.ID, user2.Username))
+		err := service.BlockUser(ctx, user1.ID, user2.Username)
+		assert.EqualError(t, err, "already blocked")
+	})
+
+	t.Run("Error - Unblock without block", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		err := service.UnblockUser(ctx, user1.ID, user2.Username)
+		assert.EqualError(t, err, "block not found")
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+
+		err := service.BlockUser(ctx, user1.ID, "nobody")
+		assert.EqualError(t, err, "user not found")
+	})
+}
+
+func TestBlockService_FeedFiltering(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewBlockService()
+	ctx := context.Background()
+
+	t.Run("Success - Muted user is hidden from timeline, comments and likes", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		viewer := testutil.CreateTestUser(t, db, "viewer@example.com", "viewer", "password123")
+		muted := testutil.CreateTestUser(t, db, "muted@example.com", "muted", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+
+		mutedPost := testutil.CreateTestPost(t, db, muted.ID, "ミュートされる投稿")
+		otherPost := testutil.CreateTestPost(t, db, other.ID, "通常の投稿")
+		testutil.CreateTestComment(t, db, otherPost.ID, muted.ID, "ミュートされるコメント")
+		testutil.CreateTestComment(t, db, otherPost.ID, other.ID, "通常のコメント")
+		testutil.CreateTestLike(t, db, otherPost.ID, muted.ID)
+		testutil.CreateTestLike(t, db, otherPost.ID, other.ID)
+
+		require.NoError(t, service.MuteUser(ctx, viewer.ID, muted.Username))
+
+		posts, _, _, err := GetTimeline(&viewer.ID, "all", 20, nil)
+		require.NoError(t, err)
+		for _, p := range posts {
+			assert.NotEqual(t, mutedPost.ID, p.ID, "ミュートしたユーザーの投稿は表示されないべき")
+		}
+		assert.Len(t, posts, 1)
+
+		comments, _, _, err := GetCommentsByPostID(otherPost.ID, &viewer.ID, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		assert.Equal(t, other.ID, comments[0].UserID)
+
+		users, _, _, err := GetLikesByPostID(otherPost.ID, &viewer.ID, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, other.ID, users[0].ID)
+
+		// 未ログインでは除外しない
+		posts, _, _, err = GetTimeline(nil, "all", 20, nil)
+		require.NoError(t, err)
+		assert.Len(t, posts, 2)
+	})
+
+	t.Run("Success - Blocker is hidden from blocked user's hashtag feed", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		blocker := testutil.CreateTestUser(t, db, "blocker@example.com", "blocker", "password123")
+		blocked := testutil.CreateTestUser(t, db, "blocked@example.com", "blocked", "password123")
+
+		post := testutil.CreateTestPost(t, db, blocker.ID, "#golang の投稿")
+		require.NoError(t, NewHashtagService().ProcessHashtags(ctx, post.ID, post.Content))
+
+		require.NoError(t, service.BlockUser(ctx, blocker.ID, blocked.Username))
+
+		posts, _, _, err := NewHashtagService().GetPostsByHashtag(ctx, "golang", blocked.ID, 20, 0)
+		require.NoError(t, err)
+		assert.Empty(t, posts, "ブロックしてきたユーザーの投稿は表示されないべき")
+
+		posts, _, _, err = NewHashtagService().GetPostsByHashtag(ctx, "golang", 0, 20, 0)
+		require.NoError(t, err)
+		assert.Len(t, posts, 1)
+	})
+
+	t.Run("Success - Reposts of muted user's posts are hidden", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		viewer := testutil.CreateTestUser(t, db, "viewer@example.com", "viewer", "password123")
+		muted := testutil.CreateTestUser(t, db, "muted@example.com", "muted", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		testutil.CreateTestFollow(t, db, viewer.ID, reposter.ID)
+
+		original := testutil.CreateTestPost(t, db, muted.ID, "ミュートされる投稿")
+		_, err := NewRepostService().Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, service.MuteUser(ctx, viewer.ID, muted.Username))
+
+		posts, _, _, err := GetTimeline(&viewer.ID, "following", 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("Success - Muted user's mentions and quoted posts are hidden", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		viewer := testutil.CreateTestUser(t, db, "viewer@example.com", "viewer", "password123")
+		muted := testutil.CreateTestUser(t, db, "muted@example.com", "muted", "password123")
+		quoter := testutil.CreateTestUser(t, db, "quoter@example.com", "quoter", "password123")
+		testutil.CreateTestFollow(t, db, viewer.ID, quoter.ID)
+
+		// ミュート前のメンション通知も表示されなくなる
+		before, err := CreatePost(muted.ID, "@viewer ミュート前 harassment")
+		require.NoError(t, err)
+		require.NoError(t, service.MuteUser(ctx, viewer.ID, muted.Username))
+		_, err = CreatePost(muted.ID, "@viewer ミュート後 harassment")
+		require.NoError(t, err)
+		quote, err := CreateQuotePost(quoter.ID, before.ID, "引用")
+		require.NoError(t, err)
+
+		var notificationCount int64
+		require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ?", viewer.ID).Count(&notificationCount).Error)
+		assert.Equal(t, int64(1), notificationCount, "ミュート後のメンションは通知されないべき")
+
+		notificationService := NewNotificationService()
+		groups, _, _, err := notificationService.GetNotifications(ctx, viewer.ID, 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+		unread, err := notificationService.GetUnreadCount(ctx, viewer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), unread)
+
+		mentioned, _, _, err := NewMentionService().GetMentionedPosts(ctx, viewer.Username, &viewer.ID, 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, mentioned)
+
+		found, _, _, err := NewSearchService().SearchPosts(ctx, "harassment", &viewer.ID, 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		// 第三者の引用に埋め込まれた元投稿はスタブになる
+		posts, _, _, err := GetTimeline(&viewer.ID, "following", 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, quote.ID, posts[0].ID)
+		require.NotNil(t, posts[0].QuotedPost)
+		assert.True(t, posts[0].QuotedPost.Unavailable)
+	})
+
+	t.Run("Success - Blocked user cannot notify", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		blocker := testutil.CreateTestUser(t, db, "blocker@example.com", "blocker", "password123")
+		blocked := testutil.CreateTestUser(t, db, "blocked@example.com", "blocked", "password123")
+		require.NoError(t, service.BlockUser(ctx, blocker.ID, blocked.Username))
+
+		_, err := CreatePost(blocked.ID, "@blocker メンション")
+		require.NoError(t, err)
+
+		var notificationCount int64
+		require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ?", blocker.ID).Count(&notificationCount).Error)
+		assert.Equal(t, int64(0), notificationCount)
+	})
+
+	t.Run("Error - Already muted", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		viewer := testutil.CreateTestUser(t, db, "viewer@example.com", "viewer", "password123")
+		muted := testutil.CreateTestUser(t, db, "muted@example.com", "muted", "password123")
+
+		require.NoError(t, service.MuteUser(ctx, viewer.ID, muted.Username))
+		assert.EqualError(t, service.MuteUser(ctx, viewer.ID, muted.Username), "already muted")
+
+		require.NoError(t, service.UnmuteUser(ctx, viewer.ID, muted.Username))
+		assert.EqualError(t, service.UnmuteUser(ctx, viewer.ID, muted.Username), "mute not found")
+	})
+}
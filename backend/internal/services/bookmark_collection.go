@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// BookmarkCollectionNameMaxLength コレクション名の最大文字数
+const BookmarkCollectionNameMaxLength = 50
+
+// ListCollections 自分のコレクション一覧を取得（既定のコレクションを先頭に、作成順）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @return ブックマーク数を含むコレクションリスト, error
+func (s *BookmarkService) ListCollections(ctx context.Context, userID uint) ([]models.BookmarkCollection, error) {
+	db := s.db.WithContext(ctx)
+
+	// 既定のコレクションは常に表示する
+	if _, err := defaultBookmarkCollection(db, userID); err != nil {
+		return nil, err
+	}
+
+	collections := []models.BookmarkCollection{}
+	if err := db.Where("user_id = ?", userID).
+		Order("is_default DESC, created_at ASC, id ASC").
+		Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	// 削除済み・未公開の投稿を除いたブックマーク数をGROUP BYで一括集計
+	var counts []struct {
+		CollectionID uint
+		Count        int64
+	}
+	if err := db.Model(&models.Bookmark{}).
+		Select("bookmarks.collection_id, COUNT(*) AS count").
+		Joins("INNER JOIN posts ON posts.id = bookmarks.post_id AND posts.deleted_at IS NULL").
+		Where("bookmarks.user_id = ?", userID).
+		Where(publishedPostCondition).
+		Group("bookmarks.collection_id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	countMap := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countMap[count.CollectionID] = count.Count
+	}
+	for i := range collections {
+		collections[i].BookmarksCount = countMap[collections[i].ID]
+	}
+
+	return collections, nil
+}
+
+// CreateCollection コレクションを作成
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param name コレクション名
+// @return 作成されたコレクション, error
+func (s *BookmarkService) CreateCollection(ctx context.Context, userID uint, name string) (*models.BookmarkCollection, error) {
+	db := s.db.WithContext(ctx)
+
+	name, err := s.validateCollectionName(db, userID, 0, name)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &models.BookmarkCollection{
+		UserID: userID,
+		Name:   name,
+	}
+	if err := db.Create(collection).Error; err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// RenameCollection コレクション名を変更（既定のコレクションは変更不可）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param collectionID コレクションID
+// @param name 新しいコレクション名
+// @return 更新されたコレクション, error
+func (s *BookmarkService) RenameCollection(ctx context.Context, userID, collectionID uint, name string) (*models.BookmarkCollection, error) {
+	db := s.db.WithContext(ctx)
+
+	collection, err := findBookmarkCollection(db, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.IsDefault {
+		return nil, errors.New("cannot modify default collection")
+	}
+
+	name, err = s.validateCollectionName(db, userID, collection.ID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(collection).Update("name", name).Error; err != nil {
+		return nil, err
+	}
+	collection.Name = name
+	return collection, nil
+}
+
+// DeleteCollection コレクションを削除（含まれていたブックマークは既定のコレクションへ移す）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param collectionID コレクションID
+// @return error
+func (s *BookmarkService) DeleteCollection(ctx context.Context, userID, collectionID uint) error {
+	db := s.db.WithContext(ctx)
+
+	collection, err := findBookmarkCollection(db, userID, collectionID)
+	if err != nil {
+		return err
+	}
+	if collection.IsDefault {
+		return errors.New("cannot modify default collection")
+	}
+
+	defaultCollection, err := defaultBookmarkCollection(db, userID)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Bookmark{}).
+			Where("collection_id = ?", collection.ID).
+			Update("collection_id", defaultCollection.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(collection).Error
+	})
+}
+
+// MoveBookmark ブックマークを別のコレクションへ移動
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID ブックマークした投稿ID
+// @param collectionID 移動先のコレクションID
+// @return error
+func (s *BookmarkService) MoveBookmark(ctx context.Context, userID, postID, collectionID uint) error {
+	db := s.db.WithContext(ctx)
+
+	if _, err := findBookmarkCollection(db, userID, collectionID); err != nil {
+		return err
+	}
+
+	result := db.Model(&models.Bookmark{}).
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		Update("collection_id", collectionID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("bookmark not found")
+	}
+	return nil
+}
+
+// ShareCollection コレクションの公開リンクを発行（発行済みの場合は同じリンクを返す）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param collectionID コレクションID
+// @return 公開リンク用のトークンを含むコレクション, error
+func (s *BookmarkService) ShareCollection(ctx context.Context, userID, collectionID uint) (*models.BookmarkCollection, error) {
+	db := s.db.WithContext(ctx)
+
+	collection, err := findBookmarkCollection(db, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.ShareToken != nil {
+		return collection, nil
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Model(collection).Update("share_token", token).Error; err != nil {
+		return nil, err
+	}
+	collection.ShareToken = &token
+	return collection, nil
+}
+
+// UnshareCollection コレクションの公開リンクを無効化（発行済みのリンクは使えなくなる）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param collectionID コレクションID
+// @return error
+func (s *BookmarkService) UnshareCollection(ctx context.Context, userID, collectionID uint) error {
+	db := s.db.WithContext(ctx)
+
+	collection, err := findBookmarkCollection(db, userID, collectionID)
+	if err != nil {
+		return err
+	}
+	return db.Model(collection).Update("share_token", nil).Error
+}
+
+// GetSharedCollection 公開リンクからコレクションと投稿を取得
+// 閲覧者が見られない投稿（公開範囲外・鍵アカウント・ブロック/ミュート）は含めない
+// @param ctx コンテキスト
+// @param token 公開リンク用のトークン
+// @param viewerID 閲覧者のユーザーID（未ログインの場合はnil）
+// @param limit 取得件数
+// @param cursor カーソル（最後の投稿ID）
+// @return コレクション, 所有者, 投稿リスト, さらにデータがあるか, 次のカーソル, error
+func (s *BookmarkService) GetSharedCollection(ctx context.Context, token string, viewerID *uint, limit int, cursor *string) (*models.BookmarkCollection, *models.User, []models.Post, bool, string, error) {
+	db := s.db.WithContext(ctx)
+
+	var collection models.BookmarkCollection
+	if err := db.Where("share_token = ?", token).Preload("User").First(&collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, false, "", errors.New("collection not found")
+		}
+		return nil, nil, nil, false, "", err
+	}
+
+	// 所有者が鍵アカウントでフォローしていない場合・ブロック関係にある場合は公開しない
+	owner := collection.User
+	if canView, err := canViewUserContent(db, &owner, viewerID); err != nil {
+		return nil, nil, nil, false, "", err
+	} else if !canView {
+		return nil, nil, nil, false, "", errors.New("collection not found")
+	}
+	if viewerID != nil {
+		blocked, err := isBlockedBetween(db, *viewerID, owner.ID)
+		if err != nil {
+			return nil, nil, nil, false, "", err
+		}
+		if blocked {
+			return nil, nil, nil, false, "", errors.New("collection not found")
+		}
+	}
+
+	posts, hasMore, nextCursor, err := s.listBookmarkedPosts(ctx, owner.ID, &collection.ID, viewerID, limit, cursor)
+	if err != nil {
+		return nil, nil, nil, false, "", err
+	}
+	return &collection, &owner, posts, hasMore, nextCursor, nil
+}
+
+// validateCollectionName コレクション名を検証し、前後の空白を除いた名前を返す
+// excludeID には名前を変更するコレクション自身のIDを指定する（作成時は0）
+func (s *BookmarkService) validateCollectionName(db *gorm.DB, userID, excludeID uint, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("collection name is required")
+	}
+	if utf8.RuneCountInString(name) > BookmarkCollectionNameMaxLength {
+		return "", errors.New("collection name is too long")
+	}
+
+	// 既定のコレクション名は予約済み
+	if name == models.DefaultBookmarkCollectionName {
+		return "", errors.New("collection name already exists")
+	}
+
+	var count int64
+	if err := db.Model(&models.BookmarkCollection{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, excludeID).
+		Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return "", errors.New("collection name already exists")
+	}
+	return name, nil
+}
+
+// findBookmarkCollection 自分のコレクションを取得
+func findBookmarkCollection(db *gorm.DB, userID, collectionID uint) (*models.BookmarkCollection, error) {
+	var collection models.BookmarkCollection
+	if err := db.Where("id = ? AND user_id = ?", collectionID, userID).First(&collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("collection not found")
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// defaultBookmarkCollection 既定のコレクションを取得（存在しない場合は作成）
+func defaultBookmarkCollection(db *gorm.DB, userID uint) (*models.BookmarkCollection, error) {
+	var collection models.BookmarkCollection
+	err := db.Where("user_id = ? AND is_default = ?", userID, true).First(&collection).Error
+	if err == nil {
+		return &collection, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	collection = models.BookmarkCollection{
+		UserID:    userID,
+		Name:      models.DefaultBookmarkCollectionName,
+		IsDefault: true,
+	}
+	if err := db.Create(&collection).Error; err != nil {
+		// 同時に作成された場合は作成済みのものを使う（名前の一意制約で重複しない）
+		var existing models.BookmarkCollection
+		if findErr := db.Where("user_id = ? AND is_default = ?", userID, true).First(&existing).Error; findErr != nil {
+			return nil, err
+		}
+		return &existing, nil
+	}
+	return &collection, nil
+}
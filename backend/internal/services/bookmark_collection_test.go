@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestBookmarkCollections(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+	service := &BookmarkService{db: db}
+
+	t.Run("Success - Move bookmarks, filter by collection and count", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		first := testutil.CreateTestPost(t, db, user.ID, "1つ目")
+		second := testutil.CreateTestPost(t, db, user.ID, "2つ目")
+		require.NoError(t, service.BookmarkPost(ctx, user.ID, first.ID))
+		require.NoError(t, service.BookmarkPost(ctx, user.ID, second.ID))
+
+		recipes, err := service.CreateCollection(ctx, user.ID, " レシピ ")
+		require.NoError(t, err)
+		assert.Equal(t, "レシピ", recipes.Name)
+		require.NoError(t, service.MoveBookmark(ctx, user.ID, second.ID, recipes.ID))
+
+		filtered, _, _, err := service.GetBookmarks(ctx, user.ID, &recipes.ID, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, filtered, 1)
+		assert.Equal(t, second.ID, filtered[0].ID)
+
+		all, _, _, err := service.GetBookmarks(ctx, user.ID, nil, 20, nil)
+		require.NoError(t, err)
+		assert.Len(t, all, 2, "コレクションを指定しない場合はすべてのブックマークを返すべき")
+
+		collections, err := service.ListCollections(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, collections, 2)
+		assert.True(t, collections[0].IsDefault, "既定のコレクションが先頭に並ぶべき")
+		assert.Equal(t, int64(1), collections[0].BookmarksCount)
+		assert.Equal(t, recipes.ID, collections[1].ID)
+		assert.Equal(t, int64(1), collections[1].BookmarksCount)
+	})
+
+	t.Run("Success - Rename and delete moves bookmarks to default", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "投稿")
+		require.NoError(t, service.BookmarkPost(ctx, user.ID, post.ID))
+
+		collection, err := service.CreateCollection(ctx, user.ID, "あとで読む")
+		require.NoError(t, err)
+		require.NoError(t, service.MoveBookmark(ctx, user.ID, post.ID, collection.ID))
+
+		renamed, err := service.RenameCollection(ctx, user.ID, collection.ID, "読む")
+		require.NoError(t, err)
+		assert.Equal(t, "読む", renamed.Name)
+
+		require.NoError(t, service.DeleteCollection(ctx, user.ID, collection.ID))
+
+		collections, err := service.ListCollections(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, collections, 1)
+		assert.Equal(t, int64(1), collections[0].BookmarksCount, "削除したコレクションのブックマークは既定のコレクションへ移るべき")
+	})
+
+	t.Run("Success - Migration moves existing bookmarks into default collection", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "移行前のブックマーク")
+		require.NoError(t, db.Create(&models.Bookmark{UserID: user.ID, PostID: post.ID}).Error)
+
+		require.NoError(t, database.MigrateBookmarkCollections(db))
+		require.NoError(t, database.MigrateBookmarkCollections(db), "再実行しても失敗しないべき")
+
+		var bookmark models.Bookmark
+		require.NoError(t, db.Where("user_id = ? AND post_id = ?", user.ID, post.ID).First(&bookmark).Error)
+		require.NotNil(t, bookmark.CollectionID)
+
+		collections, err := service.ListCollections(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, collections, 1)
+		assert.Equal(t, *bookmark.CollectionID, collections[0].ID)
+		assert.Equal(t, models.DefaultBookmarkCollectionName, collections[0].Name)
+		assert.Equal(t, int64(1), collections[0].BookmarksCount)
+	})
+
+	t.Run("Success - Shared link hides posts the viewer cannot see", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		testutil.CreateTestFollow(t, db, owner.ID, author.ID)
+		public := testutil.CreateTestPost(t, db, author.ID, "公開")
+		followersOnly, err := CreatePostWithMedia(author.ID, "フォロワー限定", PostMediaInput{}, models.PostVisibilityFollowers)
+		require.NoError(t, err)
+
+		collection, err := service.CreateCollection(ctx, owner.ID, "おすすめ")
+		require.NoError(t, err)
+		for _, postID := range []uint{public.ID, followersOnly.ID} {
+			require.NoError(t, service.BookmarkPost(ctx, owner.ID, postID))
+			require.NoError(t, service.MoveBookmark(ctx, owner.ID, postID, collection.ID))
+		}
+
+		shared, err := service.ShareCollection(ctx, owner.ID, collection.ID)
+		require.NoError(t, err)
+		require.NotNil(t, shared.ShareToken)
+
+		again, err := service.ShareCollection(ctx, owner.ID, collection.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *shared.ShareToken, *again.ShareToken, "発行済みのリンクを返すべき")
+
+		got, sharedOwner, posts, _, _, err := service.GetSharedCollection(ctx, *shared.ShareToken, nil, 20, nil)
+		require.NoError(t, err)
+		assert.Equal(t, collection.ID, got.ID)
+		assert.Equal(t, owner.ID, sharedOwner.ID)
+		require.Len(t, posts, 1, "未ログインの閲覧者にはフォロワー限定の投稿を含めないべき")
+		assert.Equal(t, public.ID, posts[0].ID)
+
+		require.NoError(t, service.UnshareCollection(ctx, owner.ID, collection.ID))
+		_, _, _, _, _, err = service.GetSharedCollection(ctx, *shared.ShareToken, nil, 20, nil)
+		assert.EqualError(t, err, "collection not found")
+	})
+
+	t.Run("Error - Invalid names, default collection and other users' collections", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "投稿")
+
+		_, err := service.CreateCollection(ctx, user.ID, "  ")
+		assert.EqualError(t, err, "collection name is required")
+		_, err = service.CreateCollection(ctx, user.ID, models.DefaultBookmarkCollectionName)
+		assert.EqualError(t, err, "collection name already exists")
+		_, err = service.CreateCollection(ctx, user.ID, "旅行")
+		require.NoError(t, err)
+		_, err = service.CreateCollection(ctx, user.ID, "旅行")
+		assert.EqualError(t, err, "collection name already exists")
+
+		collections, err := service.ListCollections(ctx, user.ID)
+		require.NoError(t, err)
+		defaultID := collections[0].ID
+		_, err = service.RenameCollection(ctx, user.ID, defaultID, "名前")
+		assert.EqualError(t, err, "cannot modify default collection")
+		assert.EqualError(t, service.DeleteCollection(ctx, user.ID, defaultID), "cannot modify default collection")
+
+		// 他人のコレクションは操作・閲覧できない
+		assert.EqualError(t, service.DeleteCollection(ctx, other.ID, collections[1].ID), "collection not found")
+		_, _, _, err = service.GetBookmarks(ctx, other.ID, &collections[1].ID, 20, nil)
+		assert.EqualError(t, err, "collection not found")
+
+		// ブックマークしていない投稿は移動できない
+		assert.EqualError(t, service.MoveBookmark(ctx, user.ID, post.ID, collections[1].ID), "bookmark not found")
+	})
+}
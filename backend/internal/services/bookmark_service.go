@@ -34,6 +34,13 @@ func (s *BookmarkService) BookmarkPost(ctx context.Context, userID, postID uint)
 		return err
 	}
 
+	// 下書き・予約投稿、閲覧できない投稿はブックマーク不可
+	if canView, err := canViewPost(s.db.WithContext(ctx), &post, &userID); err != nil {
+		return err
+	} else if !canView {
+		return errors.New("post not found")
+	}
+
 	// 重複チェック
 	var count int64
 	s.db.WithContext(ctx).Model(&models.Bookmark{}).
@@ -45,10 +52,17 @@ func (s *BookmarkService) BookmarkPost(ctx context.Context, userID, postID uint)
 		return nil
 	}
 
+	// 既定のコレクションに追加
+	collection, err := defaultBookmarkCollection(s.db.WithContext(ctx), userID)
+	if err != nil {
+		return err
+	}
+
 	// ブックマーク作成
 	bookmark := &models.Bookmark{
-		UserID: userID,
-		PostID: postID,
+		UserID:       userID,
+		PostID:       postID,
+		CollectionID: &collection.ID,
 	}
 
 	if err := s.db.WithContext(ctx).Create(bookmark).Error; err != nil {
@@ -72,39 +86,56 @@ func (s *BookmarkService) UnbookmarkPost(ctx context.Context, userID, postID uin
 	return nil
 }
 
-// GetBookmarks ブックマーク一覧を取得（ページネーション対応）
-func (s *BookmarkService) GetBookmarks(ctx context.Context, userID uint, limit int, cursor *string) ([]models.Post, bool, string, error) {
+// GetBookmarks ブックマーク一覧を取得（ページネーション対応、collectionIDを指定するとそのコレクションのみ）
+func (s *BookmarkService) GetBookmarks(ctx context.Context, userID uint, collectionID *uint, limit int, cursor *string) ([]models.Post, bool, string, error) {
+	if collectionID != nil {
+		if _, err := findBookmarkCollection(s.db.WithContext(ctx), userID, *collectionID); err != nil {
+			return nil, false, "", err
+		}
+	}
+
+	return s.listBookmarkedPosts(ctx, userID, collectionID, &userID, limit, cursor)
+}
+
+// listBookmarkedPosts ユーザーがブックマークした投稿を取得（公開リンクでは所有者以外が閲覧する）
+func (s *BookmarkService) listBookmarkedPosts(ctx context.Context, ownerID uint, collectionID *uint, viewerID *uint, limit int, cursor *string) ([]models.Post, bool, string, error) {
 	if limit <= 0 {
 		return nil, false, "", errors.New("limit must be greater than 0")
 	}
 
 	// サブクエリで集計（N+1問題解消）
-	type PostWithCounts struct {
-		models.Post
-		LikesCount    int64 `gorm:"column:likes_count"`
-		CommentsCount int64 `gorm:"column:comments_count"`
-	}
-
 	query := s.db.WithContext(ctx).Model(&models.Post{}).
-		Select(`posts.*,
-			(SELECT COUNT(*) FROM post_likes WHERE post_likes.post_id = posts.id) as likes_count,
-			(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) as comments_count`).
+		Select(postCountsSelect).
 		Joins("INNER JOIN bookmarks ON bookmarks.post_id = posts.id").
-		Where("bookmarks.user_id = ?", userID).
+		Where("bookmarks.user_id = ?", ownerID).
+		Where(publishedPostCondition).
 		Preload("User").
 		Preload("Media")
 
+	if collectionID != nil {
+		query = query.Where("bookmarks.collection_id = ?", *collectionID)
+	}
+
+	// ブックマーク後にフォロー解除した場合など、公開範囲外になった投稿は表示しない
+	query = excludeInvisiblePosts(query, viewerID)
+
+	// 所有者以外が閲覧する場合は、閲覧者から見えない鍵アカウント・ブロック/ミュートしているユーザーの投稿も除外
+	if viewerID == nil || *viewerID != ownerID {
+		query = excludeProtectedAuthors(query, "posts.user_id", viewerID)
+		query = excludeHiddenUsers(query, "posts.user_id", viewerID)
+	}
+
 	// カーソルベースページネーション
 	if cursor != nil && *cursor != "" {
 		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
 		if err == nil {
-			query = query.Where("posts.id < ?", cursorID)
+			query = beforePostCursor(query, cursorID)
 		}
 	}
 
 	// 取得件数+1を取得して、次のページがあるか判定
-	var results []PostWithCounts
-	if err := query.Order("posts.created_at DESC").Limit(limit + 1).Find(&results).Error; err != nil {
+	var results []postWithCounts
+	if err := query.Order(postFeedOrder).Limit(limit + 1).Find(&results).Error; err != nil {
 		return nil, false, "", err
 	}
 
@@ -113,15 +144,8 @@ func (s *BookmarkService) GetBookmarks(ctx context.Context, userID uint, limit i
 		results = results[:limit]
 	}
 
-	// PostWithCounts から models.Post に変換
-	posts := make([]models.Post, len(results))
-	for i := range results {
-		posts[i] = results[i].Post
-		posts[i].LikesCount = results[i].LikesCount
-		posts[i].CommentsCount = results[i].CommentsCount
-		posts[i].User = results[i].Post.User
-		posts[i].Media = results[i].Post.Media
-	}
+	// postWithCounts から models.Post に変換
+	posts := toPostsWithCounts(results)
 
 	// 次のカーソル
 	nextCursor := ""
@@ -129,26 +153,16 @@ func (s *BookmarkService) GetBookmarks(ctx context.Context, userID uint, limit i
 		nextCursor = fmt.Sprintf("%d", posts[len(posts)-1].ID)
 	}
 
-	// いいね状態を一括取得
-	if len(posts) > 0 {
-		postIDs := make([]uint, len(posts))
-		for i, post := range posts {
-			postIDs[i] = post.ID
-		}
-
-		var likedPosts []models.PostLike
-		s.db.WithContext(ctx).Where("post_id IN ? AND user_id = ?", postIDs, userID).Find(&likedPosts)
+	// いいね・ブックマーク・リポスト状態を一括取得
+	applyViewerStates(s.db.WithContext(ctx), posts, viewerID)
 
-		likedMap := make(map[uint]bool)
-		for _, like := range likedPosts {
-			likedMap[like.PostID] = true
-		}
+	// メンション・アンケート・引用元の投稿を一括取得
+	applyMentions(s.db.WithContext(ctx), posts)
+	applyPolls(s.db.WithContext(ctx), posts, viewerID)
+	applyReferencedPosts(s.db.WithContext(ctx), posts, viewerID)
 
-		for i := range posts {
-			posts[i].IsLiked = likedMap[posts[i].ID]
-			posts[i].IsBookmarked = true // ブックマーク一覧なので常にtrue
-		}
-	}
+	// メディアの表示URLを設定
+	applyMediaURLs(ctx, posts)
 
 	return posts, hasMore, nextCursor, nil
 }
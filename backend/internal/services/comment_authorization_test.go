@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
 	"github.com/yourusername/sns-backend/internal/testutil"
 )
 
@@ -48,6 +49,34 @@ func TestDeleteComment_Authorization(t *testing.T) {
 		testutil.AssertNoError(t, result.Error, "Comment should still exist")
 	})
 
+	t.Run("Success - Deleting a comment also deletes its replies", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		replier := testutil.CreateTestUser(t, db, "replier@example.com", "replier", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		comment := testutil.CreateTestComment(t, db, post.ID, user.ID, "Test comment")
+		reply, err := CreateReply(replier.ID, comment.ID, "Reply")
+		testutil.AssertNoError(t, err, "Reply creation should succeed")
+		nested, err := CreateReply(user.ID, reply.ID, "Nested reply")
+		testutil.AssertNoError(t, err, "Nested reply creation should succeed")
+
+		err = DeleteComment(comment.ID, user.ID)
+		testutil.AssertNoError(t, err, "Owner should be able to delete comment with replies")
+
+		// 返信も論理削除され、投稿のコメント数に数えられないことを確認
+		for _, id := range []uint{reply.ID, nested.ID} {
+			var deletedReply testutil.Comment
+			result := db.Unscoped().First(&deletedReply, id)
+			testutil.AssertNoError(t, result.Error, "Reply should exist in database (soft deleted)")
+			testutil.AssertTrue(t, deletedReply.DeletedAt.Valid, "Reply should be soft deleted with its parent")
+		}
+
+		fetched, err := GetPostByID(post.ID, &user.ID)
+		testutil.AssertNoError(t, err, "Post should still be retrievable")
+		testutil.AssertEqual(t, int64(0), fetched.CommentsCount, "Deleted thread should not be counted")
+	})
+
 	t.Run("Error - Delete non-existent comment", func(t *testing.T) {
 		testutil.CleanupTestDB(t, db)
 
@@ -151,3 +180,170 @@ func TestCreateComment_Validation(t *testing.T) {
 		testutil.AssertError(t, err, "Should return error for invalid user ID")
 	})
 }
+
+// TestCreateReply - 返信作成のテスト
+func TestCreateReply(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+	database.DB = db
+
+	t.Run("Success - Reply to a top-level comment", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		replier := testutil.CreateTestUser(t, db, "replier@example.com", "replier", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+		comment := testutil.CreateTestComment(t, db, post.ID, owner.ID, "Top-level comment")
+
+		reply, err := CreateReply(replier.ID, comment.ID, "Reply")
+		testutil.AssertNoError(t, err, "Should be able to reply to a comment")
+		testutil.AssertTrue(t, reply.ParentID != nil, "Reply should have a parent ID")
+		testutil.AssertEqual(t, comment.ID, *reply.ParentID, "Parent ID should match")
+		testutil.AssertEqual(t, post.ID, reply.PostID, "Reply should belong to the parent's post")
+		testutil.AssertEqual(t, 1, reply.Depth, "Reply depth should be 1")
+	})
+
+	t.Run("Error - Reply exceeding depth limit", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		parent := testutil.CreateTestComment(t, db, post.ID, user.ID, "Depth 0")
+		for i := 0; i < models.MaxCommentDepth; i++ {
+			parent = testutil.CreateTestReply(t, db, parent, user.ID, "Nested reply")
+		}
+
+		_, err := CreateReply(user.ID, parent.ID, "Too deep")
+		testutil.AssertError(t, err, "Should not be able to reply beyond the depth limit")
+		testutil.AssertEqual(t, "reply depth limit exceeded", err.Error(), "Error message should match")
+	})
+
+	t.Run("Error - Reply to non-existent comment", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+
+		_, err := CreateReply(user.ID, 99999, "Reply")
+		testutil.AssertError(t, err, "Should return error for non-existent comment")
+		testutil.AssertEqual(t, "comment not found", err.Error(), "Error message should match")
+	})
+
+	t.Run("Error - Reply to deleted comment", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		comment := testutil.CreateTestComment(t, db, post.ID, user.ID, "Test comment")
+
+		err := DeleteComment(comment.ID, user.ID)
+		testutil.AssertNoError(t, err, "Comment deletion should succeed")
+
+		_, err = CreateReply(user.ID, comment.ID, "Reply to deleted comment")
+		testutil.AssertError(t, err, "Should not be able to reply to deleted comment")
+	})
+
+	t.Run("Error - Reply on deleted post", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		comment := testutil.CreateTestComment(t, db, post.ID, user.ID, "Test comment")
+
+		err := DeletePost(post.ID, user.ID)
+		testutil.AssertNoError(t, err, "Post deletion should succeed")
+
+		_, err = CreateReply(user.ID, comment.ID, "Reply on deleted post")
+		testutil.AssertError(t, err, "Should not be able to reply on deleted post")
+	})
+}
+
+// TestGetCommentsByPostID_TopLevel - トップレベルコメントと返信数のテスト
+func TestGetCommentsByPostID_TopLevel(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+	database.DB = db
+
+	t.Run("Success - Only top-level comments with reply counts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		first := testutil.CreateTestComment(t, db, post.ID, user.ID, "First")
+		second := testutil.CreateTestComment(t, db, post.ID, user.ID, "Second")
+		testutil.CreateTestReply(t, db, first, user.ID, "Reply 1")
+		testutil.CreateTestReply(t, db, first, user.ID, "Reply 2")
+
+		comments, hasMore, _, err := GetCommentsByPostID(post.ID, nil, 20, nil)
+		testutil.AssertNoError(t, err, "GetCommentsByPostID should not return error")
+		testutil.AssertFalse(t, hasMore, "Should not have more comments")
+		testutil.AssertEqual(t, 2, len(comments), "Only top-level comments should be returned")
+
+		counts := make(map[uint]int64)
+		for _, comment := range comments {
+			testutil.AssertTrue(t, comment.ParentID == nil, "Returned comment should be top-level")
+			counts[comment.ID] = comment.RepliesCount
+		}
+		testutil.AssertEqual(t, int64(2), counts[first.ID], "First comment should have 2 replies")
+		testutil.AssertEqual(t, int64(0), counts[second.ID], "Second comment should have no replies")
+	})
+
+	t.Run("Success - Deleted replies are not counted", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		comment := testutil.CreateTestComment(t, db, post.ID, user.ID, "Comment")
+		reply := testutil.CreateTestReply(t, db, comment, user.ID, "Reply")
+
+		err := DeleteComment(reply.ID, user.ID)
+		testutil.AssertNoError(t, err, "Reply deletion should succeed")
+
+		comments, _, _, err := GetCommentsByPostID(post.ID, nil, 20, nil)
+		testutil.AssertNoError(t, err, "GetCommentsByPostID should not return error")
+		testutil.AssertEqual(t, 1, len(comments), "Top-level comment should be returned")
+		testutil.AssertEqual(t, int64(0), comments[0].RepliesCount, "Deleted reply should not be counted")
+	})
+}
+
+// TestGetRepliesByCommentID - 返信一覧取得のテスト
+func TestGetRepliesByCommentID(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+	database.DB = db
+
+	t.Run("Success - Replies are paginated oldest first", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
+		comment := testutil.CreateTestComment(t, db, post.ID, user.ID, "Comment")
+		reply1 := testutil.CreateTestReply(t, db, comment, user.ID, "Reply 1")
+		reply2 := testutil.CreateTestReply(t, db, comment, user.ID, "Reply 2")
+		reply3 := testutil.CreateTestReply(t, db, comment, user.ID, "Reply 3")
+		testutil.CreateTestReply(t, db, reply1, user.ID, "Nested reply")
+
+		replies, hasMore, nextCursor, err := GetRepliesByCommentID(comment.ID, nil, 2, nil)
+		testutil.AssertNoError(t, err, "GetRepliesByCommentID should not return error")
+		testutil.AssertTrue(t, hasMore, "Should have more replies")
+		testutil.AssertEqual(t, 2, len(replies), "Should return 2 replies")
+		testutil.AssertEqual(t, reply1.ID, replies[0].ID, "Oldest reply should come first")
+		testutil.AssertEqual(t, reply2.ID, replies[1].ID, "Second reply should come next")
+		testutil.AssertEqual(t, int64(1), replies[0].RepliesCount, "First reply should have a nested reply")
+
+		replies, hasMore, _, err = GetRepliesByCommentID(comment.ID, nil, 2, &nextCursor)
+		testutil.AssertNoError(t, err, "GetRepliesByCommentID should not return error")
+		testutil.AssertFalse(t, hasMore, "Should not have more replies")
+		testutil.AssertEqual(t, 1, len(replies), "Should return the remaining reply")
+		testutil.AssertEqual(t, reply3.ID, replies[0].ID, "Last reply should be returned")
+	})
+
+	t.Run("Error - Replies of non-existent comment", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		_, _, _, err := GetRepliesByCommentID(99999, nil, 20, nil)
+		testutil.AssertError(t, err, "Should return error for non-existent comment")
+	})
+}
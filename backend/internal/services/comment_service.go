@@ -1,18 +1,22 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/yourusername/sns-backend/internal/database"
 	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/realtime"
 	"github.com/yourusername/sns-backend/internal/utils"
 	"gorm.io/gorm"
 )
 
-// GetCommentsByPostID - 投稿のコメント一覧を取得
-func GetCommentsByPostID(postID uint, limit int, cursor *string) ([]models.Comment, bool, string, error) {
+// GetCommentsByPostID - 投稿のトップレベルコメント一覧を取得（返信数付き）
+// userIDを指定した場合、ブロック・ミュートしているユーザーのコメントは除外する
+func GetCommentsByPostID(postID uint, userID *uint, limit int, cursor *string) ([]models.Comment, bool, string, error) {
 	db := database.GetDB()
 
 	// 投稿が存在するかチェック
@@ -24,9 +28,19 @@ func GetCommentsByPostID(postID uint, limit int, cursor *string) ([]models.Comme
 		return nil, false, "", err
 	}
 
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, &post, userID); err != nil {
+		return nil, false, "", err
+	} else if !canView {
+		return nil, false, "", errors.New("post not found")
+	}
+
 	query := db.Model(&models.Comment{}).
-		Where("post_id = ?", postID).
+		Select(`comments.*,
+			(SELECT COUNT(*) FROM comments AS replies WHERE replies.parent_id = comments.id AND replies.deleted_at IS NULL) as replies_count`).
+		Where("post_id = ? AND parent_id IS NULL", postID).
 		Preload("User")
+	query = excludeHiddenUsers(query, "comments.user_id", userID)
 
 	// カーソルベースページネーション
 	if cursor != nil && *cursor != "" {
@@ -36,16 +50,18 @@ func GetCommentsByPostID(postID uint, limit int, cursor *string) ([]models.Comme
 		}
 	}
 
-	var comments []models.Comment
-	if err := query.Order("created_at DESC").Limit(limit + 1).Find(&comments).Error; err != nil {
+	var results []commentWithCounts
+	if err := query.Order("created_at DESC").Limit(limit + 1).Find(&results).Error; err != nil {
 		return nil, false, "", err
 	}
 
-	hasMore := len(comments) > limit
+	hasMore := len(results) > limit
 	if hasMore {
-		comments = comments[:limit]
+		results = results[:limit]
 	}
 
+	comments := toCommentsWithCounts(results)
+
 	nextCursor := ""
 	if hasMore && len(comments) > 0 {
 		nextCursor = fmt.Sprintf("%d", comments[len(comments)-1].ID)
@@ -54,8 +70,92 @@ func GetCommentsByPostID(postID uint, limit int, cursor *string) ([]models.Comme
 	return comments, hasMore, nextCursor, nil
 }
 
+// GetRepliesByCommentID - コメントへの返信一覧を取得（古い順）
+// userIDを指定した場合、ブロック・ミュートしているユーザーの返信は除外する
+func GetRepliesByCommentID(commentID uint, userID *uint, limit int, cursor *string) ([]models.Comment, bool, string, error) {
+	db := database.GetDB()
+
+	// 返信先コメントが存在するかチェック
+	var parent models.Comment
+	if err := db.First(&parent, commentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, "", errors.New("comment not found")
+		}
+		return nil, false, "", err
+	}
+
+	// 閲覧できない鍵アカウントの投稿への返信は存在しないものとして扱う
+	var post models.Post
+	if err := db.First(&post, parent.PostID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, "", errors.New("comment not found")
+		}
+		return nil, false, "", err
+	}
+	if canView, err := canViewPost(db, &post, userID); err != nil {
+		return nil, false, "", err
+	} else if !canView {
+		return nil, false, "", errors.New("comment not found")
+	}
+
+	query := db.Model(&models.Comment{}).
+		Select(`comments.*,
+			(SELECT COUNT(*) FROM comments AS replies WHERE replies.parent_id = comments.id AND replies.deleted_at IS NULL) as replies_count`).
+		Where("parent_id = ?", parent.ID).
+		Preload("User")
+	query = excludeHiddenUsers(query, "comments.user_id", userID)
+
+	// カーソルベースページネーション（会話の流れに沿って古い順に取得）
+	if cursor != nil && *cursor != "" {
+		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
+		if err == nil {
+			query = query.Where("id > ?", cursorID)
+		}
+	}
+
+	var results []commentWithCounts
+	if err := query.Order("created_at ASC").Order("id ASC").Limit(limit + 1).Find(&results).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(results) > limit
+	if hasMore {
+		results = results[:limit]
+	}
+
+	replies := toCommentsWithCounts(results)
+
+	nextCursor := ""
+	if hasMore && len(replies) > 0 {
+		nextCursor = fmt.Sprintf("%d", replies[len(replies)-1].ID)
+	}
+
+	return replies, hasMore, nextCursor, nil
+}
+
 // CreateComment - コメントを作成
 func CreateComment(userID, postID uint, content string) (*models.Comment, error) {
+	return createComment(userID, postID, nil, content)
+}
+
+// CreateReply - コメントへの返信を作成
+func CreateReply(userID, parentCommentID uint, content string) (*models.Comment, error) {
+	db := database.GetDB()
+
+	// 返信先コメントが存在するかチェック
+	var parent models.Comment
+	if err := db.First(&parent, parentCommentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, err
+	}
+
+	return createComment(userID, parent.PostID, &parent, content)
+}
+
+// createComment - コメント・返信の共通作成処理
+func createComment(userID, postID uint, parent *models.Comment, content string) (*models.Comment, error) {
 	db := database.GetDB()
 
 	// バリデーション
@@ -72,19 +172,71 @@ func CreateComment(userID, postID uint, content string) (*models.Comment, error)
 		return nil, err
 	}
 
+	// 下書き・予約投稿にはコメント不可
+	if !post.IsPublished() {
+		return nil, errors.New("post not found")
+	}
+
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, &post, &userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
+	// ブロック関係にある場合はコメント不可（返信先の投稿者も含む）
+	for _, otherUserID := range []uint{post.UserID, parentUserID(parent)} {
+		if otherUserID == 0 {
+			continue
+		}
+		blocked, err := isBlockedBetween(db, userID, otherUserID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, errors.New("blocked")
+		}
+	}
+
 	comment := &models.Comment{
 		PostID:  postID,
 		UserID:  userID,
 		Content: content,
 	}
 
+	// 返信の場合は深さ制限をチェック
+	if parent != nil {
+		if parent.Depth >= models.MaxCommentDepth {
+			return nil, errors.New("reply depth limit exceeded")
+		}
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
 	if err := db.Create(comment).Error; err != nil {
 		return nil, err
 	}
 
+	// 投稿者・返信先コメントの投稿者へ通知（通知の失敗はコメント作成を失敗させない）
+	ctx := context.Background()
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(ctx, post.UserID, userID, models.NotificationTypeComment, &post.ID, &comment.ID); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+	if parent != nil && parent.UserID != post.UserID {
+		if err := notificationService.Notify(ctx, parent.UserID, userID, models.NotificationTypeReply, &post.ID, &parent.ID); err != nil {
+			fmt.Printf("Warning: failed to create notification: %v\n", err)
+		}
+	}
+
 	// ユーザー情報をプリロード
 	db.Preload("User").First(comment, comment.ID)
 
+	// 投稿者へリアルタイム配信
+	if post.UserID != userID {
+		realtime.GetHub().Publish([]uint{post.UserID}, realtime.EventCommentCreated, comment)
+	}
+
 	return comment, nil
 }
 
@@ -105,10 +257,45 @@ func DeleteComment(commentID, userID uint) error {
 		return errors.New("unauthorized")
 	}
 
-	// 論理削除
-	if err := db.Delete(&comment).Error; err != nil {
+	// 返信を含むスレッド全体を論理削除
+	// （親だけを削除すると返信に辿り着けなくなる一方で、投稿のコメント数には数えられ続けるため）
+	if err := db.Exec(`WITH RECURSIVE subtree AS (
+			SELECT id FROM comments WHERE id = ?
+			UNION ALL
+			SELECT replies.id FROM comments AS replies
+			INNER JOIN subtree ON replies.parent_id = subtree.id
+			WHERE replies.deleted_at IS NULL
+		)
+		UPDATE comments SET deleted_at = ?
+		WHERE id IN (SELECT id FROM subtree) AND deleted_at IS NULL`,
+		comment.ID, time.Now()).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// commentWithCounts - 返信数を含む集計用構造体
+type commentWithCounts struct {
+	models.Comment
+	RepliesCount int64 `gorm:"column:replies_count"`
+}
+
+// toCommentsWithCounts - 集計結果を models.Comment に変換
+func toCommentsWithCounts(results []commentWithCounts) []models.Comment {
+	comments := make([]models.Comment, len(results))
+	for i := range results {
+		comments[i] = results[i].Comment
+		comments[i].RepliesCount = results[i].RepliesCount
+		comments[i].User = results[i].Comment.User
+	}
+	return comments
+}
+
+// parentUserID - 返信先コメントの投稿者ID（トップレベルの場合は0）
+func parentUserID(parent *models.Comment) uint {
+	if parent == nil {
+		return 0
+	}
+	return parent.UserID
+}
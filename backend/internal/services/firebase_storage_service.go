@@ -2,16 +2,16 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
-	"path/filepath"
 	"time"
 
 	"cloud.google.com/go/storage"
 	firebase "firebase.google.com/go/v4"
-	"github.com/google/uuid"
 	"github.com/yourusername/sns-backend/internal/config"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -68,9 +68,7 @@ func GetFirebaseStorageService() (*FirebaseStorageService, error) {
 // UploadFile Firebase Storageにファイルをアップロード
 func (s *FirebaseStorageService) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
 	// ファイル名生成（UUID + 元のファイル拡張子）
-	ext := filepath.Ext(fileHeader.Filename)
-	fileName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
-	objectPath := fmt.Sprintf("uploads/%s", fileName)
+	objectPath := newObjectPath(fileHeader.Filename)
 
 	// Cloud Storageへのアップロード
 	wc := s.bucket.Object(objectPath).NewWriter(ctx)
@@ -89,11 +87,15 @@ func (s *FirebaseStorageService) UploadFile(ctx context.Context, file multipart.
 		return "", fmt.Errorf("failed to close writer: %v", err)
 	}
 
-	// 署名付きURL（有効期限あり）を生成
-	// Note: バケットが非公開の場合、署名付きURLが必要
+	return objectPath, nil
+}
+
+// SignedURL 署名付きURL（有効期限あり）を生成
+// Note: バケットが非公開のため、表示のたびに署名付きURLが必要
+func (s *FirebaseStorageService) SignedURL(ctx context.Context, objectPath string, expiresAt time.Time) (string, error) {
 	url, err := s.bucket.SignedURL(objectPath, &storage.SignedURLOptions{
 		Method:  "GET",
-		Expires: time.Now().Add(7 * 24 * time.Hour), // 7日間有効
+		Expires: expiresAt,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to generate signed URL: %v", err)
@@ -103,20 +105,36 @@ func (s *FirebaseStorageService) UploadFile(ctx context.Context, file multipart.
 }
 
 // DeleteFile Firebase Storageからファイルを削除
-func (s *FirebaseStorageService) DeleteFile(ctx context.Context, fileURL string) error {
-	// URLからオブジェクトパスを抽出
-	// 例: https://storage.googleapis.com/bucket-name/uploads/file.jpg -> uploads/file.jpg
-	// 簡易実装（実際はURLパースが必要）
-	// objectPath := extractObjectPath(fileURL)
-
-	// obj := s.bucket.Object(objectPath)
-	// if err := obj.Delete(ctx); err != nil {
-	// 	return fmt.Errorf("failed to delete file: %v", err)
-	// }
+func (s *FirebaseStorageService) DeleteFile(ctx context.Context, objectPath string) error {
+	if err := s.bucket.Object(objectPath).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
 
 	return nil
 }
 
+// ListObjects Firebase Storage上のオブジェクトを列挙
+func (s *FirebaseStorageService) ListObjects(ctx context.Context, prefix string) ([]StorageObject, error) {
+	var objects []StorageObject
+
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %v", err)
+		}
+		objects = append(objects, StorageObject{
+			Path:      attrs.Name,
+			UpdatedAt: attrs.Updated,
+		})
+	}
+
+	return objects, nil
+}
+
 // IsConfigured Firebase Storageが設定されているか確認
 func IsFirebaseStorageConfigured() bool {
 	cfg := config.AppConfig
@@ -159,7 +159,7 @@ func TestGetFollowers_EdgeCases(t *testing.T) {
 
 		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
 
-		users, hasMore, nextCursor, err := GetFollowers(user.Username, 10, nil)
+		users, hasMore, nextCursor, err := GetFollowers(user.Username, nil, 10, nil)
 		testutil.AssertNoError(t, err, "Should not return error for user with no followers")
 		testutil.AssertEqual(t, 0, len(users), "Should return empty array")
 		testutil.AssertFalse(t, hasMore, "Should not have more")
@@ -169,7 +169,7 @@ func TestGetFollowers_EdgeCases(t *testing.T) {
 	t.Run("Error - Get followers for non-existent user", func(t *testing.T) {
 		testutil.CleanupTestDB(t, db)
 
-		_, _, _, err := GetFollowers("nonexistentuser", 10, nil)
+		_, _, _, err := GetFollowers("nonexistentuser", nil, 10, nil)
 		testutil.AssertError(t, err, "Should return error for non-existent user")
 	})
 
@@ -186,7 +186,7 @@ func TestGetFollowers_EdgeCases(t *testing.T) {
 		}
 
 		// 最初のページ（limit=2）
-		followers, hasMore, nextCursor, err := GetFollowers(user.Username, 2, nil)
+		followers, hasMore, nextCursor, err := GetFollowers(user.Username, nil, 2, nil)
 		testutil.AssertNoError(t, err, "GetFollowers should not return error")
 		testutil.AssertEqual(t, 2, len(followers), "Should return 2 followers")
 		testutil.AssertTrue(t, hasMore, "Should have more followers")
@@ -206,7 +206,7 @@ func TestGetFollowing_EdgeCases(t *testing.T) {
 
 		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
 
-		users, hasMore, nextCursor, err := GetFollowing(user.Username, 10, nil)
+		users, hasMore, nextCursor, err := GetFollowing(user.Username, nil, 10, nil)
 		testutil.AssertNoError(t, err, "Should not return error for user following no one")
 		testutil.AssertEqual(t, 0, len(users), "Should return empty array")
 		testutil.AssertFalse(t, hasMore, "Should not have more")
@@ -216,7 +216,7 @@ func TestGetFollowing_EdgeCases(t *testing.T) {
 	t.Run("Error - Get following for non-existent user", func(t *testing.T) {
 		testutil.CleanupTestDB(t, db)
 
-		_, _, _, err := GetFollowing("nonexistentuser", 10, nil)
+		_, _, _, err := GetFollowing("nonexistentuser", nil, 10, nil)
 		testutil.AssertError(t, err, "Should return error for non-existent user")
 	})
 }
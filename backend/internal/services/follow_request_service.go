@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// FollowRequestService フォローリクエストサービス（鍵アカウント用）
+type FollowRequestService struct {
+	db *gorm.DB
+}
+
+// NewFollowRequestService FollowRequestServiceのコンストラクタ
+func NewFollowRequestService() *FollowRequestService {
+	return &FollowRequestService{
+		db: database.GetDB(),
+	}
+}
+
+// GetPendingRequests 自分宛ての保留中フォローリクエスト一覧を取得（新しい順）
+// @param ctx コンテキスト
+// @param userID 鍵アカウントのユーザーID
+// @param limit 取得件数
+// @param cursor カーソル（最後のリクエストID）
+// @return リクエスト一覧, さらにデータがあるか, 次のカーソル, error
+func (s *FollowRequestService) GetPendingRequests(ctx context.Context, userID uint, limit int, cursor *string) ([]models.FollowRequest, bool, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, false, "", errors.New("limit must be greater than 0")
+	}
+
+	query := s.db.WithContext(ctx).
+		Where("target_id = ?", userID).
+		Preload("Requester")
+
+	if cursor != nil && *cursor != "" {
+		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
+		if err == nil {
+			query = query.Where("id < ?", cursorID)
+		}
+	}
+
+	var requests []models.FollowRequest
+	if err := query.Order("id DESC").Limit(limit + 1).Find(&requests).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(requests) > limit
+	if hasMore {
+		requests = requests[:limit]
+	}
+
+	nextCursor := ""
+	if hasMore && len(requests) > 0 {
+		nextCursor = fmt.Sprintf("%d", requests[len(requests)-1].ID)
+	}
+
+	return requests, hasMore, nextCursor, nil
+}
+
+// ApproveRequest フォローリクエストを承認（Followを作成してリクエストを削除）
+// @param ctx コンテキスト
+// @param userID 鍵アカウントのユーザーID
+// @param requestID リクエストID
+// @return error
+func (s *FollowRequestService) ApproveRequest(ctx context.Context, userID, requestID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.FollowRequest
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND target_id = ?", requestID, userID).First(&request).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("follow request not found")
+			}
+			return err
+		}
+		return approveRequest(tx, &request)
+	})
+	if err != nil {
+		return err
+	}
+
+	// リクエストしたユーザーへ承認を通知
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(ctx, request.RequesterID, userID, models.NotificationTypeFollowAccepted, nil, nil); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	return nil
+}
+
+// RejectRequest フォローリクエストを拒否（リクエストを削除）
+// @param ctx コンテキスト
+// @param userID 鍵アカウントのユーザーID
+// @param requestID リクエストID
+// @return error
+func (s *FollowRequestService) RejectRequest(ctx context.Context, userID, requestID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.FollowRequest
+	if err := s.db.WithContext(ctx).Where("id = ? AND target_id = ?", requestID, userID).First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("follow request not found")
+		}
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&request).Error; err != nil {
+		return err
+	}
+
+	// 未読のリクエスト通知を取り消す
+	notificationService := NewNotificationService()
+	if err := notificationService.RemoveNotification(ctx, userID, request.RequesterID, models.NotificationTypeFollowRequest, nil); err != nil {
+		fmt.Printf("Warning: failed to remove notification: %v\n", err)
+	}
+
+	return nil
+}
+
+// ApproveAllRequests 保留中のフォローリクエストをすべて承認（鍵アカウントを解除した場合）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @return error
+func (s *FollowRequestService) ApproveAllRequests(ctx context.Context, userID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var requests []models.FollowRequest
+		if err := tx.Where("target_id = ?", userID).Find(&requests).Error; err != nil {
+			return err
+		}
+		for i := range requests {
+			if err := approveRequest(tx, &requests[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createRequest フォローリクエストを作成して鍵アカウントへ通知
+func (s *FollowRequestService) createRequest(ctx context.Context, requesterID, targetID uint) error {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.FollowRequest{}).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("follow request already sent")
+	}
+
+	request := &models.FollowRequest{RequesterID: requesterID, TargetID: targetID}
+	if err := s.db.WithContext(ctx).Create(request).Error; err != nil {
+		return err
+	}
+
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(ctx, targetID, requesterID, models.NotificationTypeFollowRequest, nil, nil); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	return nil
+}
+
+// cancelRequest 送信済みのフォローリクエストを取り消す（取り消した場合はtrue）
+func (s *FollowRequestService) cancelRequest(ctx context.Context, requesterID, targetID uint) (bool, error) {
+	result := s.db.WithContext(ctx).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Delete(&models.FollowRequest{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	notificationService := NewNotificationService()
+	if err := notificationService.RemoveNotification(ctx, targetID, requesterID, models.NotificationTypeFollowRequest, nil); err != nil {
+		fmt.Printf("Warning: failed to remove notification: %v\n", err)
+	}
+
+	return true, nil
+}
+
+// approveRequest - リクエストをFollowに変換して削除（トランザクション内で使用）
+func approveRequest(tx *gorm.DB, request *models.FollowRequest) error {
+	follow := &models.Follow{
+		FollowerID:  request.RequesterID,
+		FollowingID: request.TargetID,
+	}
+	if err := tx.Where("follower_id = ? AND following_id = ?", follow.FollowerID, follow.FollowingID).
+		FirstOrCreate(follow).Error; err != nil {
+		return err
+	}
+	return tx.Delete(request).Error
+}
+
+// hasPendingFollowRequest - 閲覧者がフォローリクエスト中かチェック
+func hasPendingFollowRequest(db *gorm.DB, requesterID, targetID uint) bool {
+	var count int64
+	db.Model(&models.FollowRequest{}).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Count(&count)
+	return count > 0
+}
+
+// canViewUserContent - 閲覧者がユーザーの投稿・フォロー一覧を閲覧できるかチェック
+// 鍵アカウントの場合は本人と承認済みフォロワーのみ閲覧可能
+func canViewUserContent(db *gorm.DB, owner *models.User, viewerID *uint) (bool, error) {
+	if !owner.IsProtected {
+		return true, nil
+	}
+	if viewerID == nil {
+		return false, nil
+	}
+	if *viewerID == owner.ID {
+		return true, nil
+	}
+
+	var count int64
+	if err := db.Model(&models.Follow{}).
+		Where("follower_id = ? AND following_id = ?", *viewerID, owner.ID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// canViewPost - 閲覧者が投稿を閲覧できるかチェック（投稿者が鍵アカウントの場合・下書き・予約投稿の場合・公開範囲）
+func canViewPost(db *gorm.DB, post *models.Post, viewerID *uint) (bool, error) {
+	// 下書き・予約投稿は本人のみ閲覧可能
+	if !post.IsPublished() && (viewerID == nil || *viewerID != post.UserID) {
+		return false, nil
+	}
+
+	var owner models.User
+	if err := db.Select("id", "is_protected").First(&owner, post.UserID).Error; err != nil {
+		return false, err
+	}
+	if canView, err := canViewUserContent(db, &owner, viewerID); err != nil || !canView {
+		return false, err
+	}
+
+	// フォロワー限定・メンション限定の投稿
+	return isPostVisible(db, post.ID, viewerID)
+}
+
+// excludeProtectedAuthors - 閲覧者がフォローしていない鍵アカウントの投稿を除外する
+func excludeProtectedAuthors(query *gorm.DB, column string, viewerID *uint) *gorm.DB {
+	var viewer uint
+	if viewerID != nil {
+		viewer = *viewerID
+	}
+	return query.Where(column+` NOT IN (SELECT users.id FROM users WHERE users.is_protected = true AND users.id <> @viewer
+			AND NOT EXISTS (SELECT 1 FROM follows WHERE follows.follower_id = @viewer AND follows.following_id = users.id))`,
+		map[string]interface{}{"viewer": viewer})
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// createProtectedUser 鍵アカウントのテストユーザーを作成
+func createProtectedUser(t *testing.T, db *gorm.DB, email, username string) *models.User {
+	user := testutil.CreateTestUser(t, db, email, username, "password123")
+	require.NoError(t, db.Model(user).Update("is_protected", true).Error)
+	user.IsProtected = true
+	return user
+}
+
+func TestFollowRequestService_RequestFlow(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewFollowRequestService()
+	ctx := context.Background()
+
+	t.Run("Success - Following a protected account creates a request", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester := testutil.CreateTestUser(t, db, "requester@example.com", "requester", "password123")
+
+		requested, err := FollowUserOrRequest(requester.ID, owner.Username)
+		require.NoError(t, err)
+		assert.True(t, requested)
+
+		var followCount, requestCount int64
+		db.Model(&models.Follow{}).Count(&followCount)
+		db.Model(&models.FollowRequest{}).Count(&requestCount)
+		assert.Equal(t, int64(0), followCount, "承認前はフォローが作成されないべき")
+		assert.Equal(t, int64(1), requestCount)
+
+		// 二重リクエストはエラー
+		_, err = FollowUserOrRequest(requester.ID, owner.Username)
+		assert.EqualError(t, err, "follow request already sent")
+	})
+
+	t.Run("Success - Approve creates follow", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester := testutil.CreateTestUser(t, db, "requester@example.com", "requester", "password123")
+
+		_, err := FollowUserOrRequest(requester.ID, owner.Username)
+		require.NoError(t, err)
+
+		requests, hasMore, _, err := service.GetPendingRequests(ctx, owner.ID, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.False(t, hasMore)
+		assert.Equal(t, requester.ID, requests[0].Requester.ID)
+
+		require.NoError(t, service.ApproveRequest(ctx, owner.ID, requests[0].ID))
+
+		var followCount, requestCount int64
+		db.Model(&models.Follow{}).Where("follower_id = ? AND following_id = ?", requester.ID, owner.ID).Count(&followCount)
+		db.Model(&models.FollowRequest{}).Count(&requestCount)
+		assert.Equal(t, int64(1), followCount)
+		assert.Equal(t, int64(0), requestCount)
+	})
+
+	t.Run("Success - Reject removes request", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester := testutil.CreateTestUser(t, db, "requester@example.com", "requester", "password123")
+
+		_, err := FollowUserOrRequest(requester.ID, owner.Username)
+		require.NoError(t, err)
+
+		var request models.FollowRequest
+		require.NoError(t, db.First(&request).Error)
+		require.NoError(t, service.RejectRequest(ctx, owner.ID, request.ID))
+
+		var followCount, requestCount int64
+		db.Model(&models.Follow{}).Count(&followCount)
+		db.Model(&models.FollowRequest{}).Count(&requestCount)
+		assert.Equal(t, int64(0), followCount)
+		assert.Equal(t, int64(0), requestCount)
+	})
+
+	t.Run("Success - Unfollow cancels pending request", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester := testutil.CreateTestUser(t, db, "requester@example.com", "requester", "password123")
+
+		_, err := FollowUserOrRequest(requester.ID, owner.Username)
+		require.NoError(t, err)
+
+		require.NoError(t, UnfollowUser(requester.ID, owner.Username))
+
+		var requestCount int64
+		db.Model(&models.FollowRequest{}).Count(&requestCount)
+		assert.Equal(t, int64(0), requestCount)
+	})
+
+	t.Run("Error - Approve request addressed to another user", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester := testutil.CreateTestUser(t, db, "requester@example.com", "requester", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+
+		_, err := FollowUserOrRequest(requester.ID, owner.Username)
+		require.NoError(t, err)
+
+		var request models.FollowRequest
+		require.NoError(t, db.First(&request).Error)
+
+		err = service.ApproveRequest(ctx, other.ID, request.ID)
+		assert.EqualError(t, err, "follow request not found")
+	})
+
+	t.Run("Success - Unprotecting approves all pending requests", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester1 := testutil.CreateTestUser(t, db, "requester1@example.com", "requester1", "password123")
+		requester2 := testutil.CreateTestUser(t, db, "requester2@example.com", "requester2", "password123")
+
+		_, err := FollowUserOrRequest(requester1.ID, owner.Username)
+		require.NoError(t, err)
+		_, err = FollowUserOrRequest(requester2.ID, owner.Username)
+		require.NoError(t, err)
+
+		_, err = UpdateProfile(owner.ID, map[string]interface{}{"is_protected": false})
+		require.NoError(t, err)
+
+		var followCount, requestCount int64
+		db.Model(&models.Follow{}).Where("following_id = ?", owner.ID).Count(&followCount)
+		db.Model(&models.FollowRequest{}).Count(&requestCount)
+		assert.Equal(t, int64(2), followCount)
+		assert.Equal(t, int64(0), requestCount)
+	})
+}
+
+func TestProtectedAccount_Visibility(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	t.Run("Error - Non-follower cannot view protected posts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		stranger := testutil.CreateTestUser(t, db, "stranger@example.com", "stranger", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "鍵アカウントの投稿")
+
+		_, _, _, err := GetUserPosts(owner.Username, &stranger.ID, 10, nil)
+		assert.EqualError(t, err, "account is protected")
+
+		_, _, _, err = GetUserPosts(owner.Username, nil, 10, nil)
+		assert.EqualError(t, err, "account is protected")
+
+		_, _, _, err = GetFollowers(owner.Username, &stranger.ID, 10, nil)
+		assert.EqualError(t, err, "account is protected")
+
+		_, err = GetPostByID(post.ID, &stranger.ID)
+		assert.EqualError(t, err, "post not found")
+
+		assert.EqualError(t, LikePost(stranger.ID, post.ID), "post not found")
+	})
+
+	t.Run("Success - Follower and owner can view protected posts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		follower := testutil.CreateTestUser(t, db, "follower@example.com", "follower", "password123")
+		testutil.CreateTestFollow(t, db, follower.ID, owner.ID)
+		testutil.CreateTestPost(t, db, owner.ID, "鍵アカウントの投稿")
+
+		posts, _, _, err := GetUserPosts(owner.Username, &follower.ID, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, posts, 1)
+
+		posts, _, _, err = GetUserPosts(owner.Username, &owner.ID, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, posts, 1)
+	})
+
+	t.Run("Success - Global timeline excludes protected posts for non-followers", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		stranger := testutil.CreateTestUser(t, db, "stranger@example.com", "stranger", "password123")
+		testutil.CreateTestPost(t, db, owner.ID, "鍵アカウントの投稿")
+		publicPost := testutil.CreateTestPost(t, db, stranger.ID, "公開投稿")
+
+		posts, _, _, err := GetTimeline(&stranger.ID, "global", 10, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, publicPost.ID, posts[0].ID)
+
+		posts, _, _, err = GetTimeline(&owner.ID, "global", 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, posts, 2, "本人のタイムラインには自分の投稿が含まれるべき")
+	})
+
+	t.Run("Success - Profile reports pending follow request", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := createProtectedUser(t, db, "owner@example.com", "owner")
+		requester := testutil.CreateTestUser(t, db, "requester@example.com", "requester", "password123")
+
+		_, err := FollowUserOrRequest(requester.ID, owner.Username)
+		require.NoError(t, err)
+
+		profile, err := GetUserByUsername(owner.Username, &requester.ID)
+		require.NoError(t, err)
+		assert.True(t, profile.IsProtected)
+		require.NotNil(t, profile.FollowRequested)
+		assert.True(t, *profile.FollowRequested)
+		require.NotNil(t, profile.IsFollowing)
+		assert.False(t, *profile.IsFollowing)
+	})
+}
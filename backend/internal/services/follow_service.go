@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -10,28 +11,51 @@ import (
 	"gorm.io/gorm"
 )
 
-// FollowUser - ユーザーをフォロー
+// FollowUser - ユーザーをフォロー（鍵アカウントの場合はフォローリクエストを送信）
 func FollowUser(followerID uint, followingUsername string) error {
+	_, err := FollowUserOrRequest(followerID, followingUsername)
+	return err
+}
+
+// FollowUserOrRequest - ユーザーをフォロー（鍵アカウントの場合はフォローリクエストを送信し、requested=trueを返す）
+func FollowUserOrRequest(followerID uint, followingUsername string) (bool, error) {
 	db := database.GetDB()
 
 	// フォロー対象のユーザーを取得
 	var followingUser models.User
 	if err := db.Where("username = ?", followingUsername).First(&followingUser).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return false, errors.New("user not found")
 		}
-		return err
+		return false, err
 	}
 
 	// 自分自身をフォローしようとしていないかチェック
 	if followerID == followingUser.ID {
-		return errors.New("cannot follow yourself")
+		return false, errors.New("cannot follow yourself")
+	}
+
+	// ブロック関係にある場合はフォロー不可
+	blocked, err := isBlockedBetween(db, followerID, followingUser.ID)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		return false, errors.New("blocked")
 	}
 
 	// 既にフォロー済みかチェック
 	var existingFollow models.Follow
 	if err := db.Where("follower_id = ? AND following_id = ?", followerID, followingUser.ID).First(&existingFollow).Error; err == nil {
-		return errors.New("already following")
+		return false, errors.New("already following")
+	}
+
+	// 鍵アカウントの場合はフォローリクエストを送信
+	if followingUser.IsProtected {
+		if err := NewFollowRequestService().createRequest(context.Background(), followerID, followingUser.ID); err != nil {
+			return false, err
+		}
+		return true, nil
 	}
 
 	// フォロー関係を作成
@@ -41,10 +65,16 @@ func FollowUser(followerID uint, followingUsername string) error {
 	}
 
 	if err := db.Create(follow).Error; err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	// フォローされたユーザーへ通知（通知の失敗はフォローを失敗させない）
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(context.Background(), followingUser.ID, followerID, models.NotificationTypeFollow, nil, nil); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	return false, nil
 }
 
 // UnfollowUser - ユーザーのフォローを解除
@@ -64,6 +94,14 @@ func UnfollowUser(followerID uint, followingUsername string) error {
 	var follow models.Follow
 	if err := db.Where("follower_id = ? AND following_id = ?", followerID, followingUser.ID).First(&follow).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// フォローリクエスト中の場合はリクエストを取り消す
+			cancelled, cancelErr := NewFollowRequestService().cancelRequest(context.Background(), followerID, followingUser.ID)
+			if cancelErr != nil {
+				return cancelErr
+			}
+			if cancelled {
+				return nil
+			}
 			return errors.New("not following")
 		}
 		return err
@@ -74,6 +112,12 @@ func UnfollowUser(followerID uint, followingUsername string) error {
 		return err
 	}
 
+	// 未読のフォロー通知を取り消す
+	notificationService := NewNotificationService()
+	if err := notificationService.RemoveNotification(context.Background(), followingUser.ID, followerID, models.NotificationTypeFollow, nil); err != nil {
+		fmt.Printf("Warning: failed to remove notification: %v\n", err)
+	}
+
 	return nil
 }
 
@@ -92,7 +136,7 @@ func CheckIfFollowing(followerID, followingID uint) (bool, error) {
 }
 
 // GetFollowers - フォロワー一覧を取得
-func GetFollowers(username string, limit int, cursor *string) ([]models.User, bool, string, error) {
+func GetFollowers(username string, userID *uint, limit int, cursor *string) ([]models.User, bool, string, error) {
 	db := database.GetDB()
 
 	// ユーザーを取得
@@ -104,6 +148,15 @@ func GetFollowers(username string, limit int, cursor *string) ([]models.User, bo
 		return nil, false, "", err
 	}
 
+	// 鍵アカウントの場合は承認済みフォロワーのみ閲覧可能
+	canView, err := canViewUserContent(db, &user, userID)
+	if err != nil {
+		return nil, false, "", err
+	}
+	if !canView {
+		return nil, false, "", errors.New("account is protected")
+	}
+
 	query := db.Model(&models.User{}).
 		Joins("INNER JOIN follows ON follows.follower_id = users.id").
 		Where("follows.following_id = ?", user.ID)
@@ -135,7 +188,7 @@ func GetFollowers(username string, limit int, cursor *string) ([]models.User, bo
 }
 
 // GetFollowing - フォロー中ユーザー一覧を取得
-func GetFollowing(username string, limit int, cursor *string) ([]models.User, bool, string, error) {
+func GetFollowing(username string, userID *uint, limit int, cursor *string) ([]models.User, bool, string, error) {
 	db := database.GetDB()
 
 	// ユーザーを取得
@@ -147,6 +200,15 @@ func GetFollowing(username string, limit int, cursor *string) ([]models.User, bo
 		return nil, false, "", err
 	}
 
+	// 鍵アカウントの場合は承認済みフォロワーのみ閲覧可能
+	canView, err := canViewUserContent(db, &user, userID)
+	if err != nil {
+		return nil, false, "", err
+	}
+	if !canView {
+		return nil, false, "", errors.New("account is protected")
+	}
+
 	query := db.Model(&models.User{}).
 		Joins("INNER JOIN follows ON follows.following_id = users.id").
 		Where("follows.follower_id = ?", user.ID)
@@ -111,48 +111,53 @@ func (s *HashtagService) GetPostsByHashtag(ctx context.Context, hashtagName stri
 		return nil, 0, false, err
 	}
 
-	// 投稿を検索
-	query := s.db.WithContext(ctx).
-		Table("posts").
-		Select("posts.*").
+	var viewerID *uint
+	if currentUserID > 0 {
+		viewerID = &currentUserID
+	}
+
+	// 投稿を検索（サブクエリで集計してN+1を避ける）
+	query := s.db.WithContext(ctx).Model(&models.Post{}).
+		Select(postCountsSelect).
 		Joins("INNER JOIN post_hashtags ON post_hashtags.post_id = posts.id").
 		Where("post_hashtags.hashtag_id = ?", hashtag.ID).
-		Where("posts.deleted_at IS NULL")
+		Where(publishedPostCondition)
+
+	// ブロック・ミュートしているユーザーの投稿は表示しない
+	query = excludeHiddenUsers(query, "posts.user_id", viewerID)
+	query = excludeProtectedAuthors(query, "posts.user_id", viewerID)
+	query = excludeInvisiblePosts(query, viewerID)
 
 	if cursor > 0 {
-		query = query.Where("posts.id < ?", cursor)
+		query = beforePostCursor(query, uint64(cursor))
 	}
 
-	var posts []models.Post
+	var results []postWithCounts
 	if err := query.
-		Order("posts.created_at DESC").
+		Order(postFeedOrder).
 		Limit(limit + 1). // hasMoreを判定するために1件多く取得
 		Preload("User").
 		Preload("Media", func(db *gorm.DB) *gorm.DB {
 			return db.Order("order_index ASC")
 		}).
 		Preload("Hashtags").
-		Find(&posts).Error; err != nil {
+		Find(&results).Error; err != nil {
 		return nil, 0, false, err
 	}
 
 	// hasMoreの判定
-	hasMore := len(posts) > limit
+	hasMore := len(results) > limit
 	if hasMore {
-		posts = posts[:limit] // 余分な1件を削除
+		results = results[:limit] // 余分な1件を削除
 	}
 
-	// 各投稿のカウントといいね状態を取得
-	postService := NewPostService()
-	for i := range posts {
-		posts[i].LikesCount, _ = postService.GetLikesCount(ctx, posts[i].ID)
-		posts[i].CommentsCount, _ = postService.GetCommentsCount(ctx, posts[i].ID)
+	// postWithCounts から models.Post に変換
+	posts := toPostsWithCounts(results)
 
-		if currentUserID > 0 {
-			posts[i].IsLiked, _ = postService.CheckIfLiked(ctx, currentUserID, posts[i].ID)
-			posts[i].IsBookmarked, _ = postService.CheckIfBookmarked(ctx, currentUserID, posts[i].ID)
-		}
+	// いいね・ブックマーク・リポスト状態を一括取得
+	applyViewerStates(s.db.WithContext(ctx), posts, viewerID)
 
+	for i := range posts {
 		// ハッシュタグ名のリストを作成
 		posts[i].HashtagNames = make([]string, len(posts[i].Hashtags))
 		for j, h := range posts[i].Hashtags {
@@ -160,6 +165,14 @@ func (s *HashtagService) GetPostsByHashtag(ctx context.Context, hashtagName stri
 		}
 	}
 
+	// メンション・アンケート・引用元の投稿を一括取得
+	applyMentions(s.db.WithContext(ctx), posts)
+	applyPolls(s.db.WithContext(ctx), posts, viewerID)
+	applyReferencedPosts(s.db.WithContext(ctx), posts, viewerID)
+
+	// メディアの表示URLを設定
+	applyMediaURLs(ctx, posts)
+
 	// 次のカーソル
 	var nextCursor uint
 	if hasMore && len(posts) > 0 {
@@ -192,6 +205,7 @@ func (s *HashtagService) GetTrendingHashtags(ctx context.Context, limit int) ([]
 		Joins("INNER JOIN post_hashtags ON post_hashtags.hashtag_id = hashtags.id").
 		Joins("INNER JOIN posts ON posts.id = post_hashtags.post_id").
 		Where("posts.deleted_at IS NULL").
+		Where(publishedPostCondition).
 		Where("post_hashtags.created_at >= ?", sevenDaysAgo).
 		Group("hashtags.id, hashtags.name").
 		Order("posts_count DESC").
@@ -151,6 +151,31 @@ func TestHashtagService_GetPostsByHashtag(t *testing.T) {
 		assert.False(t, hasMore, "次のページはないはず")
 		assert.Equal(t, uint(0), nextCursor, "次のカーソルは0のはず")
 	})
+
+	t.Run("Success - Counts and viewer states are set", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		viewer := testutil.CreateTestUser(t, db, "viewer@example.com", "viewer", "password123")
+		post := testutil.CreateTestPost(t, db, author.ID, "Post with #counts")
+		require.NoError(t, service.ProcessHashtags(ctx, post.ID, post.Content))
+
+		testutil.CreateTestLike(t, db, post.ID, viewer.ID)
+		_, err := NewRepostService().Repost(ctx, viewer.ID, post.ID)
+		require.NoError(t, err)
+		_, err = CreateQuotePost(viewer.ID, post.ID, "引用")
+		require.NoError(t, err)
+
+		posts, _, _, err := service.GetPostsByHashtag(ctx, "counts", viewer.ID, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, int64(1), posts[0].LikesCount)
+		assert.Equal(t, int64(1), posts[0].RepostsCount)
+		assert.Equal(t, int64(1), posts[0].QuotesCount)
+		assert.True(t, posts[0].IsLiked)
+		assert.True(t, posts[0].IsReposted)
+		assert.Equal(t, []string{"counts"}, posts[0].HashtagNames)
+	})
 }
 
 func TestHashtagService_GetPostsByHashtag_WithPagination(t *testing.T) {
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"github.com/gen2brain/heic"
+	"golang.org/x/image/draw"
+)
+
+// 画像バリアント（アップロード時のファイル名に付与）
+const (
+	ImageVariantOriginal  = "original"
+	ImageVariantFeed      = "feed"
+	ImageVariantThumbnail = "thumbnail"
+)
+
+// 画像バリアントの長辺の最大サイズ（px）
+const (
+	imageThumbnailMaxSize = 320
+	imageFeedMaxSize      = 1080
+	imageOriginalMaxSize  = 4096
+)
+
+const (
+	// imageMaxPixels デコードを許可する最大ピクセル数（解凍爆弾対策）
+	imageMaxPixels = 50_000_000
+	// imageJPEGQuality 再エンコード時のJPEG品質
+	imageJPEGQuality = 85
+)
+
+// imageVariant エンコード済みの画像バリアント
+type imageVariant struct {
+	data        []byte
+	ext         string
+	contentType string
+	width       int
+	height      int
+}
+
+// processedImage 画像処理の結果（すべてメタデータ除去済み）
+type processedImage struct {
+	original  imageVariant
+	feed      imageVariant
+	thumbnail imageVariant
+}
+
+// processImage 画像をデコードし、メタデータを除去したオリジナル・フィード用・サムネイルを生成
+// 再エンコードによりEXIF（位置情報等）は除去される。EXIFの向きはピクセルに反映する
+// HEICはJPEGに変換し、アニメーションGIFはフレームとループ回数のみを残してGIFのまま再エンコードする
+// @param data 画像データ
+// @param ext ファイル拡張子
+func processImage(data []byte, ext string) (*processedImage, error) {
+	ext = strings.ToLower(ext)
+
+	// デコード前にサイズを確認
+	var cfg image.Config
+	var err error
+	if ext == ".heic" {
+		cfg, err = heic.DecodeConfig(bytes.NewReader(data))
+	} else {
+		cfg, _, err = image.DecodeConfig(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, newMediaValidationError(MediaErrorInvalidImage, "invalid image file")
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > imageMaxPixels {
+		return nil, newMediaValidationError(MediaErrorImageTooLarge, "image dimensions too large")
+	}
+
+	var img image.Image
+	var format string
+	if ext == ".heic" {
+		img, err = heic.Decode(bytes.NewReader(data))
+		format = "heic"
+	} else {
+		img, format, err = image.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, newMediaValidationError(MediaErrorInvalidImage, "invalid image file")
+	}
+
+	// 透過を保つためPNG・GIFはPNGで、それ以外はJPEGで出力
+	encode := encodeJPEG
+	if format == "png" || format == "gif" {
+		encode = encodePNG
+	}
+
+	original := fitImage(img, imageOriginalMaxSize)
+	if format == "jpeg" {
+		original = applyOrientation(original, jpegOrientation(data))
+	}
+
+	result := &processedImage{}
+
+	var animation *gif.GIF
+	if format == "gif" {
+		animation = decodeAnimatedGIF(data)
+	}
+	if animation != nil {
+		// コメント拡張・XMPなどのアプリケーション拡張に位置情報が含まれうるため、
+		// フレームとループ回数のみを残して再エンコードする
+		if result.original, err = encodeGIF(animation); err != nil {
+			return nil, err
+		}
+	} else if result.original, err = encode(original); err != nil {
+		return nil, err
+	}
+
+	if result.feed, err = encode(fitImage(original, imageFeedMaxSize)); err != nil {
+		return nil, err
+	}
+	if result.thumbnail, err = encode(fitImage(original, imageThumbnailMaxSize)); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// fitImage 長辺がmaxSize以下になるよう縮小（既に収まる場合はそのまま）
+func fitImage(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// encodeJPEG JPEGでエンコード
+func encodeJPEG(img image.Image) (imageVariant, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageJPEGQuality}); err != nil {
+		return imageVariant{}, err
+	}
+	bounds := img.Bounds()
+	return imageVariant{data: buf.Bytes(), ext: ".jpg", contentType: "image/jpeg", width: bounds.Dx(), height: bounds.Dy()}, nil
+}
+
+// encodePNG PNGでエンコード
+func encodePNG(img image.Image) (imageVariant, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return imageVariant{}, err
+	}
+	bounds := img.Bounds()
+	return imageVariant{data: buf.Bytes(), ext: ".png", contentType: "image/png", width: bounds.Dx(), height: bounds.Dy()}, nil
+}
+
+// decodeAnimatedGIF 複数フレームのGIFをデコード（単一フレーム・デコードできない場合はnil）
+func decodeAnimatedGIF(data []byte) *gif.GIF {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil || len(g.Image) <= 1 {
+		return nil
+	}
+	return g
+}
+
+// encodeGIF アニメーションGIFをエンコード（コメント拡張・NETSCAPE以外のアプリケーション拡張は出力されない）
+func encodeGIF(g *gif.GIF) (imageVariant, error) {
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		return imageVariant{}, err
+	}
+	return imageVariant{data: buf.Bytes(), ext: ".gif", contentType: "image/gif", width: g.Config.Width, height: g.Config.Height}, nil
+}
+
+// jpegOrientation JPEGのEXIFから向き（1〜8）を取得（見つからない場合は1）
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// SOSまでのセグメントからAPP1（Exif）を探す
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+
+	return 1
+}
+
+// exifOrientation TIFF形式のEXIFからIFD0のOrientationタグ（0x0112）を読み取る
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+// applyOrientation EXIFの向きに合わせて画像を回転・反転
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// 5〜8は90度回転を含むため幅と高さが入れ替わる
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = width-1-x, y
+			case 3: // 180度回転
+				dx, dy = width-1-x, height-1-y
+			case 4: // 上下反転
+				dx, dy = x, height-1-y
+			case 5: // 転置
+				dx, dy = y, x
+			case 6: // 時計回りに90度回転
+				dx, dy = height-1-y, x
+			case 7: // 反転置
+				dx, dy = height-1-y, width-1-x
+			case 8: // 反時計回りに90度回転
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
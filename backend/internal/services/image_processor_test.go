@@ -0,0 +1,207 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/models"
+)
+
+// newTestImage 指定サイズのテスト画像を作成（左上のピクセルのみ赤）
+func newTestImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	return img
+}
+
+// newTestJPEGWithExif 向きと位置情報を含むEXIF付きのJPEGを作成
+func newTestJPEGWithExif(t *testing.T, width, height int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, newTestImage(width, height), nil))
+	data := buf.Bytes()
+
+	// TIFFヘッダー + IFD0（Orientation 1件）+ 位置情報を模したデータ
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("GPS35.6812N139.7671E")...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	// SOIの直後にAPP1を挿入
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func TestProcessImage(t *testing.T) {
+	t.Run("Success - Strips EXIF and applies orientation", func(t *testing.T) {
+		data := newTestJPEGWithExif(t, 40, 20, 6)
+		require.Equal(t, 6, jpegOrientation(data))
+
+		processed, err := processImage(data, ".JPG")
+		require.NoError(t, err)
+
+		// 時計回りに90度回転して幅と高さが入れ替わる
+		assert.Equal(t, 20, processed.original.width)
+		assert.Equal(t, 40, processed.original.height)
+		assert.Equal(t, ".jpg", processed.original.ext)
+
+		for _, variant := range []imageVariant{processed.original, processed.feed, processed.thumbnail} {
+			assert.False(t, bytes.Contains(variant.data, []byte("Exif")), "EXIFは除去されるべき")
+			assert.False(t, bytes.Contains(variant.data, []byte("GPS35.6812N")), "位置情報は除去されるべき")
+			assert.Equal(t, 1, jpegOrientation(variant.data))
+		}
+	})
+
+	t.Run("Success - Generates resized variants", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, newTestImage(2000, 1000)))
+
+		processed, err := processImage(buf.Bytes(), ".png")
+		require.NoError(t, err)
+
+		assert.Equal(t, ".png", processed.original.ext)
+		assert.Equal(t, []int{2000, 1000}, []int{processed.original.width, processed.original.height})
+		assert.Equal(t, []int{1080, 540}, []int{processed.feed.width, processed.feed.height})
+		assert.Equal(t, []int{320, 160}, []int{processed.thumbnail.width, processed.thumbnail.height})
+
+		thumbnail, format, err := image.Decode(bytes.NewReader(processed.thumbnail.data))
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, 320, thumbnail.Bounds().Dx())
+	})
+
+	t.Run("Success - Keeps animated GIF as original", func(t *testing.T) {
+		palette := color.Palette{color.White, color.Black}
+		animation := &gif.GIF{
+			Image: []*image.Paletted{
+				image.NewPaletted(image.Rect(0, 0, 10, 10), palette),
+				image.NewPaletted(image.Rect(0, 0, 10, 10), palette),
+			},
+			Delay: []int{10, 10},
+		}
+		var buf bytes.Buffer
+		require.NoError(t, gif.EncodeAll(&buf, animation))
+
+		processed, err := processImage(buf.Bytes(), ".gif")
+		require.NoError(t, err)
+		assert.Equal(t, buf.Bytes(), processed.original.data)
+		assert.Equal(t, ".gif", processed.original.ext)
+		assert.Equal(t, ".png", processed.thumbnail.ext)
+	})
+
+	t.Run("Success - Strips comment and XMP extensions from animated GIF", func(t *testing.T) {
+		palette := color.Palette{color.White, color.Black}
+		animation := &gif.GIF{
+			Image: []*image.Paletted{
+				image.NewPaletted(image.Rect(0, 0, 10, 10), palette),
+				image.NewPaletted(image.Rect(0, 0, 10, 10), palette),
+			},
+			Delay:     []int{10, 20},
+			LoopCount: 3,
+		}
+		var buf bytes.Buffer
+		require.NoError(t, gif.EncodeAll(&buf, animation))
+
+		// トレーラーの直前にコメント拡張とXMPアプリケーション拡張を挿入
+		encoded := buf.Bytes()
+		data := append([]byte{}, encoded[:len(encoded)-1]...)
+		comment := "GPS 35.6812,139.7671"
+		data = append(data, 0x21, 0xFE, byte(len(comment)))
+		data = append(data, comment...)
+		data = append(data, 0x00)
+		xmp := "<exif:GPSLatitude>35.6812</exif:GPSLatitude>"
+		data = append(data, 0x21, 0xFF, 0x0B)
+		data = append(data, "XMP DataXMP"...)
+		data = append(data, byte(len(xmp)))
+		data = append(data, xmp...)
+		data = append(data, 0x00, 0x3B)
+
+		processed, err := processImage(data, ".gif")
+		require.NoError(t, err)
+		assert.Equal(t, ".gif", processed.original.ext)
+		assert.NotContains(t, string(processed.original.data), "GPS")
+		assert.NotContains(t, string(processed.original.data), "XMP")
+
+		decoded, err := gif.DecodeAll(bytes.NewReader(processed.original.data))
+		require.NoError(t, err)
+		assert.Len(t, decoded.Image, 2, "フレームは保たれるべき")
+		assert.Equal(t, []int{10, 20}, decoded.Delay)
+		assert.Equal(t, 3, decoded.LoopCount, "ループ回数は保たれるべき")
+	})
+
+	t.Run("Error - Invalid image data", func(t *testing.T) {
+		_, err := processImage([]byte("not an image"), ".jpg")
+		assert.EqualError(t, err, "invalid image file")
+	})
+
+	t.Run("Error - Dimensions too large", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, newTestImage(1, 1)))
+		data := buf.Bytes()
+
+		// IHDRの幅・高さを書き換えてCRCを再計算
+		binary.BigEndian.PutUint32(data[16:20], 10000)
+		binary.BigEndian.PutUint32(data[20:24], 10000)
+		binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+		_, err := processImage(data, ".png")
+		assert.EqualError(t, err, "image dimensions too large")
+	})
+}
+
+func TestMediaService_UploadImage(t *testing.T) {
+	root := t.TempDir()
+	storage, err := NewLocalStorageService(root, "http://localhost:8080")
+	require.NoError(t, err)
+	service := &MediaService{storage: storage}
+
+	file, header := newTestFileHeader("photo.jpg", newTestJPEGWithExif(t, 1600, 1200, 1))
+	media, err := service.uploadFile(context.Background(), file, header)
+	require.NoError(t, err)
+
+	assert.Equal(t, "image", media.MediaType)
+	require.NotNil(t, media.Width)
+	require.NotNil(t, media.Height)
+	assert.Equal(t, 1600, *media.Width)
+	assert.Equal(t, 1200, *media.Height)
+	require.Len(t, media.ObjectPaths(), 3)
+
+	for _, objectPath := range media.ObjectPaths() {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(objectPath)))
+		require.NoError(t, err)
+		assert.False(t, bytes.Contains(data, []byte("GPS35.6812N")), "保存されたファイルに位置情報が残らないべき")
+	}
+
+	// 削除時はバリアントも削除される
+	service.deleteObjects(context.Background(), []models.Media{*media})
+	for _, objectPath := range media.ObjectPaths() {
+		_, err := os.Stat(filepath.Join(root, filepath.FromSlash(objectPath)))
+		assert.True(t, os.IsNotExist(err))
+	}
+}
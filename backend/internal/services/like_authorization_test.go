@@ -162,7 +162,7 @@ func TestGetLikesByPostID_EdgeCases(t *testing.T) {
 		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
 		post := testutil.CreateTestPost(t, db, user.ID, "Test post")
 
-		users, hasMore, nextCursor, err := GetLikesByPostID(post.ID, 10, nil)
+		users, hasMore, nextCursor, err := GetLikesByPostID(post.ID, nil, 10, nil)
 		testutil.AssertNoError(t, err, "Should not return error for post with no likes")
 		testutil.AssertEqual(t, 0, len(users), "Should return empty array")
 		testutil.AssertFalse(t, hasMore, "Should not have more")
@@ -172,7 +172,7 @@ func TestGetLikesByPostID_EdgeCases(t *testing.T) {
 	t.Run("Error - Get likes for non-existent post", func(t *testing.T) {
 		testutil.CleanupTestDB(t, db)
 
-		_, _, _, err := GetLikesByPostID(99999, 10, nil)
+		_, _, _, err := GetLikesByPostID(99999, nil, 10, nil)
 		testutil.AssertError(t, err, "Should return error for non-existent post")
 	})
 
@@ -187,7 +187,7 @@ func TestGetLikesByPostID_EdgeCases(t *testing.T) {
 		testutil.AssertNoError(t, err, "Post deletion should succeed")
 
 		// 削除された投稿のいいね一覧を取得しようとする
-		_, _, _, err = GetLikesByPostID(post.ID, 10, nil)
+		_, _, _, err = GetLikesByPostID(post.ID, nil, 10, nil)
 		testutil.AssertError(t, err, "Should return error for deleted post")
 	})
 }
@@ -1,12 +1,14 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/yourusername/sns-backend/internal/database"
 	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/realtime"
 	"gorm.io/gorm"
 )
 
@@ -23,6 +25,27 @@ func LikePost(userID, postID uint) error {
 		return err
 	}
 
+	// 下書き・予約投稿にはいいね不可
+	if !post.IsPublished() {
+		return errors.New("post not found")
+	}
+
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, &post, &userID); err != nil {
+		return err
+	} else if !canView {
+		return errors.New("post not found")
+	}
+
+	// ブロック関係にある場合はいいね不可
+	blocked, err := isBlockedBetween(db, userID, post.UserID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errors.New("blocked")
+	}
+
 	// 既にいいね済みかチェック
 	var existingLike models.PostLike
 	if err := db.Where("post_id = ? AND user_id = ?", postID, userID).First(&existingLike).Error; err == nil {
@@ -39,6 +62,24 @@ func LikePost(userID, postID uint) error {
 		return err
 	}
 
+	// 投稿者へ通知（通知の失敗はいいねを失敗させない）
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(context.Background(), post.UserID, userID, models.NotificationTypeLike, &post.ID, nil); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	// 投稿者へリアルタイム配信
+	hub := realtime.GetHub()
+	if post.UserID != userID && hub.IsSubscribed(post.UserID) {
+		var liker models.User
+		if err := db.First(&liker, userID).Error; err == nil {
+			hub.Publish([]uint{post.UserID}, realtime.EventLikeCreated, map[string]interface{}{
+				"post_id": post.ID,
+				"user":    liker.ToPublicUser(nil),
+			})
+		}
+	}
+
 	return nil
 }
 
@@ -60,11 +101,21 @@ func UnlikePost(userID, postID uint) error {
 		return err
 	}
 
+	// 未読のいいね通知を取り消す
+	var post models.Post
+	if err := db.Unscoped().Select("id", "user_id").First(&post, postID).Error; err == nil {
+		notificationService := NewNotificationService()
+		if err := notificationService.RemoveNotification(context.Background(), post.UserID, userID, models.NotificationTypeLike, &post.ID); err != nil {
+			fmt.Printf("Warning: failed to remove notification: %v\n", err)
+		}
+	}
+
 	return nil
 }
 
 // GetLikesByPostID - 投稿のいいね一覧を取得
-func GetLikesByPostID(postID uint, limit int, cursor *string) ([]models.User, bool, string, error) {
+// userIDを指定した場合、ブロック・ミュートしているユーザーは除外する
+func GetLikesByPostID(postID uint, userID *uint, limit int, cursor *string) ([]models.User, bool, string, error) {
 	db := database.GetDB()
 
 	// 投稿が存在するかチェック
@@ -76,9 +127,17 @@ func GetLikesByPostID(postID uint, limit int, cursor *string) ([]models.User, bo
 		return nil, false, "", err
 	}
 
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, &post, userID); err != nil {
+		return nil, false, "", err
+	} else if !canView {
+		return nil, false, "", errors.New("post not found")
+	}
+
 	query := db.Model(&models.User{}).
 		Joins("INNER JOIN post_likes ON post_likes.user_id = users.id").
 		Where("post_likes.post_id = ?", postID)
+	query = excludeHiddenUsers(query, "users.id", userID)
 
 	// カーソルベースページネーション
 	if cursor != nil && *cursor != "" {
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStorageService ローカルディスクのストレージ（開発・CI用、Echoの静的ファイル配信で公開）
+type LocalStorageService struct {
+	rootDir string
+	baseURL string
+}
+
+// NewLocalStorageService LocalStorageServiceのコンストラクタ
+// @param rootDir 保存先ディレクトリ（オブジェクトパスの基準）
+// @param baseURL 配信URL（例: http://localhost:8080）
+func NewLocalStorageService(rootDir, baseURL string) (*LocalStorageService, error) {
+	if err := os.MkdirAll(filepath.Join(rootDir, UploadObjectPrefix), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %v", err)
+	}
+
+	return &LocalStorageService{
+		rootDir: rootDir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// UploadFile ローカルディスクにファイルを保存
+func (s *LocalStorageService) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
+	objectPath := newObjectPath(fileHeader.Filename)
+
+	path := s.filePath(objectPath)
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %v", err)
+	}
+
+	// 書き込みに失敗した場合は途中まで書き込んだファイルを残さない
+	_, err = io.Copy(dst, file)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to copy file: %v", err)
+	}
+
+	return objectPath, nil
+}
+
+// SignedURL 静的ファイル配信のURLを返す（ローカルドライバーは署名なし）
+func (s *LocalStorageService) SignedURL(ctx context.Context, objectPath string, expiresAt time.Time) (string, error) {
+	return s.baseURL + "/" + objectPath, nil
+}
+
+// DeleteFile ローカルディスクからファイルを削除
+func (s *LocalStorageService) DeleteFile(ctx context.Context, objectPath string) error {
+	// アップロードディレクトリ外へのパストラバーサルを防止
+	if !isUploadObjectPath(objectPath) {
+		return errors.New("invalid object path")
+	}
+
+	if err := os.Remove(s.filePath(objectPath)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
+
+	return nil
+}
+
+// ListObjects ローカルディスク上のファイルを列挙
+func (s *LocalStorageService) ListObjects(ctx context.Context, prefix string) ([]StorageObject, error) {
+	var objects []StorageObject
+
+	err := filepath.WalkDir(s.filePath(prefix), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.rootDir, path)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, StorageObject{
+			Path:      filepath.ToSlash(rel),
+			UpdatedAt: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %v", err)
+	}
+
+	return objects, nil
+}
+
+// filePath オブジェクトパスをディスク上のパスに変換
+func (s *LocalStorageService) filePath(objectPath string) string {
+	return filepath.Join(s.rootDir, filepath.FromSlash(objectPath))
+}
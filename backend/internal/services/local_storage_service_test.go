@@ -0,0 +1,167 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/config"
+)
+
+// memoryFile multipart.Fileのテスト用実装
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (f *memoryFile) Close() error { return nil }
+
+// failingFile 途中で読み込みに失敗するmultipart.File
+type failingFile struct {
+	data []byte
+	read bool
+}
+
+func (f *failingFile) Read(p []byte) (int, error) {
+	if f.read {
+		return 0, errors.New("connection reset")
+	}
+	f.read = true
+	return copy(p, f.data), nil
+}
+
+func (f *failingFile) ReadAt(p []byte, off int64) (int, error) { return 0, errors.New("not supported") }
+func (f *failingFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, errors.New("not supported")
+}
+func (f *failingFile) Close() error { return nil }
+
+// newTestFileHeader テスト用のファイルとヘッダーを作成
+func newTestFileHeader(filename string, content []byte) (multipart.File, *multipart.FileHeader) {
+	header := &multipart.FileHeader{
+		Filename: filename,
+		Size:     int64(len(content)),
+		Header:   textproto.MIMEHeader{},
+	}
+	return &memoryFile{bytes.NewReader(content)}, header
+}
+
+func TestLocalStorageService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Upload and delete file", func(t *testing.T) {
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080/")
+		require.NoError(t, err)
+
+		file, header := newTestFileHeader("photo.jpg", []byte("image data"))
+		objectPath, err := storage.UploadFile(ctx, file, header)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(objectPath, "uploads/"))
+		assert.True(t, strings.HasSuffix(objectPath, ".jpg"))
+
+		fileURL, err := storage.SignedURL(ctx, objectPath, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/"+objectPath, fileURL)
+
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(objectPath)))
+		require.NoError(t, err)
+		assert.Equal(t, "image data", string(data))
+
+		require.NoError(t, storage.DeleteFile(ctx, objectPath))
+		_, err = os.Stat(filepath.Join(root, filepath.FromSlash(objectPath)))
+		assert.True(t, os.IsNotExist(err), "ファイルが削除されるべき")
+
+		// 削除済みファイルの再削除はエラーにしない
+		assert.NoError(t, storage.DeleteFile(ctx, objectPath))
+	})
+
+	t.Run("Error - Failed upload leaves no partial file", func(t *testing.T) {
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		file := &failingFile{data: []byte("partial data")}
+		_, header := newTestFileHeader("photo.jpg", nil)
+		_, err = storage.UploadFile(ctx, file, header)
+		require.Error(t, err)
+
+		objects, err := storage.ListObjects(ctx, UploadObjectPrefix)
+		require.NoError(t, err)
+		assert.Empty(t, objects, "途中まで書き込んだファイルは削除されるべき")
+	})
+
+	t.Run("Error - Delete rejects paths outside upload directory", func(t *testing.T) {
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0o644))
+
+		err = storage.DeleteFile(ctx, "uploads/../secret.txt")
+		assert.EqualError(t, err, "invalid object path")
+
+		err = storage.DeleteFile(ctx, "secret.txt")
+		assert.EqualError(t, err, "invalid object path")
+
+		_, err = os.Stat(filepath.Join(root, "secret.txt"))
+		assert.NoError(t, err, "アップロードディレクトリ外のファイルは削除されないべき")
+	})
+}
+
+func TestLocalStorageService_ListObjects(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	storage, err := NewLocalStorageService(root, "http://localhost:8080")
+	require.NoError(t, err)
+
+	file1, header1 := newTestFileHeader("a.png", []byte("a"))
+	path1, err := storage.UploadFile(ctx, file1, header1)
+	require.NoError(t, err)
+	file2, header2 := newTestFileHeader("b.mp4", []byte("b"))
+	path2, err := storage.UploadFile(ctx, file2, header2)
+	require.NoError(t, err)
+
+	objects, err := storage.ListObjects(ctx, UploadObjectPrefix)
+	require.NoError(t, err)
+
+	paths := make([]string, len(objects))
+	for i, object := range objects {
+		paths[i] = object.Path
+		assert.False(t, object.UpdatedAt.IsZero())
+	}
+	assert.ElementsMatch(t, []string{path1, path2}, paths)
+}
+
+func TestNewStorage(t *testing.T) {
+	t.Run("Success - Local driver", func(t *testing.T) {
+		storage, err := NewStorage(&config.Config{
+			StorageDriver:       StorageDriverLocal,
+			LocalStorageRoot:    t.TempDir(),
+			LocalStorageBaseURL: "http://localhost:8080",
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &LocalStorageService{}, storage)
+	})
+
+	t.Run("Error - Unsupported driver", func(t *testing.T) {
+		storage, err := NewStorage(&config.Config{StorageDriver: "ftp"})
+		assert.EqualError(t, err, "unsupported storage driver: ftp")
+		assert.Nil(t, storage)
+	})
+
+	t.Run("Error - S3 driver without endpoint returns nil storage", func(t *testing.T) {
+		storage, err := NewStorage(&config.Config{StorageDriver: StorageDriverS3})
+		assert.Error(t, err)
+		assert.Nil(t, storage, "エラー時は型付きnilではなくnilを返すべき")
+	})
+}
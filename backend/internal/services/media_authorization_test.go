@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// createTestMedia - テスト用のメディアを作成
+func createTestMedia(t *testing.T, db *gorm.DB, media models.Media) *models.Media {
+	t.Helper()
+
+	media.MediaType = "image"
+	media.ObjectPath = "uploads/test.jpg"
+	media.FileSize = 1
+	if err := db.Create(&media).Error; err != nil {
+		t.Fatalf("Failed to create test media: %v", err)
+	}
+	return &media
+}
+
+// TestDeleteMedia_Authorization - メディア削除の認可テスト
+func TestDeleteMedia_Authorization(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+	service := &MediaService{db: db}
+
+	mediaExists := func(mediaID uint) bool {
+		var count int64
+		db.Model(&models.Media{}).Where("id = ?", mediaID).Count(&count)
+		return count > 0
+	}
+
+	t.Run("Success - Post owner can delete media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Post with media")
+		media := createTestMedia(t, db, models.Media{PostID: &post.ID})
+
+		err := service.DeleteMedia(ctx, media.ID, owner.ID)
+
+		testutil.AssertNoError(t, err, "Owner should be able to delete own media")
+		testutil.AssertFalse(t, mediaExists(media.ID), "Media should be deleted")
+	})
+
+	t.Run("Error - Non-owner cannot delete media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		otherUser := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Post with media")
+		media := createTestMedia(t, db, models.Media{PostID: &post.ID})
+
+		err := service.DeleteMedia(ctx, media.ID, otherUser.ID)
+
+		testutil.AssertError(t, err, "Non-owner should not be able to delete media")
+		testutil.AssertEqual(t, "unauthorized", err.Error(), "Error should be unauthorized")
+		testutil.AssertTrue(t, mediaExists(media.ID), "Media should not be deleted")
+	})
+
+	t.Run("Success - Admin can delete other user's media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		admin := testutil.CreateTestUser(t, db, "admin@example.com", "admin", "password123")
+		db.Model(admin).Update("role", "admin")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Post with media")
+		media := createTestMedia(t, db, models.Media{PostID: &post.ID})
+
+		err := service.DeleteMedia(ctx, media.ID, admin.ID)
+
+		testutil.AssertNoError(t, err, "Admin should be able to delete any media")
+		testutil.AssertFalse(t, mediaExists(media.ID), "Media should be deleted")
+	})
+
+	t.Run("Success - Uploader can delete own draft media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		media := createTestMedia(t, db, models.Media{UserID: &user.ID})
+
+		err := service.DeleteMedia(ctx, media.ID, user.ID)
+
+		testutil.AssertNoError(t, err, "Uploader should be able to delete own draft")
+	})
+
+	t.Run("Error - Non-owner cannot delete draft media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		otherUser := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		media := createTestMedia(t, db, models.Media{UserID: &user.ID})
+
+		err := service.DeleteMedia(ctx, media.ID, otherUser.ID)
+
+		testutil.AssertError(t, err, "Non-owner should not be able to delete draft")
+		testutil.AssertTrue(t, mediaExists(media.ID), "Draft should not be deleted")
+	})
+
+	t.Run("Error - Recipient cannot delete message attachment", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		sender := testutil.CreateTestUser(t, db, "sender@example.com", "sender", "password123")
+		recipient := testutil.CreateTestUser(t, db, "recipient@example.com", "recipient", "password123")
+		conversation := models.Conversation{}
+		db.Create(&conversation)
+		message := models.Message{ConversationID: conversation.ID, SenderID: sender.ID}
+		db.Create(&message)
+		media := createTestMedia(t, db, models.Media{MessageID: &message.ID})
+
+		err := service.DeleteMedia(ctx, media.ID, recipient.ID)
+		testutil.AssertError(t, err, "Recipient should not be able to delete attachment")
+
+		err = service.DeleteMedia(ctx, media.ID, sender.ID)
+		testutil.AssertNoError(t, err, "Sender should be able to delete attachment")
+	})
+
+	t.Run("Error - Delete non-existent media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+
+		err := service.DeleteMedia(ctx, 99999, user.ID)
+
+		testutil.AssertError(t, err, "Should return error for non-existent media")
+		testutil.AssertEqual(t, "media not found", err.Error(), "Error should be media not found")
+	})
+}
@@ -0,0 +1,544 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/config"
+)
+
+// 再生時間の上限（設定がない場合の既定値）
+const (
+	defaultMaxVideoDuration = 3 * time.Minute
+	defaultMaxAudioDuration = 10 * time.Minute
+)
+
+// mediaMetadata 動画・音声のコンテナから読み取ったメタデータ
+type mediaMetadata struct {
+	duration time.Duration
+	width    int // 動画の幅（px、音声の場合は0）
+	height   int // 動画の高さ（px）
+	codec    string
+}
+
+// errInvalidMediaContainer コンテナの構造が壊れている
+var errInvalidMediaContainer = errors.New("invalid media container")
+
+// maxMediaDuration メディアタイプごとの再生時間の上限（0は無制限）
+func maxMediaDuration(mediaType string) time.Duration {
+	switch mediaType {
+	case "video":
+		if config.AppConfig != nil {
+			return config.AppConfig.MediaMaxVideoDuration
+		}
+		return defaultMaxVideoDuration
+	case "audio":
+		if config.AppConfig != nil {
+			return config.AppConfig.MediaMaxAudioDuration
+		}
+		return defaultMaxAudioDuration
+	default:
+		return 0
+	}
+}
+
+// extractMediaMetadata 動画・音声のメタデータを読み取り、再生時間の上限を検証
+// @param r ファイル（読み取り位置は変更しない）
+// @param size ファイルサイズ
+// @param mediaType メディアタイプ（video / audio）
+// @param format 内容から判定した形式
+func extractMediaMetadata(r io.ReaderAt, size int64, mediaType, format string) (*mediaMetadata, error) {
+	var metadata *mediaMetadata
+	var err error
+	switch format {
+	case mediaFormatMP4:
+		metadata, err = parseMP4Metadata(r, size)
+	case mediaFormatMP3:
+		metadata, err = parseMP3Metadata(r, size)
+	default:
+		return nil, newMediaValidationError(MediaErrorInvalidMedia, "unsupported media container")
+	}
+	if err != nil {
+		return nil, newMediaValidationError(MediaErrorInvalidMedia, "failed to read %s metadata", mediaType)
+	}
+
+	if limit := maxMediaDuration(mediaType); limit > 0 && metadata.duration > limit {
+		return nil, newMediaValidationError(MediaErrorDurationTooLong, "%s duration exceeds limit (max %ds)", mediaType, int(limit.Seconds()))
+	}
+
+	return metadata, nil
+}
+
+// durationSeconds Media.Durationに保存する秒数（四捨五入）
+func (m *mediaMetadata) durationSeconds() int {
+	return int(math.Round(m.duration.Seconds()))
+}
+
+// readAt 指定位置からnバイト読み込む
+func readAt(r io.ReaderAt, offset int64, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errInvalidMediaContainer
+		}
+		return nil, err
+	}
+	return buf, nil
+}
+
+// ---- MP4 / MOV（ISO BMFF）----
+
+// mp4Box ボックスの位置情報
+type mp4Box struct {
+	boxType   string
+	dataStart int64 // ヘッダーを除いたデータの開始位置
+	end       int64 // ボックスの終了位置
+}
+
+// mp4Track トラックの情報
+type mp4Track struct {
+	handler   string // vide / soun
+	codec     string
+	width     int
+	height    int
+	timescale uint32
+	duration  uint64
+}
+
+// readMP4Boxes start〜endの範囲にあるボックスを列挙
+func readMP4Boxes(r io.ReaderAt, start, end int64) ([]mp4Box, error) {
+	boxes := make([]mp4Box, 0)
+	for offset := start; offset+8 <= end; {
+		header, err := readAt(r, offset, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+		switch size {
+		case 0:
+			// ファイル末尾まで
+			size = end - offset
+		case 1:
+			// 64bitサイズ
+			large, err := readAt(r, offset+8, 8)
+			if err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(large))
+			headerSize = 16
+		}
+		if size < headerSize || offset+size > end {
+			return nil, errInvalidMediaContainer
+		}
+
+		boxes = append(boxes, mp4Box{
+			boxType:   string(header[4:8]),
+			dataStart: offset + headerSize,
+			end:       offset + size,
+		})
+		offset += size
+	}
+	return boxes, nil
+}
+
+// findMP4Box 子ボックスから指定した種類の最初のボックスを探す
+func findMP4Box(r io.ReaderAt, parent mp4Box, boxType string) (*mp4Box, error) {
+	children, err := readMP4Boxes(r, parent.dataStart, parent.end)
+	if err != nil {
+		return nil, err
+	}
+	for i := range children {
+		if children[i].boxType == boxType {
+			return &children[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// parseMP4Metadata moovボックス（mvhd・trak）から再生時間・解像度・コーデックを取得
+func parseMP4Metadata(r io.ReaderAt, size int64) (*mediaMetadata, error) {
+	boxes, err := readMP4Boxes(r, 0, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var moov *mp4Box
+	for i := range boxes {
+		if boxes[i].boxType == "moov" {
+			moov = &boxes[i]
+			break
+		}
+	}
+	if moov == nil {
+		return nil, errInvalidMediaContainer
+	}
+
+	children, err := readMP4Boxes(r, moov.dataStart, moov.end)
+	if err != nil {
+		return nil, err
+	}
+
+	var timescale uint32
+	var duration uint64
+	var tracks []mp4Track
+	for _, child := range children {
+		switch child.boxType {
+		case "mvhd":
+			if timescale, duration, err = readMP4Duration(r, child); err != nil {
+				return nil, err
+			}
+		case "trak":
+			track, err := parseMP4Track(r, child)
+			if err != nil {
+				return nil, err
+			}
+			tracks = append(tracks, *track)
+		}
+	}
+
+	metadata := &mediaMetadata{}
+	if timescale > 0 {
+		metadata.duration = scaleDuration(duration, timescale)
+	}
+
+	// 映像トラックを優先し、なければ音声トラックのコーデックを使う
+	for _, track := range tracks {
+		// mvhdに長さがない場合（断片化MP4など）はトラックの長さを使う
+		if track.timescale > 0 {
+			metadata.duration = max(metadata.duration, scaleDuration(track.duration, track.timescale))
+		}
+		if track.handler == "vide" && metadata.width == 0 {
+			metadata.width, metadata.height = track.width, track.height
+			metadata.codec = track.codec
+		}
+	}
+	if metadata.codec == "" {
+		for _, track := range tracks {
+			if track.handler == "soun" {
+				metadata.codec = track.codec
+				break
+			}
+		}
+	}
+	if len(tracks) == 0 {
+		return nil, errInvalidMediaContainer
+	}
+
+	return metadata, nil
+}
+
+// parseMP4Track trakボックスからトラックの情報を取得
+func parseMP4Track(r io.ReaderAt, trak mp4Box) (*mp4Track, error) {
+	track := &mp4Track{}
+
+	if tkhd, err := findMP4Box(r, trak, "tkhd"); err != nil {
+		return nil, err
+	} else if tkhd != nil {
+		if track.width, track.height, err = readMP4TrackSize(r, *tkhd); err != nil {
+			return nil, err
+		}
+	}
+
+	mdia, err := findMP4Box(r, trak, "mdia")
+	if err != nil || mdia == nil {
+		return track, err
+	}
+
+	if mdhd, err := findMP4Box(r, *mdia, "mdhd"); err != nil {
+		return nil, err
+	} else if mdhd != nil {
+		if track.timescale, track.duration, err = readMP4Duration(r, *mdhd); err != nil {
+			return nil, err
+		}
+	}
+
+	if hdlr, err := findMP4Box(r, *mdia, "hdlr"); err != nil {
+		return nil, err
+	} else if hdlr != nil {
+		// version/flags(4) + pre_defined(4) + handler_type(4)
+		data, err := readAt(r, hdlr.dataStart+8, 4)
+		if err != nil {
+			return nil, err
+		}
+		track.handler = string(data)
+	}
+
+	// minf → stbl → stsd の最初のエントリの形式がコーデック
+	box := mdia
+	for _, boxType := range []string{"minf", "stbl", "stsd"} {
+		if box, err = findMP4Box(r, *box, boxType); err != nil {
+			return nil, err
+		} else if box == nil {
+			return track, nil
+		}
+	}
+	// version/flags(4) + entry_count(4) + entry size(4) + format(4)
+	if box.end-box.dataStart >= 16 {
+		data, err := readAt(r, box.dataStart+12, 4)
+		if err != nil {
+			return nil, err
+		}
+		track.codec = mp4CodecName(string(data))
+	}
+
+	return track, nil
+}
+
+// readMP4Duration mvhd・mdhdからタイムスケールと長さを取得
+func readMP4Duration(r io.ReaderAt, box mp4Box) (uint32, uint64, error) {
+	data, err := readAt(r, box.dataStart, int(min(box.end-box.dataStart, 32)))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(data) < 20 || (data[0] == 1 && len(data) < 32) {
+		return 0, 0, errInvalidMediaContainer
+	}
+
+	if data[0] == 1 {
+		// version 1: creation(8) + modification(8) + timescale(4) + duration(8)
+		return binary.BigEndian.Uint32(data[20:24]), binary.BigEndian.Uint64(data[24:32]), nil
+	}
+	// version 0: creation(4) + modification(4) + timescale(4) + duration(4)
+	duration := uint64(binary.BigEndian.Uint32(data[16:20]))
+	if duration == math.MaxUint32 {
+		// 長さ不明
+		duration = 0
+	}
+	return binary.BigEndian.Uint32(data[12:16]), duration, nil
+}
+
+// readMP4TrackSize tkhdから表示サイズ（16.16固定小数点）を取得
+func readMP4TrackSize(r io.ReaderAt, tkhd mp4Box) (int, int, error) {
+	version, err := readAt(r, tkhd.dataStart, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// version/flags(4) + 時刻・トラックID・長さ + reserved(8) + layer(2) + alternate_group(2) + volume(2) + reserved(2) + matrix(36)
+	offset := int64(76)
+	if version[0] == 1 {
+		offset = 88
+	}
+	data, err := readAt(r, tkhd.dataStart+offset, 8)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(binary.BigEndian.Uint32(data[0:4]) >> 16), int(binary.BigEndian.Uint32(data[4:8]) >> 16), nil
+}
+
+// mp4CodecName サンプルエントリの形式をコーデック名に変換
+func mp4CodecName(format string) string {
+	switch format {
+	case "avc1", "avc3":
+		return "h264"
+	case "hvc1", "hev1":
+		return "hevc"
+	case "av01":
+		return "av1"
+	case "vp08":
+		return "vp8"
+	case "vp09":
+		return "vp9"
+	case "mp4v":
+		return "mpeg4"
+	case "mp4a":
+		return "aac"
+	case "Opus":
+		return "opus"
+	case "ac-3":
+		return "ac3"
+	case "ec-3":
+		return "eac3"
+	case ".mp3":
+		return "mp3"
+	}
+	return strings.ToLower(strings.TrimSpace(format))
+}
+
+// scaleDuration タイムスケール単位の長さをtime.Durationに変換
+func scaleDuration(duration uint64, timescale uint32) time.Duration {
+	seconds := float64(duration) / float64(timescale)
+	if seconds > math.MaxInt64/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// ---- MP3（MPEGオーディオ）----
+
+// mp3BitratesKbps ビットレート表（[MPEG1/MPEG2][レイヤー1〜3][インデックス]）
+var mp3BitratesKbps = [2][3][16]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	},
+}
+
+// mp3SampleRates MPEG1のサンプリングレート（MPEG2は1/2、MPEG2.5は1/4）
+var mp3SampleRates = [3]int{44100, 48000, 32000}
+
+// mp3FrameSearchLimit 最初のフレームを探す範囲（ID3タグの後）
+const mp3FrameSearchLimit = 64 * 1024
+
+// mp3Frame フレームヘッダーの情報
+type mp3Frame struct {
+	mpeg1           bool
+	layer           int // 1〜3
+	bitrateKbps     int
+	sampleRate      int
+	samplesPerFrame int
+	mono            bool
+}
+
+// parseMP3FrameHeader 4バイトのフレームヘッダーを解析（不正な場合はnil）
+func parseMP3FrameHeader(header []byte) *mp3Frame {
+	if len(header) < 4 || header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+		return nil
+	}
+
+	version := (header[1] >> 3) & 0x03 // 3: MPEG1, 2: MPEG2, 0: MPEG2.5
+	layerBits := (header[1] >> 1) & 0x03
+	bitrateIndex := header[2] >> 4
+	sampleRateIndex := (header[2] >> 2) & 0x03
+	if version == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return nil
+	}
+
+	frame := &mp3Frame{
+		mpeg1: version == 3,
+		layer: 4 - int(layerBits),
+		mono:  header[3]>>6 == 3,
+	}
+
+	table := 1
+	if frame.mpeg1 {
+		table = 0
+	}
+	frame.bitrateKbps = mp3BitratesKbps[table][frame.layer-1][bitrateIndex]
+
+	frame.sampleRate = mp3SampleRates[sampleRateIndex]
+	switch version {
+	case 2:
+		frame.sampleRate /= 2
+	case 0:
+		frame.sampleRate /= 4
+	}
+
+	switch {
+	case frame.layer == 1:
+		frame.samplesPerFrame = 384
+	case frame.layer == 3 && !frame.mpeg1:
+		frame.samplesPerFrame = 576
+	default:
+		frame.samplesPerFrame = 1152
+	}
+
+	return frame
+}
+
+// parseMP3Metadata ID3タグを読み飛ばし、Xing/VBRIヘッダー（VBR）またはビットレート（CBR）から再生時間を求める
+func parseMP3Metadata(r io.ReaderAt, size int64) (*mediaMetadata, error) {
+	// ID3v2タグ（複数ある場合もある）をスキップ
+	audioStart := int64(0)
+	for audioStart+10 <= size {
+		header, err := readAt(r, audioStart, 10)
+		if err != nil {
+			return nil, err
+		}
+		if string(header[:3]) != "ID3" {
+			break
+		}
+		tagSize := int64(header[6]&0x7F)<<21 | int64(header[7]&0x7F)<<14 | int64(header[8]&0x7F)<<7 | int64(header[9]&0x7F)
+		audioStart += 10 + tagSize
+		if header[5]&0x10 != 0 {
+			// フッターあり
+			audioStart += 10
+		}
+	}
+
+	// 末尾のID3v1タグは音声データに含めない
+	audioEnd := size
+	if size-128 >= audioStart {
+		if tag, err := readAt(r, size-128, 3); err == nil && string(tag) == "TAG" {
+			audioEnd -= 128
+		}
+	}
+
+	// 最初のフレームを探す
+	searchEnd := min(audioStart+mp3FrameSearchLimit, audioEnd)
+	if searchEnd-audioStart < 4 {
+		return nil, errInvalidMediaContainer
+	}
+	window, err := readAt(r, audioStart, int(searchEnd-audioStart))
+	if err != nil {
+		return nil, err
+	}
+
+	var frame *mp3Frame
+	frameStart := int64(-1)
+	for i := 0; i+4 <= len(window); i++ {
+		if frame = parseMP3FrameHeader(window[i : i+4]); frame != nil {
+			frameStart = audioStart + int64(i)
+			break
+		}
+	}
+	if frame == nil {
+		return nil, errInvalidMediaContainer
+	}
+
+	metadata := &mediaMetadata{codec: fmt.Sprintf("mp%d", frame.layer)}
+	if frames := readMP3FrameCount(r, frameStart, frame); frames > 0 {
+		// VBR: 総フレーム数 × フレームあたりのサンプル数
+		metadata.duration = time.Duration(float64(frames) * float64(frame.samplesPerFrame) / float64(frame.sampleRate) * float64(time.Second))
+	} else {
+		// CBR: 音声データのサイズ ÷ ビットレート
+		bits := float64(audioEnd-frameStart) * 8
+		metadata.duration = time.Duration(bits / float64(frame.bitrateKbps*1000) * float64(time.Second))
+	}
+
+	return metadata, nil
+}
+
+// readMP3FrameCount 最初のフレームのXing/Info・VBRIヘッダーから総フレーム数を取得（ない場合は0）
+func readMP3FrameCount(r io.ReaderAt, frameStart int64, frame *mp3Frame) uint32 {
+	// Xing/Infoはサイド情報の直後
+	sideInfo := int64(32)
+	switch {
+	case frame.mpeg1 && frame.mono:
+		sideInfo = 17
+	case !frame.mpeg1 && frame.mono:
+		sideInfo = 9
+	case !frame.mpeg1:
+		sideInfo = 17
+	}
+	if data, err := readAt(r, frameStart+4+sideInfo, 12); err == nil {
+		if bytes.Equal(data[:4], []byte("Xing")) || bytes.Equal(data[:4], []byte("Info")) {
+			// フラグの1ビット目がフレーム数の有無
+			if binary.BigEndian.Uint32(data[4:8])&0x01 != 0 {
+				return binary.BigEndian.Uint32(data[8:12])
+			}
+			return 0
+		}
+	}
+
+	// VBRIはフレームヘッダーから32バイト後
+	if data, err := readAt(r, frameStart+4+32, 18); err == nil && bytes.Equal(data[:4], []byte("VBRI")) {
+		return binary.BigEndian.Uint32(data[14:18])
+	}
+
+	return 0
+}
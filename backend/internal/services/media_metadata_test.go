@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mp4TestBox MP4のボックスを作成
+func mp4TestBox(boxType string, payloads ...[]byte) []byte {
+	payload := bytes.Join(payloads, nil)
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(payload)))
+	box = append(box, boxType...)
+	return append(box, payload...)
+}
+
+// mp4TestTrack トラック（tkhd・mdhd・hdlr・stsd）を作成
+func mp4TestTrack(handler, format string, width, height int, timescale, duration uint32) []byte {
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:80], uint32(width)<<16)
+	binary.BigEndian.PutUint32(tkhd[80:84], uint32(height)<<16)
+
+	mdhd := make([]byte, 24)
+	binary.BigEndian.PutUint32(mdhd[12:16], timescale)
+	binary.BigEndian.PutUint32(mdhd[16:20], duration)
+
+	hdlr := make([]byte, 25)
+	copy(hdlr[8:12], handler)
+
+	stsd := binary.BigEndian.AppendUint32(make([]byte, 4), 1)
+	stsd = binary.BigEndian.AppendUint32(stsd, 16)
+	stsd = append(stsd, format...)
+	stsd = append(stsd, make([]byte, 8)...)
+
+	return mp4TestBox("trak",
+		mp4TestBox("tkhd", tkhd),
+		mp4TestBox("mdia",
+			mp4TestBox("mdhd", mdhd),
+			mp4TestBox("hdlr", hdlr),
+			mp4TestBox("minf", mp4TestBox("stbl", mp4TestBox("stsd", stsd))),
+		),
+	)
+}
+
+// newTestMP4WithDuration 指定した長さ・解像度のH.264+AACのMP4を作成（sizeに満たない分はfreeボックスで埋める）
+func newTestMP4WithDuration(duration time.Duration, width, height, size int) []byte {
+	const timescale = 1000
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:20], uint32(duration.Milliseconds()))
+
+	data := mp4TestBox("ftyp", []byte("isom\x00\x00\x02\x00isomiso2"))
+	data = append(data, mp4TestBox("moov",
+		mp4TestBox("mvhd", mvhd),
+		mp4TestTrack("vide", "avc1", width, height, 90000, uint32(duration.Seconds()*90000)),
+		mp4TestTrack("soun", "mp4a", 0, 0, 44100, uint32(duration.Seconds()*44100)),
+	)...)
+
+	if padding := size - len(data); padding >= 8 {
+		data = append(data, mp4TestBox("free", make([]byte, padding-8))...)
+	}
+	return data
+}
+
+// newTestMP4 指定サイズの10秒・640x360のMP4を作成
+func newTestMP4(size int) []byte {
+	return newTestMP4WithDuration(10*time.Second, 640, 360, size)
+}
+
+// mp3TestFrameHeader MPEG1レイヤー3・44.1kHz・ステレオのフレームヘッダー
+func mp3TestFrameHeader(bitrateIndex byte) []byte {
+	return []byte{0xFF, 0xFB, bitrateIndex<<4 | 0x00, 0x00}
+}
+
+// newTestCBRMP3 ID3v2タグ付きのCBR（128kbps）のMP3を作成
+func newTestCBRMP3(duration time.Duration) []byte {
+	id3 := []byte("ID3\x04\x00\x00\x00\x00\x00\x0A")
+	id3 = append(id3, make([]byte, 10)...)
+
+	// 128kbps・44.1kHzのフレームは417バイト
+	const frameSize = 417
+	frames := int(duration.Seconds() * 44100 / 1152)
+	data := id3
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, frameSize)
+		copy(frame, mp3TestFrameHeader(9))
+		data = append(data, frame...)
+	}
+	return data
+}
+
+// newTestVBRMP3 総フレーム数を持つXingヘッダー付きのMP3を作成
+func newTestVBRMP3(frames uint32) []byte {
+	frame := make([]byte, 417)
+	copy(frame, mp3TestFrameHeader(9))
+	// MPEG1ステレオはサイド情報32バイトの後にXingヘッダー
+	copy(frame[36:], "Xing")
+	binary.BigEndian.PutUint32(frame[40:44], 0x01)
+	binary.BigEndian.PutUint32(frame[44:48], frames)
+	return frame
+}
+
+func TestExtractMediaMetadata(t *testing.T) {
+	t.Run("Success - MP4 duration, dimensions and codec", func(t *testing.T) {
+		data := newTestMP4WithDuration(90*time.Second, 1920, 1080, 0)
+
+		metadata, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "video", mediaFormatMP4)
+		require.NoError(t, err)
+		assert.Equal(t, 90, metadata.durationSeconds())
+		assert.Equal(t, 1920, metadata.width)
+		assert.Equal(t, 1080, metadata.height)
+		assert.Equal(t, "h264", metadata.codec)
+	})
+
+	t.Run("Success - Audio-only MP4 uses audio codec", func(t *testing.T) {
+		data := mp4TestBox("ftyp", []byte("M4A \x00\x00\x00\x00"))
+		data = append(data, mp4TestBox("moov", mp4TestTrack("soun", "mp4a", 0, 0, 44100, 44100*30))...)
+
+		metadata, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "video", mediaFormatMP4)
+		require.NoError(t, err)
+		assert.Equal(t, 30, metadata.durationSeconds(), "mvhdがない場合はトラックの長さを使うべき")
+		assert.Equal(t, 0, metadata.width)
+		assert.Equal(t, "aac", metadata.codec)
+	})
+
+	t.Run("Success - CBR MP3 with ID3 tag", func(t *testing.T) {
+		data := newTestCBRMP3(20 * time.Second)
+
+		metadata, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "audio", mediaFormatMP3)
+		require.NoError(t, err)
+		assert.Equal(t, 20, metadata.durationSeconds())
+		assert.Equal(t, "mp3", metadata.codec)
+	})
+
+	t.Run("Success - VBR MP3 uses Xing frame count", func(t *testing.T) {
+		// 1152サンプル × 11025フレーム ÷ 44100Hz = 288秒
+		data := newTestVBRMP3(11025)
+
+		metadata, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "audio", mediaFormatMP3)
+		require.NoError(t, err)
+		assert.Equal(t, 288, metadata.durationSeconds())
+	})
+
+	t.Run("Error - Duration exceeds limit", func(t *testing.T) {
+		data := newTestMP4WithDuration(defaultMaxVideoDuration+time.Second, 640, 360, 0)
+
+		_, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "video", mediaFormatMP4)
+		var validationErr *MediaValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, MediaErrorDurationTooLong, validationErr.Code)
+	})
+
+	t.Run("Error - MP4 without moov", func(t *testing.T) {
+		data := mp4TestBox("ftyp", []byte("isom\x00\x00\x02\x00"))
+		data = append(data, mp4TestBox("mdat", make([]byte, 64))...)
+
+		_, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "video", mediaFormatMP4)
+		var validationErr *MediaValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, MediaErrorInvalidMedia, validationErr.Code)
+	})
+
+	t.Run("Error - Truncated MP4 box", func(t *testing.T) {
+		data := newTestMP4(0)
+		data = data[:len(data)-10]
+
+		_, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "video", mediaFormatMP4)
+		var validationErr *MediaValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, MediaErrorInvalidMedia, validationErr.Code)
+	})
+
+	t.Run("Error - MP3 without frames", func(t *testing.T) {
+		data := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x00"), make([]byte, 100)...)
+
+		_, err := extractMediaMetadata(bytes.NewReader(data), int64(len(data)), "audio", mediaFormatMP3)
+		var validationErr *MediaValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, MediaErrorInvalidMedia, validationErr.Code)
+	})
+}
+
+func TestMediaService_UploadVideoMetadata(t *testing.T) {
+	storage, err := NewLocalStorageService(t.TempDir(), "http://localhost:8080")
+	require.NoError(t, err)
+	service := &MediaService{storage: storage}
+
+	file, header := newTestFileHeader("movie.mov", newTestMP4WithDuration(42*time.Second, 1280, 720, 4096))
+	media, err := service.uploadFile(context.Background(), file, header)
+	require.NoError(t, err)
+
+	require.NotNil(t, media.Duration)
+	assert.Equal(t, 42, *media.Duration)
+	require.NotNil(t, media.Width)
+	assert.Equal(t, 1280, *media.Width)
+	assert.Equal(t, 720, *media.Height)
+	assert.Equal(t, "h264", media.Codec)
+}
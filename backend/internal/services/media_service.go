@@ -4,11 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
 
-	"github.com/yourusername/sns-backend/internal/config"
 	"github.com/yourusername/sns-backend/internal/database"
 	"github.com/yourusername/sns-backend/internal/models"
 	"gorm.io/gorm"
@@ -16,79 +16,298 @@ import (
 
 // MediaService メディアサービス
 type MediaService struct {
-	db                     *gorm.DB
-	firebaseStorageService *FirebaseStorageService
-	useFirebase            bool
+	db      *gorm.DB
+	storage Storage
 }
 
 // NewMediaService MediaServiceのコンストラクタ
 func NewMediaService() *MediaService {
-	cfg := config.AppConfig
-
-	// Firebase Storageが設定されているか確認
-	useFirebase := cfg.FirebaseCredentialsPath != "" && cfg.FirebaseStorageBucket != ""
-
-	var firebaseService *FirebaseStorageService
-	if useFirebase {
-		var err error
-		firebaseService, err = InitFirebaseStorage()
-		if err != nil {
-			// Firebase初期化失敗時はローカルモードにフォールバック
-			fmt.Printf("Warning: Failed to initialize Firebase Storage: %v\n", err)
-			useFirebase = false
-		}
+	// 設定されたドライバー（firebase / local / s3）でストレージを初期化
+	storage, err := GetStorage()
+	if err != nil {
+		// 初期化失敗時はアップロードのみエラーにする
+		fmt.Printf("Warning: Failed to initialize media storage: %v\n", err)
 	}
 
 	return &MediaService{
-		db:                     database.GetDB(),
-		firebaseStorageService: firebaseService,
-		useFirebase:            useFirebase,
+		db:      database.GetDB(),
+		storage: storage,
 	}
 }
 
 // UploadMedia メディアをアップロード
 func (s *MediaService) UploadMedia(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader, postID uint, orderIndex int) (*models.Media, error) {
-	// ファイルサイズ取得
-	fileSize := fileHeader.Size
+	media, err := s.uploadFile(ctx, file, fileHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// メディアレコード作成
+	media.PostID = &postID
+	media.OrderIndex = orderIndex
+
+	if err := s.db.WithContext(ctx).Create(media).Error; err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// UploadFiles 複数ファイルを検証してストレージにアップロード（最大4枚、DBには保存しない）
+// 呼び出し側で紐付け先（メッセージ等）を設定してトランザクション内で保存する
+func (s *MediaService) UploadFiles(ctx context.Context, files []*multipart.FileHeader) ([]models.Media, error) {
+	if len(files) > 4 {
+		return nil, errors.New("maximum 4 media files allowed")
+	}
 
-	// メディアタイプを判定
-	mediaType, err := s.getMediaType(fileHeader.Filename)
+	mediaList := make([]models.Media, 0, len(files))
+
+	for i, fileHeader := range files {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file %d: %w", i, err)
+		}
+
+		media, err := s.uploadFile(ctx, file, fileHeader)
+		file.Close()
+		if err != nil {
+			// 途中で失敗した場合はアップロード済みのオブジェクトを削除
+			s.deleteObjects(ctx, mediaList)
+			return nil, fmt.Errorf("failed to upload media %d: %w", i, err)
+		}
+
+		media.OrderIndex = i
+		mediaList = append(mediaList, *media)
+	}
+
+	return mediaList, nil
+}
+
+// UploadDraftMedia 投稿に紐付ける前の下書きメディアとしてアップロード（最大4枚）
+// 投稿作成時にメディアIDを指定して紐付ける。紐付けられないまま猶予期間を過ぎた下書きは掃除で削除される
+// @param ctx コンテキスト
+// @param userID アップロードするユーザーID
+// @param files アップロードするファイル
+// @return 作成された下書きメディア, error
+func (s *MediaService) UploadDraftMedia(ctx context.Context, userID uint, files []*multipart.FileHeader) ([]models.Media, error) {
+	mediaList, err := s.UploadFiles(ctx, files)
 	if err != nil {
 		return nil, err
 	}
+	if len(mediaList) == 0 {
+		return mediaList, nil
+	}
 
-	// メディアタイプごとのサイズ制限チェック
-	if err := s.validateFileSize(mediaType, fileSize); err != nil {
+	for i := range mediaList {
+		mediaList[i].UserID = &userID
+	}
+	if err := s.db.WithContext(ctx).Create(&mediaList).Error; err != nil {
+		s.deleteObjects(ctx, mediaList)
+		return nil, err
+	}
+
+	return mediaList, nil
+}
+
+// uploadFile ファイルを検証してストレージにアップロードし、未保存のメディアを返す
+func (s *MediaService) uploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (*models.Media, error) {
+	// 拡張子からメディアタイプを判定
+	mediaType, expectedFormat, err := s.getMediaType(fileHeader.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内容（マジックバイト）と実際のサイズを検証（クライアントの申告値は信用しない）
+	fileHeader, err = s.inspectUpload(file, fileHeader, mediaType, expectedFormat)
+	if err != nil {
 		return nil, err
 	}
 
 	// アップロード処理
-	var mediaURL string
-	if s.useFirebase {
-		// Firebase Storageにアップロード
-		mediaURL, err = s.firebaseStorageService.UploadFile(ctx, file, fileHeader)
+	if s.storage == nil {
+		return nil, errors.New("media storage not configured")
+	}
+
+	var media *models.Media
+	if mediaType == "image" {
+		// 画像はメタデータを除去し、サイズ別のバリアントを生成して保存
+		media, err = s.uploadImage(ctx, file, fileHeader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to upload to Firebase Storage: %w", err)
+			return nil, err
 		}
 	} else {
-		// ローカルストレージにアップロード（Phase 1の動作）
-		return nil, errors.New("local storage upload not implemented in Phase 2")
+		// 動画・音声はコンテナのメタデータから再生時間・解像度・コーデックを取得し、再生時間の上限を検証
+		metadata, err := extractMediaMetadata(file, fileHeader.Size, mediaType, expectedFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		objectPath, err := s.storage.UploadFile(ctx, file, fileHeader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload to storage: %w", err)
+		}
+		duration := metadata.durationSeconds()
+		media = &models.Media{
+			MediaType:  mediaType,
+			ObjectPath: objectPath,
+			FileSize:   fileHeader.Size,
+			Duration:   &duration,
+			Codec:      metadata.codec,
+		}
+		if metadata.width > 0 && metadata.height > 0 {
+			media.Width = &metadata.width
+			media.Height = &metadata.height
+		}
 	}
 
-	// メディアレコード作成
-	media := &models.Media{
-		PostID:     postID,
-		MediaType:  mediaType,
-		MediaURL:   mediaURL,
-		FileSize:   fileSize,
-		OrderIndex: orderIndex,
+	// レスポンス用の表示URL（DBには保存しない）
+	if resolver := GetMediaURLResolver(); resolver != nil {
+		resolver.resolve(ctx, media)
 	}
 
-	if err := s.db.WithContext(ctx).Create(media).Error; err != nil {
+	return media, nil
+}
+
+// uploadImage 画像を処理し、オリジナル・フィード用・サムネイルをアップロード
+// 途中で失敗した場合はアップロード済みのバリアントを削除する
+func (s *MediaService) uploadImage(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (*models.Media, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	processed, err := processImage(data, filepath.Ext(fileHeader.Filename))
+	if err != nil {
 		return nil, err
 	}
 
-	return media, nil
+	baseName := strings.TrimSuffix(fileHeader.Filename, filepath.Ext(fileHeader.Filename))
+	variants := []struct {
+		name    string
+		variant imageVariant
+	}{
+		{ImageVariantOriginal, processed.original},
+		{ImageVariantFeed, processed.feed},
+		{ImageVariantThumbnail, processed.thumbnail},
+	}
+
+	paths := make([]string, 0, len(variants))
+	for _, v := range variants {
+		objectPath, err := uploadBytes(ctx, s.storage, baseName+"_"+v.name+v.variant.ext, v.variant.contentType, v.variant.data)
+		if err != nil {
+			for _, uploaded := range paths {
+				if err := s.storage.DeleteFile(ctx, uploaded); err != nil {
+					fmt.Printf("Warning: failed to delete media object %s: %v\n", uploaded, err)
+				}
+			}
+			return nil, fmt.Errorf("failed to upload to storage: %w", err)
+		}
+		paths = append(paths, objectPath)
+	}
+
+	width, height := processed.original.width, processed.original.height
+	return &models.Media{
+		MediaType:           "image",
+		ObjectPath:          paths[0],
+		FeedObjectPath:      paths[1],
+		ThumbnailObjectPath: paths[2],
+		FileSize:            int64(len(processed.original.data)),
+		Width:               &width,
+		Height:              &height,
+	}, nil
+}
+
+// PostMediaInput 投稿作成時に添付するメディア（合計で最大4枚）
+type PostMediaInput struct {
+	Files    []*multipart.FileHeader // 新たにアップロードするファイル
+	MediaIDs []uint                  // アップロード済みの下書きメディアID（指定順に並べる）
+}
+
+// IsEmpty 添付メディアがないか判定
+func (in PostMediaInput) IsEmpty() bool {
+	return len(in.Files) == 0 && len(in.MediaIDs) == 0
+}
+
+// CreatePostWithMedia 投稿とメディアを1つのトランザクションで作成
+// ファイルはトランザクション前にアップロードし、保存に失敗した場合はアップロードしたオブジェクトを削除する
+// 下書きメディアは投稿者本人のもので、投稿・メッセージに未使用の場合のみ紐付け可能
+// @param ctx コンテキスト
+// @param post 作成する投稿（IDが設定される）
+// @param input 添付するメディア
+// @return 添付されたメディア（表示順）, error
+func (s *MediaService) CreatePostWithMedia(ctx context.Context, post *models.Post, input PostMediaInput) ([]models.Media, error) {
+	if len(input.Files)+len(input.MediaIDs) > 4 {
+		return nil, errors.New("maximum 4 media files allowed")
+	}
+
+	uploaded, err := s.UploadFiles(ctx, input.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	var mediaList []models.Media
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+
+		mediaList = make([]models.Media, 0, len(input.MediaIDs)+len(uploaded))
+
+		if len(input.MediaIDs) > 0 {
+			var drafts []models.Media
+			if err := tx.Where("id IN ? AND user_id = ? AND post_id IS NULL AND message_id IS NULL", input.MediaIDs, post.UserID).
+				Find(&drafts).Error; err != nil {
+				return err
+			}
+
+			draftsByID := make(map[uint]models.Media, len(drafts))
+			for _, draft := range drafts {
+				draftsByID[draft.ID] = draft
+			}
+
+			// 指定順に紐付ける（他人の下書き・使用済み・重複指定は見つからない扱い）
+			for _, mediaID := range input.MediaIDs {
+				draft, ok := draftsByID[mediaID]
+				if !ok {
+					return errors.New("media not found")
+				}
+				delete(draftsByID, mediaID)
+
+				// 同時に別の投稿へ紐付けられていないことを条件に更新
+				draft.PostID = &post.ID
+				draft.OrderIndex = len(mediaList)
+				result := tx.Model(&models.Media{}).
+					Where("id = ? AND post_id IS NULL AND message_id IS NULL", draft.ID).
+					Updates(map[string]interface{}{"post_id": post.ID, "order_index": draft.OrderIndex})
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					return errors.New("media not found")
+				}
+				mediaList = append(mediaList, draft)
+			}
+		}
+
+		for i := range uploaded {
+			uploaded[i].PostID = &post.ID
+			uploaded[i].UserID = &post.UserID
+			uploaded[i].OrderIndex = len(mediaList)
+			if err := tx.Create(&uploaded[i]).Error; err != nil {
+				return err
+			}
+			mediaList = append(mediaList, uploaded[i])
+		}
+
+		return nil
+	})
+	if err != nil {
+		// 下書きメディアは下書きのまま残し、今回アップロードしたオブジェクトのみ削除
+		s.deleteObjects(ctx, uploaded)
+		return nil, err
+	}
+
+	return mediaList, nil
 }
 
 // UploadMultipleMedia 複数メディアをアップロード（最大4枚）
@@ -101,90 +320,182 @@ func (s *MediaService) UploadMultipleMedia(ctx context.Context, files []*multipa
 		return []models.Media{}, nil
 	}
 
-	mediaList := make([]models.Media, 0, len(files))
-
-	for i, fileHeader := range files {
-		file, err := fileHeader.Open()
-		if err != nil {
-			return nil, fmt.Errorf("failed to open file %d: %w", i, err)
-		}
-		defer file.Close()
-
-		media, err := s.UploadMedia(ctx, file, fileHeader, postID, i)
-		if err != nil {
-			return nil, fmt.Errorf("failed to upload media %d: %w", i, err)
-		}
+	// すべてアップロードしてから一括保存（一部だけ添付された状態にしない）
+	mediaList, err := s.UploadFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
 
-		mediaList = append(mediaList, *media)
+	for i := range mediaList {
+		mediaList[i].PostID = &postID
+	}
+	if err := s.db.WithContext(ctx).Create(&mediaList).Error; err != nil {
+		s.deleteObjects(ctx, mediaList)
+		return nil, err
 	}
 
 	return mediaList, nil
 }
 
-// getMediaType ファイル名から媒体タイプを判定
-func (s *MediaService) getMediaType(filename string) (string, error) {
+// getMediaType ファイル名から媒体タイプと想定される形式を判定
+func (s *MediaService) getMediaType(filename string) (string, string, error) {
 	ext := strings.ToLower(filepath.Ext(filename))
 
 	switch ext {
-	case ".jpg", ".jpeg", ".png", ".gif", ".heic":
-		return "image", nil
+	case ".jpg", ".jpeg":
+		return "image", mediaFormatJPEG, nil
+	case ".png":
+		return "image", mediaFormatPNG, nil
+	case ".gif":
+		return "image", mediaFormatGIF, nil
+	case ".heic":
+		return "image", mediaFormatHEIC, nil
 	case ".mp4", ".mov":
-		return "video", nil
+		return "video", mediaFormatMP4, nil
 	case ".mp3":
-		return "audio", nil
+		return "audio", mediaFormatMP3, nil
 	default:
-		return "", fmt.Errorf("unsupported file format: %s", ext)
+		return "", "", newMediaValidationError(MediaErrorUnsupportedFormat, "unsupported file format: %s", ext)
 	}
 }
 
-// validateFileSize ファイルサイズを検証
-func (s *MediaService) validateFileSize(mediaType string, fileSize int64) error {
-	const (
-		MaxImageSize = 5 * 1024 * 1024   // 5 MB
-		MaxVideoSize = 50 * 1024 * 1024  // 50 MB
-		MaxAudioSize = 10 * 1024 * 1024  // 10 MB
-	)
+// メディアタイプごとのサイズ上限
+const (
+	MaxImageSize = 5 * 1024 * 1024  // 5 MB
+	MaxVideoSize = 50 * 1024 * 1024 // 50 MB
+	MaxAudioSize = 10 * 1024 * 1024 // 10 MB
+)
 
+// maxMediaSize メディアタイプのサイズ上限を取得
+func maxMediaSize(mediaType string) int64 {
 	switch mediaType {
-	case "image":
-		if fileSize > MaxImageSize {
-			return fmt.Errorf("image file size exceeds limit (max 5MB)")
-		}
 	case "video":
-		if fileSize > MaxVideoSize {
-			return fmt.Errorf("video file size exceeds limit (max 50MB)")
-		}
+		return MaxVideoSize
 	case "audio":
-		if fileSize > MaxAudioSize {
-			return fmt.Errorf("audio file size exceeds limit (max 10MB)")
-		}
+		return MaxAudioSize
+	default:
+		return MaxImageSize
+	}
+}
+
+// validateFileSize ファイルサイズを検証
+func (s *MediaService) validateFileSize(mediaType string, fileSize int64) error {
+	if fileSize > maxMediaSize(mediaType) {
+		return newMediaValidationError(MediaErrorFileTooLarge, "%s file size exceeds limit (max %dMB)", mediaType, maxMediaSize(mediaType)/(1024*1024))
 	}
 
 	return nil
 }
 
-// DeleteMedia メディアを削除
-func (s *MediaService) DeleteMedia(ctx context.Context, mediaID uint) error {
+// DeleteMedia メディアを削除（ストレージ上のオブジェクトも削除）
+// 削除できるのは所有者（投稿の添付は投稿者、メッセージの添付は送信者、下書きはアップロードしたユーザー）と管理者のみ
+// @param ctx コンテキスト
+// @param mediaID メディアID
+// @param requesterID 削除をリクエストしたユーザーID
+func (s *MediaService) DeleteMedia(ctx context.Context, mediaID, requesterID uint) error {
+	db := s.db.WithContext(ctx)
+
 	// メディア情報取得
 	var media models.Media
-	if err := s.db.WithContext(ctx).First(&media, mediaID).Error; err != nil {
+	if err := db.First(&media, mediaID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("media not found")
 		}
 		return err
 	}
 
+	// 所有者チェック（管理者は他人のメディアも削除可能）
+	ownerID, err := mediaOwnerID(db, &media)
+	if err != nil {
+		return err
+	}
+	if ownerID == nil || *ownerID != requesterID {
+		var requester models.User
+		if err := db.Select("id", "role").First(&requester, requesterID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("unauthorized")
+			}
+			return err
+		}
+		if requester.Role != "admin" {
+			return errors.New("unauthorized")
+		}
+	}
+
 	// DBから削除
 	if err := s.db.WithContext(ctx).Delete(&media).Error; err != nil {
 		return err
 	}
 
-	// Firebase Storageからの削除は実装しない（将来的に実装可能）
-	// 理由: URLからファイルパスを抽出して削除する必要があるが、複雑性を避けるため省略
+	// ストレージから削除（失敗しても孤立オブジェクトの掃除で回収される）
+	s.deleteObjects(ctx, []models.Media{media})
 
 	return nil
 }
 
+// mediaOwnerID メディアの所有者のユーザーIDを取得（判定できない場合はnil）
+func mediaOwnerID(db *gorm.DB, media *models.Media) (*uint, error) {
+	switch {
+	case media.PostID != nil:
+		// 論理削除された投稿の添付メディアも投稿者のものとして扱う
+		var post models.Post
+		if err := db.Unscoped().Select("id", "user_id").First(&post, *media.PostID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &post.UserID, nil
+	case media.MessageID != nil:
+		var message models.Message
+		if err := db.Select("id", "sender_id").First(&message, *media.MessageID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &message.SenderID, nil
+	default:
+		// 下書きメディアはアップロードしたユーザーのもの
+		return media.UserID, nil
+	}
+}
+
+// DeleteMediaByPostID 投稿に紐づくメディアをすべて削除（投稿削除時に使用）
+func (s *MediaService) DeleteMediaByPostID(ctx context.Context, postID uint) error {
+	var mediaList []models.Media
+	if err := s.db.WithContext(ctx).Where("post_id = ?", postID).Find(&mediaList).Error; err != nil {
+		return err
+	}
+
+	if len(mediaList) == 0 {
+		return nil
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&mediaList).Error; err != nil {
+		return err
+	}
+
+	s.deleteObjects(ctx, mediaList)
+
+	return nil
+}
+
+// deleteObjects メディアのオブジェクトをストレージから削除（失敗は警告のみ）
+func (s *MediaService) deleteObjects(ctx context.Context, mediaList []models.Media) {
+	if s.storage == nil {
+		return
+	}
+
+	for _, media := range mediaList {
+		for _, objectPath := range media.ObjectPaths() {
+			if err := s.storage.DeleteFile(ctx, objectPath); err != nil {
+				fmt.Printf("Warning: failed to delete media object %s: %v\n", objectPath, err)
+			}
+		}
+	}
+}
+
 // GetMediaByPostID 投稿に紐づくメディア一覧を取得
 func (s *MediaService) GetMediaByPostID(ctx context.Context, postID uint) ([]models.Media, error) {
 	var mediaList []models.Media
@@ -195,5 +506,9 @@ func (s *MediaService) GetMediaByPostID(ctx context.Context, postID uint) ([]mod
 		return nil, err
 	}
 
+	if resolver := GetMediaURLResolver(); resolver != nil {
+		resolver.ResolveMedia(ctx, mediaList)
+	}
+
 	return mediaList, nil
 }
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// mediaOrphanGracePeriod アップロード直後（DB保存前）のオブジェクトを誤って削除しないための猶予期間
+	mediaOrphanGracePeriod = 24 * time.Hour
+	// mediaSweepBatchSize 参照確認のIN句に渡すオブジェクトパスの件数
+	mediaSweepBatchSize = 500
+)
+
+// MediaSweeper 孤立オブジェクトの掃除（どのmediaレコードからも参照されないオブジェクトを削除）
+type MediaSweeper struct {
+	db          *gorm.DB
+	storage     Storage
+	gracePeriod time.Duration
+}
+
+// NewMediaSweeper MediaSweeperのコンストラクタ
+// @param storage 掃除対象のストレージ
+// @param gracePeriod 更新からこの期間が経過したオブジェクトのみ削除対象にする
+func NewMediaSweeper(storage Storage, gracePeriod time.Duration) *MediaSweeper {
+	return &MediaSweeper{
+		db:          database.GetDB(),
+		storage:     storage,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// StartMediaSweeper 孤立オブジェクトの定期掃除を開始（intervalが0以下の場合は何もしない）
+func StartMediaSweeper(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+
+	storage, err := GetStorage()
+	if err != nil {
+		return err
+	}
+
+	NewMediaSweeper(storage, mediaOrphanGracePeriod).Start(ctx, interval)
+	return nil
+}
+
+// Start バックグラウンドで定期的に掃除を実行（ctxのキャンセルで停止）
+func (s *MediaSweeper) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := s.Sweep(ctx)
+				if err != nil {
+					fmt.Printf("Warning: media sweep failed: %v\n", err)
+					continue
+				}
+				if deleted > 0 {
+					fmt.Printf("Media sweep deleted %d orphaned objects\n", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// Sweep ストレージとmediaテーブルを突き合わせ、参照されていないオブジェクトを削除
+// @return 削除したオブジェクト数, error
+func (s *MediaSweeper) Sweep(ctx context.Context) (int, error) {
+	if s.storage == nil {
+		return 0, errors.New("media storage not configured")
+	}
+
+	objects, err := s.storage.ListObjects(ctx, UploadObjectPrefix+"/")
+	if err != nil {
+		return 0, err
+	}
+
+	// 猶予期間を過ぎたオブジェクトのみ候補にする
+	threshold := time.Now().Add(-s.gracePeriod)
+
+	// 投稿に使われないまま猶予期間を過ぎた下書きメディアを削除（オブジェクトは孤立として削除される）
+	if err := s.deleteStaleDrafts(ctx, threshold); err != nil {
+		return 0, err
+	}
+
+	candidates := make([]string, 0, len(objects))
+	for _, object := range objects {
+		if object.UpdatedAt.Before(threshold) {
+			candidates = append(candidates, object.Path)
+		}
+	}
+
+	orphans, err := s.findOrphans(ctx, candidates)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, objectPath := range orphans {
+		if err := s.storage.DeleteFile(ctx, objectPath); err != nil {
+			fmt.Printf("Warning: failed to delete orphaned object %s: %v\n", objectPath, err)
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// deleteStaleDrafts 投稿・メッセージに紐付けられないままthresholdより前に作成された下書きメディアを削除
+func (s *MediaSweeper) deleteStaleDrafts(ctx context.Context, threshold time.Time) error {
+	return s.db.WithContext(ctx).
+		Where("post_id IS NULL AND message_id IS NULL AND user_id IS NOT NULL AND created_at < ?", threshold).
+		Delete(&models.Media{}).Error
+}
+
+// findOrphans 候補のうちmediaレコードから参照されていないオブジェクトパスを返す
+func (s *MediaSweeper) findOrphans(ctx context.Context, candidates []string) ([]string, error) {
+	db := s.db.WithContext(ctx)
+
+	referenced := make(map[string]bool, len(candidates))
+	for start := 0; start < len(candidates); start += mediaSweepBatchSize {
+		end := min(start+mediaSweepBatchSize, len(candidates))
+
+		// オリジナル・画像バリアントのいずれかで参照されていれば残す
+		batch := candidates[start:end]
+		var mediaList []models.Media
+		if err := db.Select("object_path", "feed_object_path", "thumbnail_object_path").
+			Where("object_path IN ? OR feed_object_path IN ? OR thumbnail_object_path IN ?", batch, batch, batch).
+			Find(&mediaList).Error; err != nil {
+			return nil, err
+		}
+		for _, media := range mediaList {
+			for _, path := range media.ObjectPaths() {
+				referenced[path] = true
+			}
+		}
+	}
+
+	orphans := make([]string, 0)
+	for _, path := range candidates {
+		if !referenced[path] {
+			orphans = append(orphans, path)
+		}
+	}
+
+	return orphans, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestMediaSweeper_Sweep(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+
+	t.Run("Success - Deletes only unreferenced objects", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "メディア付き投稿")
+
+		file, header := newTestFileHeader("kept.jpg", []byte("kept"))
+		keptPath, err := storage.UploadFile(ctx, file, header)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(&models.Media{
+			PostID: &post.ID, MediaType: "image", ObjectPath: keptPath, FileSize: 4,
+		}).Error)
+
+		file, header = newTestFileHeader("orphan.jpg", []byte("orphan"))
+		orphanPath, err := storage.UploadFile(ctx, file, header)
+		require.NoError(t, err)
+
+		deleted, err := NewMediaSweeper(storage, 0).Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		_, err = os.Stat(filepath.Join(root, filepath.FromSlash(keptPath)))
+		assert.NoError(t, err, "参照されているオブジェクトは残るべき")
+		_, err = os.Stat(filepath.Join(root, filepath.FromSlash(orphanPath)))
+		assert.True(t, os.IsNotExist(err), "孤立オブジェクトは削除されるべき")
+	})
+
+	t.Run("Success - Keeps objects attached to messages", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		sender := testutil.CreateTestUser(t, db, "sender@example.com", "sender", "password123")
+		conversation := models.Conversation{}
+		require.NoError(t, db.Create(&conversation).Error)
+		message := models.Message{ConversationID: conversation.ID, SenderID: sender.ID}
+		require.NoError(t, db.Create(&message).Error)
+
+		file, header := newTestFileHeader("message.jpg", []byte("message"))
+		messagePath, err := storage.UploadFile(ctx, file, header)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(&models.Media{
+			MessageID: &message.ID, MediaType: "image", ObjectPath: messagePath, FileSize: 7,
+		}).Error)
+
+		deleted, err := NewMediaSweeper(storage, 0).Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+
+		_, err = os.Stat(filepath.Join(root, filepath.FromSlash(messagePath)))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - Keeps image variants", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "画像付き投稿")
+
+		var paths []string
+		for _, name := range []string{"original.jpg", "feed.jpg", "thumbnail.jpg"} {
+			file, header := newTestFileHeader(name, []byte(name))
+			objectPath, err := storage.UploadFile(ctx, file, header)
+			require.NoError(t, err)
+			paths = append(paths, objectPath)
+		}
+		require.NoError(t, db.Create(&models.Media{
+			PostID: &post.ID, MediaType: "image", FileSize: 12,
+			ObjectPath: paths[0], FeedObjectPath: paths[1], ThumbnailObjectPath: paths[2],
+		}).Error)
+
+		deleted, err := NewMediaSweeper(storage, 0).Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted, "バリアントも参照されているとみなすべき")
+	})
+
+	t.Run("Success - Deletes stale draft media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+
+		file, header := newTestFileHeader("draft.jpg", []byte("draft"))
+		draftPath, err := storage.UploadFile(ctx, file, header)
+		require.NoError(t, err)
+		draft := models.Media{UserID: &user.ID, MediaType: "image", ObjectPath: draftPath, FileSize: 5}
+		require.NoError(t, db.Create(&draft).Error)
+
+		deleted, err := NewMediaSweeper(storage, 0).Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		var count int64
+		db.Model(&models.Media{}).Where("id = ?", draft.ID).Count(&count)
+		assert.Equal(t, int64(0), count, "使われなかった下書きは削除されるべき")
+		_, err = os.Stat(filepath.Join(root, filepath.FromSlash(draftPath)))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Success - Grace period protects fresh uploads", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+
+		file, header := newTestFileHeader("fresh.jpg", []byte("fresh"))
+		_, err = storage.UploadFile(ctx, file, header)
+		require.NoError(t, err)
+
+		deleted, err := NewMediaSweeper(storage, mediaOrphanGracePeriod).Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+	})
+}
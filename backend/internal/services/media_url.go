@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/config"
+	"github.com/yourusername/sns-backend/internal/models"
+)
+
+const (
+	// signedURLTTL 署名付きURLの有効期間
+	signedURLTTL = 24 * time.Hour
+	// signedURLRefreshWindow 有効期限をこの単位で丸め、同じ時間帯の表示では同じURLを再利用する
+	signedURLRefreshWindow = time.Hour
+	// signedURLCacheMaxEntries キャッシュの上限（超えた場合は全件破棄）
+	signedURLCacheMaxEntries = 10000
+)
+
+// signedURLKey 署名付きURLキャッシュのキー（オブジェクトパス + 有効期限）
+type signedURLKey struct {
+	objectPath string
+	expiresAt  time.Time
+}
+
+// MediaURLResolver オブジェクトパスを表示用URLに変換（署名付きURLはキャッシュする）
+type MediaURLResolver struct {
+	storage    Storage
+	cdnBaseURL string
+	now        func() time.Time
+
+	mu            sync.Mutex
+	cache         map[signedURLKey]string
+	currentExpiry time.Time
+}
+
+// NewMediaURLResolver MediaURLResolverのコンストラクタ
+// @param storage 署名付きURLを生成するストレージ
+// @param cdnBaseURL CDNのURL（設定されている場合は署名せずにCDNのURLを返す）
+func NewMediaURLResolver(storage Storage, cdnBaseURL string) *MediaURLResolver {
+	return &MediaURLResolver{
+		storage:    storage,
+		cdnBaseURL: strings.TrimRight(cdnBaseURL, "/"),
+		now:        time.Now,
+		cache:      make(map[signedURLKey]string),
+	}
+}
+
+var (
+	mediaURLResolverOnce sync.Once
+	mediaURLResolver     *MediaURLResolver
+)
+
+// GetMediaURLResolver シングルトンインスタンスを取得（ストレージ未設定の場合はnil）
+func GetMediaURLResolver() *MediaURLResolver {
+	mediaURLResolverOnce.Do(func() {
+		storage, err := GetStorage()
+		if err != nil {
+			fmt.Printf("Warning: media URLs cannot be resolved: %v\n", err)
+			return
+		}
+		mediaURLResolver = NewMediaURLResolver(storage, config.AppConfig.MediaCDNBaseURL)
+	})
+	return mediaURLResolver
+}
+
+// ResolveURL オブジェクトパスから表示用URLを取得
+func (r *MediaURLResolver) ResolveURL(ctx context.Context, objectPath string) (string, error) {
+	if objectPath == "" {
+		return "", nil
+	}
+
+	// バックフィルでオブジェクトパスに変換できなかった外部URLはそのまま返す
+	if strings.HasPrefix(objectPath, "http://") || strings.HasPrefix(objectPath, "https://") {
+		return objectPath, nil
+	}
+
+	if r.cdnBaseURL != "" {
+		return r.cdnBaseURL + "/" + objectPath, nil
+	}
+
+	// 有効期限を丸めて、同じ時間帯は同じ署名付きURLを返す（最低でも TTL - 丸め単位 は有効）
+	expiresAt := r.now().Truncate(signedURLRefreshWindow).Add(signedURLTTL)
+	key := signedURLKey{objectPath: objectPath, expiresAt: expiresAt}
+
+	r.mu.Lock()
+	if url, ok := r.cache[key]; ok {
+		r.mu.Unlock()
+		return url, nil
+	}
+	r.mu.Unlock()
+
+	url, err := r.storage.SignedURL(ctx, objectPath, expiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 時間帯が変わったら古い有効期限のエントリを破棄
+	if !expiresAt.Equal(r.currentExpiry) {
+		for cachedKey := range r.cache {
+			if cachedKey.expiresAt.Before(expiresAt) {
+				delete(r.cache, cachedKey)
+			}
+		}
+		if expiresAt.After(r.currentExpiry) {
+			r.currentExpiry = expiresAt
+		}
+	}
+	if len(r.cache) >= signedURLCacheMaxEntries {
+		r.cache = make(map[signedURLKey]string)
+	}
+	r.cache[key] = url
+
+	return url, nil
+}
+
+// ResolveMedia メディア一覧に表示用URLを設定（失敗したメディアは空URLのまま）
+func (r *MediaURLResolver) ResolveMedia(ctx context.Context, mediaList []models.Media) {
+	for i := range mediaList {
+		r.resolve(ctx, &mediaList[i])
+	}
+}
+
+// resolve メディアのオリジナル・画像バリアントの表示用URLを設定
+func (r *MediaURLResolver) resolve(ctx context.Context, media *models.Media) {
+	media.MediaURL = r.resolveOrWarn(ctx, media.ObjectPath)
+
+	// バリアントのない画像（旧データ）はvariantsを返さない
+	if media.FeedObjectPath == "" || media.ThumbnailObjectPath == "" {
+		return
+	}
+	media.Variants = &models.MediaVariants{
+		Thumbnail: r.resolveOrWarn(ctx, media.ThumbnailObjectPath),
+		Feed:      r.resolveOrWarn(ctx, media.FeedObjectPath),
+		Original:  media.MediaURL,
+	}
+}
+
+// resolveOrWarn 表示用URLを取得（失敗時は警告を出して空文字を返す）
+func (r *MediaURLResolver) resolveOrWarn(ctx context.Context, objectPath string) string {
+	url, err := r.ResolveURL(ctx, objectPath)
+	if err != nil {
+		fmt.Printf("Warning: failed to resolve media URL %s: %v\n", objectPath, err)
+		return ""
+	}
+	return url
+}
+
+// applyMediaURLs 投稿のメディアに表示用URLを設定（リポスト元・引用元はapplyReferencedPostsで設定済み）
+func applyMediaURLs(ctx context.Context, posts []models.Post) {
+	resolver := GetMediaURLResolver()
+	if resolver == nil {
+		return
+	}
+
+	for i := range posts {
+		resolver.ResolveMedia(ctx, posts[i].Media)
+	}
+}
+
+// applyMessageMediaURLs メッセージのメディアに表示用URLを設定
+func applyMessageMediaURLs(ctx context.Context, messages []models.Message) {
+	resolver := GetMediaURLResolver()
+	if resolver == nil {
+		return
+	}
+
+	for i := range messages {
+		resolver.ResolveMedia(ctx, messages[i].Media)
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/models"
+)
+
+// fakeSigningStorage 署名回数を記録するテスト用ストレージ
+type fakeSigningStorage struct {
+	signCount int
+}
+
+func (s *fakeSigningStorage) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
+	return newObjectPath(fileHeader.Filename), nil
+}
+
+func (s *fakeSigningStorage) SignedURL(ctx context.Context, objectPath string, expiresAt time.Time) (string, error) {
+	s.signCount++
+	return "https://storage.example.com/" + objectPath + "?expires=" + expiresAt.UTC().Format(time.RFC3339), nil
+}
+
+func (s *fakeSigningStorage) DeleteFile(ctx context.Context, objectPath string) error {
+	return nil
+}
+
+func (s *fakeSigningStorage) ListObjects(ctx context.Context, prefix string) ([]StorageObject, error) {
+	return nil, nil
+}
+
+func TestMediaURLResolver_ResolveURL(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)
+
+	t.Run("Success - Reuses signed URL within the same window", func(t *testing.T) {
+		storage := &fakeSigningStorage{}
+		resolver := NewMediaURLResolver(storage, "")
+		resolver.now = func() time.Time { return base }
+
+		first, err := resolver.ResolveURL(ctx, "uploads/a.jpg")
+		require.NoError(t, err)
+		assert.Equal(t, "https://storage.example.com/uploads/a.jpg?expires=2026-01-02T10:00:00Z", first)
+
+		resolver.now = func() time.Time { return base.Add(30 * time.Minute) }
+		second, err := resolver.ResolveURL(ctx, "uploads/a.jpg")
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, storage.signCount, "同じ時間帯はキャッシュを使うべき")
+	})
+
+	t.Run("Success - Re-signs in the next window and drops old entries", func(t *testing.T) {
+		storage := &fakeSigningStorage{}
+		resolver := NewMediaURLResolver(storage, "")
+		resolver.now = func() time.Time { return base }
+
+		first, err := resolver.ResolveURL(ctx, "uploads/a.jpg")
+		require.NoError(t, err)
+
+		resolver.now = func() time.Time { return base.Add(time.Hour) }
+		second, err := resolver.ResolveURL(ctx, "uploads/a.jpg")
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, 2, storage.signCount)
+		assert.Len(t, resolver.cache, 1, "古い有効期限のエントリは破棄されるべき")
+	})
+
+	t.Run("Success - CDN base URL skips signing", func(t *testing.T) {
+		storage := &fakeSigningStorage{}
+		resolver := NewMediaURLResolver(storage, "https://cdn.example.com/")
+
+		url, err := resolver.ResolveURL(ctx, "uploads/a.jpg")
+		require.NoError(t, err)
+		assert.Equal(t, "https://cdn.example.com/uploads/a.jpg", url)
+		assert.Equal(t, 0, storage.signCount)
+	})
+
+	t.Run("Success - External URLs and empty paths are returned as-is", func(t *testing.T) {
+		storage := &fakeSigningStorage{}
+		resolver := NewMediaURLResolver(storage, "")
+
+		url, err := resolver.ResolveURL(ctx, "https://example.com/legacy.jpg")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/legacy.jpg", url)
+
+		url, err = resolver.ResolveURL(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, url)
+		assert.Equal(t, 0, storage.signCount)
+	})
+}
+
+func TestMediaURLResolver_ResolveMedia(t *testing.T) {
+	resolver := NewMediaURLResolver(&fakeSigningStorage{}, "https://cdn.example.com")
+
+	mediaList := []models.Media{
+		{ObjectPath: "uploads/a.jpg"},
+		{ObjectPath: "uploads/b.mp4"},
+	}
+	resolver.ResolveMedia(context.Background(), mediaList)
+
+	assert.Equal(t, "https://cdn.example.com/uploads/a.jpg", mediaList[0].MediaURL)
+	assert.Equal(t, "https://cdn.example.com/uploads/b.mp4", mediaList[1].MediaURL)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+)
+
+// メディア検証エラーのコード（クライアントが拒否理由を判別するために使用）
+const (
+	MediaErrorUnsupportedFormat   = "unsupported_format"         // 対応していない拡張子
+	MediaErrorUnrecognizedContent = "unrecognized_content"       // 内容から形式を判定できない
+	MediaErrorContentMismatch     = "content_mismatch"           // 拡張子と内容の形式が一致しない
+	MediaErrorFileTooLarge        = "file_too_large"             // 実際のサイズが上限を超えている
+	MediaErrorInvalidImage        = "invalid_image"              // 画像としてデコードできない
+	MediaErrorImageTooLarge       = "image_dimensions_too_large" // 画像の縦横サイズが上限を超えている
+	MediaErrorInvalidMedia        = "invalid_media"              // 動画・音声のメタデータを読み取れない
+	MediaErrorDurationTooLong     = "duration_too_long"          // 再生時間が上限を超えている
+)
+
+// MediaValidationError アップロードされたメディアの検証エラー
+type MediaValidationError struct {
+	Code    string
+	Message string
+}
+
+func (e *MediaValidationError) Error() string {
+	return e.Message
+}
+
+// newMediaValidationError MediaValidationErrorを作成
+func newMediaValidationError(code, format string, args ...interface{}) *MediaValidationError {
+	return &MediaValidationError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// メディアの実際の形式（マジックバイトから判定）
+const (
+	mediaFormatJPEG = "jpeg"
+	mediaFormatPNG  = "png"
+	mediaFormatGIF  = "gif"
+	mediaFormatHEIC = "heic"
+	mediaFormatMP4  = "mp4" // MP4・MOV（ISO BMFF / QuickTime）
+	mediaFormatMP3  = "mp3"
+)
+
+// mediaSniffLength 形式の判定に読み込むバイト数
+const mediaSniffLength = 512
+
+// mediaFormatContentTypes 形式ごとの保存時のContent-Type（クライアントの申告値は使用しない）
+var mediaFormatContentTypes = map[string]string{
+	mediaFormatJPEG: "image/jpeg",
+	mediaFormatPNG:  "image/png",
+	mediaFormatGIF:  "image/gif",
+	mediaFormatHEIC: "image/heic",
+	mediaFormatMP4:  "video/mp4",
+	mediaFormatMP3:  "audio/mpeg",
+}
+
+// heicBrands HEIC/HEIFのftypブランド
+var heicBrands = map[string]bool{
+	"heic": true, "heix": true, "hevc": true, "hevx": true,
+	"heim": true, "heis": true, "mif1": true, "msf1": true,
+}
+
+// sniffMediaFormat 先頭のバイト列からメディアの形式を判定（判定できない場合は空文字）
+func sniffMediaFormat(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		return mediaFormatJPEG
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return mediaFormatPNG
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return mediaFormatGIF
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return mediaFormatMP3
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		// MPEGオーディオのフレーム同期（ID3タグなしのMP3）
+		return mediaFormatMP3
+	}
+
+	// ISO BMFF（MP4・MOV・HEIC）はボックスの種類で判定
+	if len(head) >= 12 {
+		switch string(head[4:8]) {
+		case "ftyp":
+			if heicBrands[string(head[8:12])] {
+				return mediaFormatHEIC
+			}
+			return mediaFormatMP4
+		case "moov", "mdat", "wide", "free", "skip":
+			// ftypを持たない古いQuickTime形式
+			return mediaFormatMP4
+		}
+	}
+
+	return ""
+}
+
+// inspectUpload ファイルの内容を検証し、実際のサイズと形式に基づくヘッダーを返す
+// 拡張子と内容の形式が一致しない場合・実際に読み込んだサイズが上限を超える場合はエラー
+// 検証後はファイルを先頭に戻す
+// @param file アップロードされたファイル
+// @param fileHeader クライアントが送信したヘッダー（ファイル名のみ使用）
+// @param mediaType 拡張子から判定したメディアタイプ
+// @param expectedFormat 拡張子から判定した形式
+// @return 検証済みのヘッダー（サイズ・Content-Typeは実際の値）, error
+func (s *MediaService) inspectUpload(file multipart.File, fileHeader *multipart.FileHeader, mediaType, expectedFormat string) (*multipart.FileHeader, error) {
+	head := make([]byte, mediaSniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	format := sniffMediaFormat(head[:n])
+	if format == "" {
+		return nil, newMediaValidationError(MediaErrorUnrecognizedContent, "file content is not a supported media format")
+	}
+	if format != expectedFormat {
+		return nil, newMediaValidationError(MediaErrorContentMismatch, "file content (%s) does not match extension", format)
+	}
+
+	// 申告されたサイズではなく実際に読み込んだバイト数で制限する（上限+1バイトまで読めば超過を判定できる）
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	size, err := io.Copy(io.Discard, io.LimitReader(file, maxMediaSize(mediaType)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := s.validateFileSize(mediaType, size); err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return &multipart.FileHeader{
+		Filename: fileHeader.Filename,
+		Size:     size,
+		Header:   textproto.MIMEHeader{"Content-Type": {mediaFormatContentTypes[format]}},
+	}, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSniffMediaFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{"JPEG", []byte{0xFF, 0xD8, 0xFF, 0xE0}, mediaFormatJPEG},
+		{"PNG", []byte("\x89PNG\r\n\x1a\n...."), mediaFormatPNG},
+		{"GIF", []byte("GIF89a...."), mediaFormatGIF},
+		{"HEIC", []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00"), mediaFormatHEIC},
+		{"MP4", []byte("\x00\x00\x00\x18ftypisom\x00\x00\x02\x00"), mediaFormatMP4},
+		{"MOV", []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00"), mediaFormatMP4},
+		{"MP3 with ID3", []byte("ID3\x04\x00\x00"), mediaFormatMP3},
+		{"MP3 frame sync", []byte{0xFF, 0xFB, 0x90, 0x64}, mediaFormatMP3},
+		{"Text", []byte("hello world"), ""},
+		{"Empty", []byte{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sniffMediaFormat(tt.head))
+		})
+	}
+}
+
+func TestMediaService_UploadValidation(t *testing.T) {
+	ctx := context.Background()
+
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, newTestImage(10, 10)))
+
+	newService := func(t *testing.T) *MediaService {
+		storage, err := NewLocalStorageService(t.TempDir(), "http://localhost:8080")
+		require.NoError(t, err)
+		return &MediaService{storage: storage}
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		size     int64 // クライアントが申告するサイズ（0の場合は実際のサイズ）
+		wantCode string
+	}{
+		{"Unsupported extension", "document.pdf", []byte("%PDF-1.7"), 0, MediaErrorUnsupportedFormat},
+		{"Unrecognized content", "photo.jpg", []byte("<script>alert(1)</script>"), 0, MediaErrorUnrecognizedContent},
+		{"Extension does not match content", "photo.jpg", pngData.Bytes(), 0, MediaErrorContentMismatch},
+		{"Image disguised as audio", "song.mp3", pngData.Bytes(), 0, MediaErrorContentMismatch},
+		{"Declared size is smaller than actual", "photo.png", append(append([]byte{}, pngData.Bytes()...), make([]byte, MaxImageSize)...), 10, MediaErrorFileTooLarge},
+		{"Corrupted image", "photo.png", []byte("\x89PNG\r\n\x1a\nbroken"), 0, MediaErrorInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run("Error - "+tt.name, func(t *testing.T) {
+			file, header := newTestFileHeader(tt.filename, tt.content)
+			if tt.size > 0 {
+				header.Size = tt.size
+			}
+
+			_, err := newService(t).uploadFile(ctx, file, header)
+			require.Error(t, err)
+
+			var validationErr *MediaValidationError
+			require.True(t, errors.As(err, &validationErr), "MediaValidationErrorを返すべき: %v", err)
+			assert.Equal(t, tt.wantCode, validationErr.Code)
+		})
+	}
+
+	t.Run("Success - Stored size and content type come from actual content", func(t *testing.T) {
+		file, header := newTestFileHeader("photo.png", pngData.Bytes())
+		header.Size = 1
+		header.Header.Set("Content-Type", "text/html")
+
+		validated, err := newService(t).inspectUpload(file, header, "image", mediaFormatPNG)
+		require.NoError(t, err)
+		assert.Equal(t, int64(pngData.Len()), validated.Size)
+		assert.Equal(t, "image/png", validated.Header.Get("Content-Type"))
+	})
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// MentionService メンションサービス
+type MentionService struct {
+	db *gorm.DB
+}
+
+// NewMentionService メンションサービスのコンストラクタ
+func NewMentionService() *MentionService {
+	return &MentionService{
+		db: database.GetDB(),
+	}
+}
+
+// ProcessMentions 投稿内容から@メンションを抽出してpost_mentionsを同期
+// 存在しないユーザー名は無視する。投稿編集時は本文から消えたメンションを削除する
+// @param ctx コンテキスト
+// @param postID 投稿ID
+// @param content 投稿内容
+// @return 新たにメンションされたユーザーID, メンションが外れたユーザーID, error
+func (s *MentionService) ProcessMentions(ctx context.Context, postID uint, content string) ([]uint, []uint, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// コンテンツからメンションを抽出し、実在するユーザーに解決
+	var mentionedIDs []uint
+	if usernames := utils.ExtractMentions(content); len(usernames) > 0 {
+		if err := s.db.WithContext(ctx).Model(&models.User{}).
+			Where("username IN ?", usernames).
+			Pluck("id", &mentionedIDs).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var added, removed []uint
+
+	// トランザクション開始
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingIDs []uint
+		if err := tx.Model(&models.PostMention{}).
+			Where("post_id = ?", postID).
+			Pluck("user_id", &existingIDs).Error; err != nil {
+			return err
+		}
+
+		existing := make(map[uint]bool, len(existingIDs))
+		for _, id := range existingIDs {
+			existing[id] = true
+		}
+		mentioned := make(map[uint]bool, len(mentionedIDs))
+		for _, id := range mentionedIDs {
+			mentioned[id] = true
+		}
+
+		// 本文から消えたメンションを削除
+		for _, id := range existingIDs {
+			if !mentioned[id] {
+				removed = append(removed, id)
+			}
+		}
+		if len(removed) > 0 {
+			if err := tx.Where("post_id = ? AND user_id IN ?", postID, removed).
+				Delete(&models.PostMention{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// 新しいメンションを作成
+		for _, id := range mentionedIDs {
+			if existing[id] {
+				continue
+			}
+			if err := tx.Create(&models.PostMention{PostID: postID, UserID: id}).Error; err != nil {
+				return err
+			}
+			added = append(added, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return added, removed, nil
+}
+
+// NotifyMentions メンションされたユーザーに通知（外れたメンションの未読通知は取り消す）
+// @param ctx コンテキスト
+// @param post 投稿
+// @param added 新たにメンションされたユーザーID
+// @param removed メンションが外れたユーザーID
+func (s *MentionService) NotifyMentions(ctx context.Context, post *models.Post, added, removed []uint) {
+	notificationService := NewNotificationService()
+	for _, userID := range added {
+		if err := notificationService.Notify(ctx, userID, post.UserID, models.NotificationTypeMention, &post.ID, nil); err != nil {
+			fmt.Printf("Warning: failed to create mention notification: %v\n", err)
+		}
+	}
+	for _, userID := range removed {
+		if err := notificationService.RemoveNotification(ctx, userID, post.UserID, models.NotificationTypeMention, &post.ID); err != nil {
+			fmt.Printf("Warning: failed to remove mention notification: %v\n", err)
+		}
+	}
+}
+
+// GetMentionedPosts ユーザーがメンションされた投稿を取得（ページネーション対応）
+// @param ctx コンテキスト
+// @param username メンションされたユーザー名
+// @param userID 現在のユーザーID（いいね・ブックマーク状態取得用、nilの場合は未認証）
+// @param limit 取得件数
+// @param cursor カーソル（最後の投稿ID）
+// @return 投稿リスト, さらにデータがあるか, 次のカーソル, error
+func (s *MentionService) GetMentionedPosts(ctx context.Context, username string, userID *uint, limit int, cursor *string) ([]models.Post, bool, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, false, "", errors.New("limit must be greater than 0")
+	}
+
+	// ユーザーを取得
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, "", errors.New("user not found")
+		}
+		return nil, false, "", err
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.Post{}).
+		Select(postCountsSelect).
+		Joins("INNER JOIN post_mentions ON post_mentions.post_id = posts.id").
+		Where("post_mentions.user_id = ?", user.ID).
+		Where(publishedPostCondition).
+		Preload("User").
+		Preload("Media")
+
+	// フォローしていない鍵アカウントの投稿と、公開範囲外の投稿は表示しない
+	query = excludeProtectedAuthors(query, "posts.user_id", userID)
+	query = excludeInvisiblePosts(query, userID)
+
+	// ブロック・ミュートしているユーザーの投稿は表示しない
+	query = excludeHiddenUsers(query, "posts.user_id", userID)
+
+	// カーソルベースページネーション
+	if cursor != nil && *cursor != "" {
+		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
+		if err == nil {
+			query = beforePostCursor(query, cursorID)
+		}
+	}
+
+	var results []postWithCounts
+	if err := query.Order(postFeedOrder).Limit(limit + 1).Find(&results).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(results) > limit
+	if hasMore {
+		results = results[:limit]
+	}
+
+	posts := toPostsWithCounts(results)
+
+	nextCursor := ""
+	if hasMore && len(posts) > 0 {
+		nextCursor = fmt.Sprintf("%d", posts[len(posts)-1].ID)
+	}
+
+	applyViewerStates(s.db.WithContext(ctx), posts, userID)
+	applyMentions(s.db.WithContext(ctx), posts)
+	applyPolls(s.db.WithContext(ctx), posts, userID)
+	applyReferencedPosts(s.db.WithContext(ctx), posts, userID)
+	applyMediaURLs(ctx, posts)
+
+	return posts, hasMore, nextCursor, nil
+}
+
+// applyMentions - 投稿に解決済みメンションを一括設定（N+1解消）
+func applyMentions(db *gorm.DB, posts []models.Post) {
+	if len(posts) == 0 {
+		return
+	}
+
+	postIDs := make([]uint, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	mentionsMap := mentionsByPostID(db, postIDs)
+	for i := range posts {
+		posts[i].Mentions = mentionsMap[posts[i].ID]
+	}
+}
+
+// mentionsByPostID - 投稿IDごとの解決済みメンションを取得
+func mentionsByPostID(db *gorm.DB, postIDs []uint) map[uint][]models.MentionEntity {
+	type mentionRow struct {
+		PostID   uint
+		UserID   uint
+		Username string
+	}
+
+	mentionsMap := make(map[uint][]models.MentionEntity)
+
+	var rows []mentionRow
+	if err := db.Table("post_mentions").
+		Select("post_mentions.post_id, post_mentions.user_id, users.username").
+		Joins("INNER JOIN users ON users.id = post_mentions.user_id").
+		Where("post_mentions.post_id IN ?", postIDs).
+		Order("post_mentions.id ASC").
+		Scan(&rows).Error; err != nil {
+		fmt.Printf("Warning: failed to load mentions: %v\n", err)
+		return mentionsMap
+	}
+
+	for _, row := range rows {
+		mentionsMap[row.PostID] = append(mentionsMap[row.PostID], models.MentionEntity{
+			UserID:   row.UserID,
+			Username: row.Username,
+		})
+	}
+
+	return mentionsMap
+}
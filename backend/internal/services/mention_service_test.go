@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestMentionService_ProcessMentions(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewMentionService()
+	ctx := context.Background()
+
+	t.Run("Success - Resolve existing users only", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		alice := testutil.CreateTestUser(t, db, "alice@example.com", "alice", "password123")
+		post := testutil.CreateTestPost(t, db, author.ID, "@alice と @nobody へ")
+
+		added, removed, err := service.ProcessMentions(ctx, post.ID, post.Content)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{alice.ID}, added)
+		assert.Empty(t, removed)
+
+		var mentions []models.PostMention
+		require.NoError(t, db.Where("post_id = ?", post.ID).Find(&mentions).Error)
+		assert.Len(t, mentions, 1, "存在するユーザーのみ関連付けられるべき")
+	})
+
+	t.Run("Success - Sync mentions on edit", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		alice := testutil.CreateTestUser(t, db, "alice@example.com", "alice", "password123")
+		bob := testutil.CreateTestUser(t, db, "bob@example.com", "bob", "password123")
+		post := testutil.CreateTestPost(t, db, author.ID, "@alice こんにちは")
+
+		_, _, err := service.ProcessMentions(ctx, post.ID, post.Content)
+		require.NoError(t, err)
+
+		// aliceを外してbobを追加
+		added, removed, err := service.ProcessMentions(ctx, post.ID, "@bob こんにちは")
+		require.NoError(t, err)
+		assert.Equal(t, []uint{bob.ID}, added)
+		assert.Equal(t, []uint{alice.ID}, removed)
+
+		var userIDs []uint
+		require.NoError(t, db.Model(&models.PostMention{}).Where("post_id = ?", post.ID).Pluck("user_id", &userIDs).Error)
+		assert.Equal(t, []uint{bob.ID}, userIDs)
+	})
+}
+
+func TestCreatePost_Mentions(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+
+	t.Run("Success - Mention entities and notification", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		alice := testutil.CreateTestUser(t, db, "alice@example.com", "alice", "password123")
+
+		post, err := CreatePost(author.ID, "@alice 見てください")
+		require.NoError(t, err)
+		require.Len(t, post.Mentions, 1)
+		assert.Equal(t, alice.ID, post.Mentions[0].UserID)
+		assert.Equal(t, "alice", post.Mentions[0].Username)
+
+		// メンション通知が作成される
+		count, err := NewNotificationService().GetUnreadCount(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Success - Only newly added mentions are notified on update", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		alice := testutil.CreateTestUser(t, db, "alice@example.com", "alice", "password123")
+		bob := testutil.CreateTestUser(t, db, "bob@example.com", "bob", "password123")
+
+		post, err := CreatePost(author.ID, "@alice 見てください")
+		require.NoError(t, err)
+
+		_, err = UpdatePost(post.ID, author.ID, "@alice @bob 見てください")
+		require.NoError(t, err)
+
+		var aliceCount, bobCount int64
+		db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", alice.ID, models.NotificationTypeMention).Count(&aliceCount)
+		db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", bob.ID, models.NotificationTypeMention).Count(&bobCount)
+		assert.Equal(t, int64(1), aliceCount, "既存のメンションには再通知しない")
+		assert.Equal(t, int64(1), bobCount)
+	})
+
+	t.Run("Success - Self mention is not notified", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+
+		_, err := CreatePost(author.ID, "@author メモ")
+		require.NoError(t, err)
+
+		count, err := NewNotificationService().GetUnreadCount(ctx, author.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestMentionService_GetMentionedPosts(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewMentionService()
+	ctx := context.Background()
+
+	t.Run("Success - List posts mentioning user with pagination", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		alice := testutil.CreateTestUser(t, db, "alice@example.com", "alice", "password123")
+
+		for _, content := range []string{"@alice 1", "メンションなし", "@alice 2", "@alice 3"} {
+			_, err := CreatePost(author.ID, content)
+			require.NoError(t, err)
+		}
+
+		posts, hasMore, nextCursor, err := service.GetMentionedPosts(ctx, "alice", nil, 2, nil)
+		require.NoError(t, err)
+		assert.Len(t, posts, 2)
+		assert.True(t, hasMore)
+		assert.Equal(t, "@alice 3", posts[0].Content)
+		require.Len(t, posts[0].Mentions, 1)
+		assert.Equal(t, alice.ID, posts[0].Mentions[0].UserID)
+
+		posts, hasMore, _, err = service.GetMentionedPosts(ctx, "alice", nil, 2, &nextCursor)
+		require.NoError(t, err)
+		assert.Len(t, posts, 1)
+		assert.False(t, hasMore)
+		assert.Equal(t, "@alice 1", posts[0].Content)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		_, _, _, err := service.GetMentionedPosts(ctx, "nobody", nil, 20, nil)
+		assert.EqualError(t, err, "user not found")
+	})
+}
@@ -0,0 +1,436 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/realtime"
+	"github.com/yourusername/sns-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// MessageService ダイレクトメッセージサービス
+type MessageService struct {
+	db *gorm.DB
+}
+
+// NewMessageService MessageServiceのコンストラクタ
+func NewMessageService() *MessageService {
+	return &MessageService{
+		db: database.GetDB(),
+	}
+}
+
+// GetOrCreateConversation 指定ユーザーとの1対1の会話を取得（存在しなければ作成）
+// @param ctx コンテキスト
+// @param userID 自分のユーザーID
+// @param username 相手のユーザー名
+// @return 会話, error
+func (s *MessageService) GetOrCreateConversation(ctx context.Context, userID uint, username string) (*models.Conversation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := s.db.WithContext(ctx)
+
+	var target models.User
+	if err := db.Where("username = ?", username).First(&target).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	if target.ID == userID {
+		return nil, errors.New("cannot message yourself")
+	}
+
+	// ブロック関係にある場合は会話を開始できない
+	blocked, err := isBlockedBetween(db, userID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("blocked")
+	}
+
+	// 既存の1対1の会話を検索（参加者ペアの一意キーで重複作成を防ぐ）
+	directKey := directConversationKey(userID, target.ID)
+	var conversation models.Conversation
+	err = db.Where("direct_key = ?", directKey).First(&conversation).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			conversation = models.Conversation{IsGroup: false, DirectKey: &directKey}
+			if err := tx.Create(&conversation).Error; err != nil {
+				return err
+			}
+			members := []models.ConversationMember{
+				{ConversationID: conversation.ID, UserID: userID},
+				{ConversationID: conversation.ID, UserID: target.ID},
+			}
+			return tx.Create(&members).Error
+		})
+		if err != nil {
+			// 同時に作成された場合は作成済みの会話を使う（一意キーで重複しない）
+			conversation = models.Conversation{}
+			if findErr := db.Where("direct_key = ?", directKey).First(&conversation).Error; findErr != nil {
+				return nil, err
+			}
+		}
+	}
+
+	conversations := []models.Conversation{conversation}
+	if err := s.applyConversationDetails(db, conversations, userID); err != nil {
+		return nil, err
+	}
+
+	return &conversations[0], nil
+}
+
+// GetConversations 自分が参加している会話一覧を取得（最新メッセージの新しい順）
+// @param ctx コンテキスト
+// @param userID 自分のユーザーID
+// @param limit 取得件数
+// @param cursor カーソル（最後の会話の最新メッセージID）
+// @return 会話一覧, さらにデータがあるか, 次のカーソル, error
+func (s *MessageService) GetConversations(ctx context.Context, userID uint, limit int, cursor *string) ([]models.Conversation, bool, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, false, "", errors.New("limit must be greater than 0")
+	}
+
+	db := s.db.WithContext(ctx)
+
+	// メッセージが1件もない会話は一覧に表示しない
+	query := db.Model(&models.Conversation{}).
+		Select("conversations.*").
+		Joins("INNER JOIN conversation_members ON conversation_members.conversation_id = conversations.id").
+		Where("conversation_members.user_id = ?", userID).
+		Where("conversations.last_message_id IS NOT NULL")
+
+	if cursor != nil && *cursor != "" {
+		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
+		if err == nil {
+			query = query.Where("conversations.last_message_id < ?", cursorID)
+		}
+	}
+
+	var conversations []models.Conversation
+	if err := query.Order("conversations.last_message_id DESC").Limit(limit + 1).Find(&conversations).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(conversations) > limit
+	if hasMore {
+		conversations = conversations[:limit]
+	}
+
+	nextCursor := ""
+	if hasMore && len(conversations) > 0 {
+		nextCursor = fmt.Sprintf("%d", *conversations[len(conversations)-1].LastMessageID)
+	}
+
+	if err := s.applyConversationDetails(db, conversations, userID); err != nil {
+		return nil, false, "", err
+	}
+
+	return conversations, hasMore, nextCursor, nil
+}
+
+// GetMessages 会話のメッセージ履歴を取得（新しい順）
+// @param ctx コンテキスト
+// @param userID 自分のユーザーID
+// @param conversationID 会話ID
+// @param limit 取得件数
+// @param cursor カーソル（最後のメッセージID）
+// @return メッセージ一覧, さらにデータがあるか, 次のカーソル, error
+func (s *MessageService) GetMessages(ctx context.Context, userID, conversationID uint, limit int, cursor *string) ([]models.Message, bool, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, false, "", errors.New("limit must be greater than 0")
+	}
+
+	db := s.db.WithContext(ctx)
+
+	if _, err := findConversationMember(db, conversationID, userID); err != nil {
+		return nil, false, "", err
+	}
+
+	query := db.Where("conversation_id = ?", conversationID).
+		Preload("Sender").
+		Preload("Media", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC")
+		})
+
+	// カーソルベースページネーション
+	if cursor != nil && *cursor != "" {
+		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
+		if err == nil {
+			query = query.Where("id < ?", cursorID)
+		}
+	}
+
+	// 取得件数+1を取得して、次のページがあるか判定
+	var messages []models.Message
+	if err := query.Order("id DESC").Limit(limit + 1).Find(&messages).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	nextCursor := ""
+	if hasMore && len(messages) > 0 {
+		nextCursor = fmt.Sprintf("%d", messages[len(messages)-1].ID)
+	}
+
+	// メディアの表示URLを設定
+	applyMessageMediaURLs(ctx, messages)
+
+	return messages, hasMore, nextCursor, nil
+}
+
+// SendMessage 会話にメッセージを送信（メディアは最大4つまで添付可能）
+// @param ctx コンテキスト
+// @param senderID 送信者のユーザーID
+// @param conversationID 会話ID
+// @param content 本文（メディア添付時は空でも可）
+// @param files 添付ファイル
+// @return 作成されたメッセージ, error
+func (s *MessageService) SendMessage(ctx context.Context, senderID, conversationID uint, content string, files []*multipart.FileHeader) (*models.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	content = strings.TrimSpace(content)
+	if err := utils.ValidateMessageContent(content, len(files) > 0); err != nil {
+		return nil, err
+	}
+
+	if len(files) > 4 {
+		return nil, errors.New("maximum 4 media files allowed")
+	}
+
+	db := s.db.WithContext(ctx)
+
+	if _, err := findConversationMember(db, conversationID, senderID); err != nil {
+		return nil, err
+	}
+
+	// 他の参加者とブロック関係にある場合は送信不可
+	var recipientIDs []uint
+	if err := db.Model(&models.ConversationMember{}).
+		Where("conversation_id = ? AND user_id <> ?", conversationID, senderID).
+		Pluck("user_id", &recipientIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, recipientID := range recipientIDs {
+		blocked, err := isBlockedBetween(db, senderID, recipientID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, errors.New("blocked")
+		}
+	}
+
+	// 添付ファイルをストレージにアップロード
+	mediaService := NewMediaService()
+	var mediaList []models.Media
+	if len(files) > 0 {
+		var err error
+		mediaList, err = mediaService.UploadFiles(ctx, files)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	message := models.Message{
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Content:        content,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&message).Error; err != nil {
+			return err
+		}
+
+		for i := range mediaList {
+			mediaList[i].MessageID = &message.ID
+			mediaList[i].UserID = &senderID
+		}
+		if len(mediaList) > 0 {
+			if err := tx.Create(&mediaList).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.Conversation{}).
+			Where("id = ?", conversationID).
+			Update("last_message_id", message.ID).Error; err != nil {
+			return err
+		}
+
+		// 自分の送信したメッセージは既読扱い
+		return tx.Model(&models.ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, senderID).
+			Update("last_read_message_id", message.ID).Error
+	})
+	if err != nil {
+		// DBに保存できなかった場合はアップロードしたオブジェクトを削除（孤立メディアの掃除はmediaテーブルのみ対象のため）
+		mediaService.deleteObjects(ctx, mediaList)
+		return nil, err
+	}
+
+	message.Media = mediaList
+	if err := db.First(&message.Sender, senderID).Error; err != nil {
+		return nil, err
+	}
+
+	// 他の参加者へリアルタイム配信
+	realtime.GetHub().Publish(recipientIDs, realtime.EventMessageCreated, message)
+
+	return &message, nil
+}
+
+// MarkAsRead 会話を最新メッセージまで既読にする
+// @param ctx コンテキスト
+// @param userID 自分のユーザーID
+// @param conversationID 会話ID
+// @return error
+func (s *MessageService) MarkAsRead(ctx context.Context, userID, conversationID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := s.db.WithContext(ctx)
+
+	member, err := findConversationMember(db, conversationID, userID)
+	if err != nil {
+		return err
+	}
+
+	var conversation models.Conversation
+	if err := db.First(&conversation, conversationID).Error; err != nil {
+		return err
+	}
+
+	if conversation.LastMessageID == nil {
+		return nil
+	}
+
+	return db.Model(member).Update("last_read_message_id", *conversation.LastMessageID).Error
+}
+
+// findConversationMember 会話の参加者レコードを取得（参加していない場合は会話が存在しない扱い）
+func findConversationMember(db *gorm.DB, conversationID, userID uint) (*models.ConversationMember, error) {
+	var member models.ConversationMember
+	if err := db.Where("conversation_id = ? AND user_id = ?", conversationID, userID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("conversation not found")
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// applyConversationDetails 会話一覧に参加者・最新メッセージ・未読数を一括設定（N+1解消）
+func (s *MessageService) applyConversationDetails(db *gorm.DB, conversations []models.Conversation, userID uint) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+
+	conversationIDs := make([]uint, len(conversations))
+	lastMessageIDs := make([]uint, 0, len(conversations))
+	for i, conversation := range conversations {
+		conversationIDs[i] = conversation.ID
+		if conversation.LastMessageID != nil {
+			lastMessageIDs = append(lastMessageIDs, *conversation.LastMessageID)
+		}
+	}
+
+	// 自分以外の参加者
+	var members []models.ConversationMember
+	if err := db.Preload("User").
+		Where("conversation_id IN ? AND user_id <> ?", conversationIDs, userID).
+		Order("id ASC").
+		Find(&members).Error; err != nil {
+		return err
+	}
+	participants := make(map[uint][]*models.PublicUser)
+	for _, member := range members {
+		participants[member.ConversationID] = append(participants[member.ConversationID], member.User.ToPublicUser(&userID))
+	}
+
+	// 最新メッセージ
+	lastMessages := make(map[uint]*models.Message)
+	if len(lastMessageIDs) > 0 {
+		var messages []models.Message
+		if err := db.Preload("Sender").
+			Preload("Media").
+			Where("id IN ?", lastMessageIDs).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+		applyMessageMediaURLs(db.Statement.Context, messages)
+		for i := range messages {
+			lastMessages[messages[i].ConversationID] = &messages[i]
+		}
+	}
+
+	// 未読数（自分が送信したメッセージは除く）
+	type unreadResult struct {
+		ConversationID uint
+		Count          int64
+	}
+	var unreadResults []unreadResult
+	if err := db.Table("messages").
+		Select("messages.conversation_id, COUNT(*) AS count").
+		Joins("INNER JOIN conversation_members ON conversation_members.conversation_id = messages.conversation_id AND conversation_members.user_id = ?", userID).
+		Where("messages.conversation_id IN ?", conversationIDs).
+		Where("messages.sender_id <> ?", userID).
+		Where("messages.id > COALESCE(conversation_members.last_read_message_id, 0)").
+		Group("messages.conversation_id").
+		Scan(&unreadResults).Error; err != nil {
+		return err
+	}
+	unreadCounts := make(map[uint]int64, len(unreadResults))
+	for _, result := range unreadResults {
+		unreadCounts[result.ConversationID] = result.Count
+	}
+
+	for i := range conversations {
+		id := conversations[i].ID
+		conversations[i].Participants = participants[id]
+		if conversations[i].Participants == nil {
+			conversations[i].Participants = []*models.PublicUser{}
+		}
+		conversations[i].LastMessage = lastMessages[id]
+		conversations[i].UnreadCount = unreadCounts[id]
+	}
+
+	return nil
+}
+
+// directConversationKey - 1対1の会話の参加者ペアを表す一意キー（参加者の順序によらず同じ値になる）
+func directConversationKey(userID, otherUserID uint) string {
+	if userID > otherUserID {
+		userID, otherUserID = otherUserID, userID
+	}
+	return fmt.Sprintf("%d:%d", userID, otherUserID)
+}
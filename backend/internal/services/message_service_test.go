@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestMessageService_GetOrCreateConversation(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewMessageService()
+	ctx := context.Background()
+
+	t.Run("Success - Returns the same conversation for both users", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		conversation, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+		require.Len(t, conversation.Participants, 1)
+		assert.Equal(t, user2.ID, conversation.Participants[0].ID)
+
+		again, err := service.GetOrCreateConversation(ctx, user2.ID, user1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, conversation.ID, again.ID)
+
+		var count int64
+		db.Model(&models.Conversation{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Success - Concurrent requests create a single conversation", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		const workers = 8
+		ids := make(chan uint, workers)
+		errs := make(chan error, workers)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// 双方から同時に会話を開始する
+				userID, username := user1.ID, user2.Username
+				if i%2 == 1 {
+					userID, username = user2.ID, user1.Username
+				}
+				conversation, err := service.GetOrCreateConversation(ctx, userID, username)
+				if err != nil {
+					errs <- err
+					return
+				}
+				ids <- conversation.ID
+			}(i)
+		}
+		wg.Wait()
+		close(ids)
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		var first uint
+		for id := range ids {
+			if first == 0 {
+				first = id
+			}
+			assert.Equal(t, first, id, "同じ会話が返されるべき")
+		}
+
+		var count int64
+		db.Model(&models.Conversation{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Error - Cannot message yourself", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+
+		_, err := service.GetOrCreateConversation(ctx, user1.ID, user1.Username)
+		assert.EqualError(t, err, "cannot message yourself")
+	})
+
+	t.Run("Error - Blocked user", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+		require.NoError(t, NewBlockService().BlockUser(ctx, user2.ID, user1.Username))
+
+		_, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		assert.EqualError(t, err, "blocked")
+	})
+}
+
+func TestMessageService_SendAndList(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewMessageService()
+	ctx := context.Background()
+
+	t.Run("Success - Conversation list has last message and unread count", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		conversation, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+
+		// メッセージのない会話は一覧に表示されない
+		conversations, _, _, err := service.GetConversations(ctx, user2.ID, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, conversations, 0)
+
+		_, err = service.SendMessage(ctx, user1.ID, conversation.ID, "こんにちは", nil)
+		require.NoError(t, err)
+		last, err := service.SendMessage(ctx, user1.ID, conversation.ID, "元気？", nil)
+		require.NoError(t, err)
+
+		conversations, _, _, err = service.GetConversations(ctx, user2.ID, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, conversations, 1)
+		require.NotNil(t, conversations[0].LastMessage)
+		assert.Equal(t, last.ID, conversations[0].LastMessage.ID)
+		assert.Equal(t, int64(2), conversations[0].UnreadCount)
+
+		// 送信者側は未読なし
+		conversations, _, _, err = service.GetConversations(ctx, user1.ID, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, conversations, 1)
+		assert.Equal(t, int64(0), conversations[0].UnreadCount)
+
+		// 既読にすると未読数が0になる
+		require.NoError(t, service.MarkAsRead(ctx, user2.ID, conversation.ID))
+		conversations, _, _, err = service.GetConversations(ctx, user2.ID, 10, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), conversations[0].UnreadCount)
+	})
+
+	t.Run("Success - Message history pagination", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		conversation, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			_, err := service.SendMessage(ctx, user1.ID, conversation.ID, fmt.Sprintf("メッセージ%d", i), nil)
+			require.NoError(t, err)
+		}
+
+		messages, hasMore, nextCursor, err := service.GetMessages(ctx, user2.ID, conversation.ID, 3, nil)
+		require.NoError(t, err)
+		assert.Len(t, messages, 3)
+		assert.True(t, hasMore)
+		assert.NotEmpty(t, nextCursor)
+		assert.Equal(t, "メッセージ4", messages[0].Content)
+
+		messages, hasMore, _, err = service.GetMessages(ctx, user2.ID, conversation.ID, 3, &nextCursor)
+		require.NoError(t, err)
+		assert.Len(t, messages, 2)
+		assert.False(t, hasMore)
+		assert.Equal(t, "メッセージ0", messages[1].Content)
+	})
+
+	t.Run("Error - Non-member cannot read or send", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+		outsider := testutil.CreateTestUser(t, db, "outsider@example.com", "outsider", "password123")
+
+		conversation, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+
+		_, _, _, err = service.GetMessages(ctx, outsider.ID, conversation.ID, 10, nil)
+		assert.EqualError(t, err, "conversation not found")
+
+		_, err = service.SendMessage(ctx, outsider.ID, conversation.ID, "割り込み", nil)
+		assert.EqualError(t, err, "conversation not found")
+	})
+
+	t.Run("Error - Cannot send after block", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		conversation, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+		require.NoError(t, NewBlockService().BlockUser(ctx, user1.ID, user2.Username))
+
+		_, err = service.SendMessage(ctx, user2.ID, conversation.ID, "こんにちは", nil)
+		assert.EqualError(t, err, "blocked")
+
+		_, err = service.SendMessage(ctx, user1.ID, conversation.ID, "こんにちは", nil)
+		assert.EqualError(t, err, "blocked")
+	})
+
+	t.Run("Error - Empty message without media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+
+		conversation, err := service.GetOrCreateConversation(ctx, user1.ID, user2.Username)
+		require.NoError(t, err)
+
+		_, err = service.SendMessage(ctx, user1.ID, conversation.ID, "   ", nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// 1グループあたりに表示する通知者の最大数
+const maxNotificationGroupActors = 3
+
+// NotificationService 通知サービス
+type NotificationService struct {
+	db *gorm.DB
+}
+
+// NewNotificationService NotificationServiceのコンストラクタ
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		db: database.GetDB(),
+	}
+}
+
+// Notify 通知を作成（自分自身の操作、ブロック関係にある・受信者がミュートしているユーザーの操作では通知しない）
+// replyの場合、commentIDには返信先（受信者自身の）コメントIDを渡す
+func (s *NotificationService) Notify(ctx context.Context, recipientID, actorID uint, notificationType string, postID, commentID *uint) error {
+	if recipientID == actorID {
+		return nil
+	}
+
+	hidden, err := isHiddenFrom(s.db.WithContext(ctx), recipientID, actorID)
+	if err != nil {
+		return err
+	}
+	if hidden {
+		return nil
+	}
+
+	notification := &models.Notification{
+		UserID:    recipientID,
+		ActorID:   actorID,
+		Type:      notificationType,
+		PostID:    postID,
+		CommentID: commentID,
+		GroupKey:  notificationGroupKey(notificationType, postID, commentID),
+	}
+
+	return s.db.WithContext(ctx).Create(notification).Error
+}
+
+// RemoveNotification 未読の通知を取り消す（いいね解除・フォロー解除時）
+func (s *NotificationService) RemoveNotification(ctx context.Context, recipientID, actorID uint, notificationType string, postID *uint) error {
+	return s.db.WithContext(ctx).
+		Where("user_id = ? AND actor_id = ? AND group_key = ? AND read_at IS NULL",
+			recipientID, actorID, notificationGroupKey(notificationType, postID, nil)).
+		Delete(&models.Notification{}).Error
+}
+
+// GetNotifications 通知一覧を取得（同種の通知をまとめる、ページネーション対応）
+func (s *NotificationService) GetNotifications(ctx context.Context, userID uint, limit int, cursor *string) ([]models.NotificationGroup, bool, string, error) {
+	if limit <= 0 {
+		return nil, false, "", errors.New("limit must be greater than 0")
+	}
+
+	// グループ単位で集計（未読と既読は別グループとして扱う）
+	type groupRow struct {
+		ID          uint
+		GroupKey    string
+		Type        string
+		ActorsCount int64
+		IsRead      bool
+		CreatedAt   time.Time
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.Notification{}).
+		Select(`MAX(id) AS id, group_key, type,
+			COUNT(DISTINCT actor_id) AS actors_count,
+			(read_at IS NOT NULL) AS is_read,
+			MAX(created_at) AS created_at`).
+		Where("user_id = ?", userID).
+		Group("group_key, type, (read_at IS NOT NULL)")
+
+	// ブロック・ミュートしているユーザーからの通知は表示しない
+	query = excludeHiddenUsers(query, "actor_id", &userID)
+
+	// カーソルベースページネーション（グループ内の最新IDで判定）
+	if cursor != nil && *cursor != "" {
+		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
+		if err == nil {
+			query = query.Having("MAX(id) < ?", cursorID)
+		}
+	}
+
+	var rows []groupRow
+	if err := query.Order("MAX(id) DESC").Limit(limit + 1).Scan(&rows).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	groups := make([]models.NotificationGroup, len(rows))
+	if len(rows) == 0 {
+		return groups, false, "", nil
+	}
+
+	latestIDs := make([]uint, len(rows))
+	groupKeys := make([]string, len(rows))
+	for i, row := range rows {
+		latestIDs[i] = row.ID
+		groupKeys[i] = row.GroupKey
+	}
+
+	// 各グループの最新通知（投稿ID・コメントID取得用）
+	var latest []models.Notification
+	if err := s.db.WithContext(ctx).Where("id IN ?", latestIDs).Find(&latest).Error; err != nil {
+		return nil, false, "", err
+	}
+	latestMap := make(map[uint]models.Notification)
+	for _, n := range latest {
+		latestMap[n.ID] = n
+	}
+
+	// 各グループの最新の通知者を一括取得（N+1解消）
+	var recent []models.Notification
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT * FROM (
+			SELECT notifications.*,
+				ROW_NUMBER() OVER (PARTITION BY group_key, (read_at IS NOT NULL) ORDER BY id DESC) AS rn
+			FROM notifications
+			WHERE user_id = @viewer AND group_key IN @keys
+				AND actor_id NOT IN (`+hiddenUserIDsSubquery+`)
+		) ranked
+		WHERE rn <= @limit
+		ORDER BY id DESC`, map[string]interface{}{
+		"viewer": userID,
+		"keys":   groupKeys,
+		"limit":  maxNotificationGroupActors,
+	}).
+		Scan(&recent).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	actorIDs := make([]uint, 0, len(recent))
+	for _, n := range recent {
+		actorIDs = append(actorIDs, n.ActorID)
+	}
+	var actors []models.User
+	if err := s.db.WithContext(ctx).Where("id IN ?", actorIDs).Find(&actors).Error; err != nil {
+		return nil, false, "", err
+	}
+	actorMap := make(map[uint]*models.PublicUser)
+	for i := range actors {
+		actorMap[actors[i].ID] = actors[i].ToPublicUser(nil)
+	}
+
+	// グループごとに通知者をまとめる
+	type groupID struct {
+		key    string
+		isRead bool
+	}
+	groupActors := make(map[groupID][]*models.PublicUser)
+	seen := make(map[groupID]map[uint]bool)
+	for _, n := range recent {
+		gid := groupID{key: n.GroupKey, isRead: n.ReadAt != nil}
+		if seen[gid] == nil {
+			seen[gid] = make(map[uint]bool)
+		}
+		actor, ok := actorMap[n.ActorID]
+		if !ok || seen[gid][n.ActorID] {
+			continue
+		}
+		seen[gid][n.ActorID] = true
+		groupActors[gid] = append(groupActors[gid], actor)
+	}
+
+	for i, row := range rows {
+		n := latestMap[row.ID]
+		actorsInGroup := groupActors[groupID{key: row.GroupKey, isRead: row.IsRead}]
+		if actorsInGroup == nil {
+			actorsInGroup = []*models.PublicUser{}
+		}
+
+		groups[i] = models.NotificationGroup{
+			ID:          row.ID,
+			Type:        row.Type,
+			PostID:      n.PostID,
+			CommentID:   n.CommentID,
+			Actors:      actorsInGroup,
+			ActorsCount: row.ActorsCount,
+			IsRead:      row.IsRead,
+			CreatedAt:   row.CreatedAt,
+		}
+		if row.ActorsCount > 1 {
+			groups[i].OthersCount = row.ActorsCount - 1
+		}
+	}
+
+	nextCursor := ""
+	if hasMore {
+		nextCursor = fmt.Sprintf("%d", groups[len(groups)-1].ID)
+	}
+
+	return groups, hasMore, nextCursor, nil
+}
+
+// GetUnreadCount 未読通知数を取得（まとめられた通知は1件として数える）
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	query := s.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	query = excludeHiddenUsers(query, "actor_id", &userID)
+	err := query.Distinct("group_key").Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead 通知を既読にする（同じグループの未読通知もまとめて既読化）
+func (s *NotificationService) MarkAsRead(ctx context.Context, userID, notificationID uint) error {
+	var notification models.Notification
+	if err := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("notification not found")
+		}
+		return err
+	}
+
+	return s.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND group_key = ? AND read_at IS NULL", userID, notification.GroupKey).
+		Update("read_at", time.Now()).Error
+}
+
+// MarkAllAsRead すべての通知を既読にする
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID uint) error {
+	return s.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+// notificationGroupKey 通知をまとめるためのキーを生成
+func notificationGroupKey(notificationType string, postID, commentID *uint) string {
+	switch {
+	case notificationType == models.NotificationTypeReply && commentID != nil:
+		return fmt.Sprintf("%s:comment:%d", notificationType, *commentID)
+	case postID != nil:
+		return fmt.Sprintf("%s:post:%d", notificationType, *postID)
+	default:
+		return notificationType
+	}
+}
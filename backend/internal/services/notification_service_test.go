@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestNotificationService_LikeNotifications(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewNotificationService()
+	ctx := context.Background()
+
+	t.Run("Success - Likes on the same post are grouped", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+		likers := []*models.User{
+			testutil.CreateTestUser(t, db, "a@example.com", "usera", "password123"),
+			testutil.CreateTestUser(t, db, "b@example.com", "userb", "password123"),
+			testutil.CreateTestUser(t, db, "c@example.com", "userc", "password123"),
+			testutil.CreateTestUser(t, db, "d@example.com", "userd", "password123"),
+		}
+		for _, liker := range likers {
+			require.NoError(t, LikePost(liker.ID, post.ID))
+		}
+
+		groups, hasMore, _, err := service.GetNotifications(ctx, owner.ID, 20, nil)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		require.Len(t, groups, 1, "同じ投稿へのいいねは1つにまとめられるべき")
+
+		group := groups[0]
+		assert.Equal(t, models.NotificationTypeLike, group.Type)
+		assert.Equal(t, post.ID, *group.PostID)
+		assert.Equal(t, int64(4), group.ActorsCount)
+		assert.Equal(t, int64(3), group.OthersCount, "「Aさん他3人」となるべき")
+		assert.Len(t, group.Actors, 3, "表示する通知者は最大3人")
+		assert.Equal(t, "userd", group.Actors[0].Username, "最新の通知者が先頭になるべき")
+		assert.False(t, group.IsRead)
+	})
+
+	t.Run("Success - Liking own post does not notify", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+		require.NoError(t, LikePost(owner.ID, post.ID))
+
+		count, err := service.GetUnreadCount(ctx, owner.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Success - Unlike removes unread notification", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		liker := testutil.CreateTestUser(t, db, "liker@example.com", "liker", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+
+		require.NoError(t, LikePost(liker.ID, post.ID))
+		require.NoError(t, UnlikePost(liker.ID, post.ID))
+
+		var count int64
+		require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ?", owner.ID).Count(&count).Error)
+		assert.Equal(t, int64(0), count, "いいね解除で未読通知は取り消されるべき")
+	})
+}
+
+func TestNotificationService_FollowAndCommentNotifications(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewNotificationService()
+	ctx := context.Background()
+
+	t.Run("Success - Follow, comment and reply create separate groups", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		commenter := testutil.CreateTestUser(t, db, "commenter@example.com", "commenter", "password123")
+		replier := testutil.CreateTestUser(t, db, "replier@example.com", "replier", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+
+		require.NoError(t, FollowUser(commenter.ID, owner.Username))
+		comment, err := CreateComment(commenter.ID, post.ID, "Nice post")
+		require.NoError(t, err)
+		_, err = CreateReply(replier.ID, comment.ID, "Agreed")
+		require.NoError(t, err)
+
+		// 投稿者: フォロー + コメント（コメントと返信はまとめられる）
+		groups, _, _, err := service.GetNotifications(ctx, owner.ID, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, models.NotificationTypeComment, groups[0].Type)
+		assert.Equal(t, int64(2), groups[0].ActorsCount)
+		assert.Equal(t, models.NotificationTypeFollow, groups[1].Type)
+
+		// コメント投稿者: 返信通知
+		groups, _, _, err = service.GetNotifications(ctx, commenter.ID, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, models.NotificationTypeReply, groups[0].Type)
+		assert.Equal(t, comment.ID, *groups[0].CommentID)
+	})
+}
+
+func TestNotificationService_MarkAsRead(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewNotificationService()
+	ctx := context.Background()
+
+	t.Run("Success - Mark a group as read", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		userA := testutil.CreateTestUser(t, db, "a@example.com", "usera", "password123")
+		userB := testutil.CreateTestUser(t, db, "b@example.com", "userb", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+
+		require.NoError(t, LikePost(userA.ID, post.ID))
+		require.NoError(t, LikePost(userB.ID, post.ID))
+		require.NoError(t, FollowUser(userA.ID, owner.Username))
+
+		count, err := service.GetUnreadCount(ctx, owner.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count, "未読はグループ単位で数えるべき")
+
+		groups, _, _, err := service.GetNotifications(ctx, owner.ID, 20, nil)
+		require.NoError(t, err)
+		var likeGroup models.NotificationGroup
+		for _, g := range groups {
+			if g.Type == models.NotificationTypeLike {
+				likeGroup = g
+			}
+		}
+		require.NoError(t, service.MarkAsRead(ctx, owner.ID, likeGroup.ID))
+
+		count, err = service.GetUnreadCount(ctx, owner.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count, "グループ内の通知がまとめて既読になるべき")
+	})
+
+	t.Run("Error - Cannot mark another user's notification", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		require.NoError(t, FollowUser(other.ID, owner.Username))
+
+		var notification models.Notification
+		require.NoError(t, db.Where("user_id = ?", owner.ID).First(&notification).Error)
+
+		err := service.MarkAsRead(ctx, other.ID, notification.ID)
+		assert.EqualError(t, err, "notification not found")
+	})
+
+	t.Run("Success - Mark all as read", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		userA := testutil.CreateTestUser(t, db, "a@example.com", "usera", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+
+		require.NoError(t, LikePost(userA.ID, post.ID))
+		require.NoError(t, FollowUser(userA.ID, owner.Username))
+		require.NoError(t, service.MarkAllAsRead(ctx, owner.ID))
+
+		count, err := service.GetUnreadCount(ctx, owner.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		groups, _, _, err := service.GetNotifications(ctx, owner.ID, 20, nil)
+		require.NoError(t, err)
+		for _, g := range groups {
+			assert.True(t, g.IsRead)
+		}
+	})
+}
+
+func TestNotificationService_Pagination(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewNotificationService()
+	ctx := context.Background()
+
+	t.Run("Success - Groups are paginated by cursor", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		liker := testutil.CreateTestUser(t, db, "liker@example.com", "liker", "password123")
+		for i := 0; i < 3; i++ {
+			post := testutil.CreateTestPost(t, db, owner.ID, "Test post")
+			require.NoError(t, LikePost(liker.ID, post.ID))
+		}
+
+		groups, hasMore, nextCursor, err := service.GetNotifications(ctx, owner.ID, 2, nil)
+		require.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Len(t, groups, 2)
+
+		groups, hasMore, _, err = service.GetNotifications(ctx, owner.ID, 2, &nextCursor)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Len(t, groups, 1)
+	})
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// PinService プロフィールへの投稿固定サービス
+// 固定できる投稿はユーザーごとに1件（users.pinned_post_idに保持し、新しく固定すると置き換える）
+type PinService struct {
+	db *gorm.DB
+}
+
+// NewPinService PinServiceのコンストラクタ
+func NewPinService() *PinService {
+	return &PinService{
+		db: database.GetDB(),
+	}
+}
+
+// PinPost 自分の投稿をプロフィールに固定（既に固定している投稿があれば置き換える）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID 固定する投稿ID
+// @return error
+func (s *PinService) PinPost(ctx context.Context, userID, postID uint) error {
+	db := s.db.WithContext(ctx)
+
+	var post models.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("post not found")
+		}
+		return err
+	}
+
+	// 下書き・予約投稿は存在しないものとして扱う
+	if !post.IsPublished() {
+		return errors.New("post not found")
+	}
+
+	// 投稿者チェック
+	if post.UserID != userID {
+		return errors.New("unauthorized")
+	}
+
+	// リポストは本文を持たないため固定不可（元投稿は投稿者本人が固定する）
+	if post.RepostOfID != nil {
+		return errors.New("cannot pin repost")
+	}
+
+	// 固定と同時に投稿が削除された場合に削除済みの投稿を固定しないよう、存在を条件に更新する
+	result := db.Model(&models.User{}).
+		Where("id = ? AND EXISTS (SELECT 1 FROM posts WHERE posts.id = ? AND posts.deleted_at IS NULL)", userID, post.ID).
+		Update("pinned_post_id", post.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("post not found")
+	}
+	return nil
+}
+
+// UnpinPost 投稿の固定を解除（固定していない場合もエラーにしない）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID 固定を解除する投稿ID
+// @return error
+func (s *PinService) UnpinPost(ctx context.Context, userID, postID uint) error {
+	// 削除件数が0でもエラーにしない（冪等性）
+	return s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND pinned_post_id = ?", userID, postID).
+		Update("pinned_post_id", nil).Error
+}
+
+// clearPinnedPost 削除された投稿の固定を解除
+func clearPinnedPost(db *gorm.DB, postID uint) error {
+	return db.Model(&models.User{}).
+		Where("pinned_post_id = ?", postID).
+		Update("pinned_post_id", nil).Error
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestPinService(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+	service := &PinService{db: db}
+
+	pinnedPostID := func(t *testing.T, userID uint) *uint {
+		var user models.User
+		require.NoError(t, db.First(&user, userID).Error)
+		return user.PinnedPostID
+	}
+
+	t.Run("Success - Pinned post comes first outside cursor ordering", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		oldest := testutil.CreateTestPost(t, db, user.ID, "固定する投稿")
+		middle := testutil.CreateTestPost(t, db, user.ID, "2番目の投稿")
+		newest := testutil.CreateTestPost(t, db, user.ID, "最新の投稿")
+
+		require.NoError(t, service.PinPost(ctx, user.ID, oldest.ID))
+		pinned := pinnedPostID(t, user.ID)
+		require.NotNil(t, pinned)
+		assert.Equal(t, oldest.ID, *pinned)
+
+		posts, hasMore, nextCursor, err := GetUserPosts(user.Username, nil, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 2, "固定された投稿は件数に含めず先頭に追加するべき")
+		assert.Equal(t, oldest.ID, posts[0].ID)
+		assert.True(t, posts[0].IsPinned)
+		assert.Equal(t, newest.ID, posts[1].ID)
+		assert.False(t, posts[1].IsPinned)
+		assert.True(t, hasMore)
+
+		// 次のページには固定された投稿を含めない
+		posts, hasMore, _, err = GetUserPosts(user.Username, nil, 20, &nextCursor)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, middle.ID, posts[0].ID)
+		assert.False(t, hasMore)
+	})
+
+	t.Run("Success - Pinning replaces previous pin and unpin clears it", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		first := testutil.CreateTestPost(t, db, user.ID, "最初に固定")
+		second := testutil.CreateTestPost(t, db, user.ID, "次に固定")
+
+		require.NoError(t, service.PinPost(ctx, user.ID, first.ID))
+		require.NoError(t, service.PinPost(ctx, user.ID, second.ID))
+		assert.Equal(t, second.ID, *pinnedPostID(t, user.ID))
+
+		// 固定していない投稿の解除は何もしない
+		require.NoError(t, service.UnpinPost(ctx, user.ID, first.ID))
+		assert.Equal(t, second.ID, *pinnedPostID(t, user.ID))
+
+		require.NoError(t, service.UnpinPost(ctx, user.ID, second.ID))
+		assert.Nil(t, pinnedPostID(t, user.ID))
+	})
+
+	t.Run("Success - Deleting pinned post clears pin", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "削除する投稿")
+		require.NoError(t, service.PinPost(ctx, user.ID, post.ID))
+
+		require.NoError(t, DeletePost(post.ID, user.ID))
+		assert.Nil(t, pinnedPostID(t, user.ID))
+
+		posts, _, _, err := GetUserPosts(user.Username, nil, 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("Error - Cannot pin other users' posts, reposts or drafts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "他人の投稿")
+
+		assert.EqualError(t, service.PinPost(ctx, other.ID, post.ID), "unauthorized")
+		assert.EqualError(t, service.PinPost(ctx, other.ID, 99999), "post not found")
+
+		repost, err := NewRepostService().Repost(ctx, other.ID, post.ID)
+		require.NoError(t, err)
+		assert.EqualError(t, service.PinPost(ctx, other.ID, repost.ID), "cannot pin repost")
+
+		draft, err := NewScheduledPostService().CreateDraft(ctx, other.ID, "下書き", nil, PostMediaInput{})
+		require.NoError(t, err)
+		assert.EqualError(t, service.PinPost(ctx, other.ID, draft.ID), "post not found")
+
+		assert.Nil(t, pinnedPostID(t, other.ID))
+	})
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// アンケートの制限
+const (
+	PollMinOptions      = 2
+	PollMaxOptions      = 4
+	PollOptionMaxLength = 25                 // 選択肢の最大文字数
+	PollMaxDuration     = 7 * 24 * time.Hour // 締切までの最大期間
+)
+
+// PollInput アンケートの作成内容
+type PollInput struct {
+	Options        []string
+	MultipleChoice bool
+	ClosesAt       time.Time
+}
+
+// Validate 選択肢の数・文字数と締切日時を検証
+func (p PollInput) Validate() error {
+	now := time.Now()
+	if len(p.Options) < PollMinOptions || len(p.Options) > PollMaxOptions {
+		return fmt.Errorf("poll must have %d to %d options", PollMinOptions, PollMaxOptions)
+	}
+	seen := make(map[string]bool, len(p.Options))
+	for _, option := range p.Options {
+		text := strings.TrimSpace(option)
+		if text == "" {
+			return errors.New("poll option cannot be empty")
+		}
+		if utf8.RuneCountInString(text) > PollOptionMaxLength {
+			return fmt.Errorf("poll option must be at most %d characters", PollOptionMaxLength)
+		}
+		if seen[text] {
+			return errors.New("poll options must be unique")
+		}
+		seen[text] = true
+	}
+	if !p.ClosesAt.After(now) {
+		return errors.New("poll closing time must be in the future")
+	}
+	if p.ClosesAt.Sub(now) > PollMaxDuration {
+		return errors.New("poll duration is too long")
+	}
+	return nil
+}
+
+// toModel 保存用のアンケートを作成（投稿と同時に作成する）
+func (p PollInput) toModel() *models.Poll {
+	options := make([]models.PollOption, len(p.Options))
+	for i, option := range p.Options {
+		options[i] = models.PollOption{Position: i, Text: strings.TrimSpace(option)}
+	}
+	return &models.Poll{
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       p.ClosesAt,
+		Options:        options,
+	}
+}
+
+// PollService アンケートサービス
+type PollService struct {
+	db *gorm.DB
+}
+
+// NewPollService PollServiceのコンストラクタ
+func NewPollService() *PollService {
+	return &PollService{
+		db: database.GetDB(),
+	}
+}
+
+// Vote アンケートに投票（締切前であれば投票し直せる）
+// @param ctx コンテキスト
+// @param userID 投票するユーザーID
+// @param postID アンケートを含む投稿ID
+// @param optionIDs 選択した選択肢ID（単一選択の場合は1つ）
+// @return 投票後のアンケート, error
+func (s *PollService) Vote(ctx context.Context, userID, postID uint, optionIDs []uint) (*models.Poll, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := s.db.WithContext(ctx)
+
+	var post models.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	// 下書き・予約投稿、閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if !post.IsPublished() {
+		return nil, errors.New("post not found")
+	}
+	if canView, err := canViewPost(db, &post, &userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
+	// ブロック関係にある場合は投票不可
+	blocked, err := isBlockedBetween(db, userID, post.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("blocked")
+	}
+
+	var poll models.Poll
+	if err := db.Preload("Options").Where("post_id = ?", post.ID).First(&poll).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("poll not found")
+		}
+		return nil, err
+	}
+	if !time.Now().Before(poll.ClosesAt) {
+		return nil, errors.New("poll closed")
+	}
+	if err := validatePollChoice(&poll, optionIDs); err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// アンケートの行を更新してロックし、同じアンケートへの投票を直列化する
+		// （締切を過ぎていれば更新されない）
+		result := tx.Model(&models.Poll{}).
+			Where("id = ? AND closes_at > ?", poll.ID, time.Now()).
+			Update("voters_count", gorm.Expr("voters_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("poll closed")
+		}
+
+		// 投票し直す場合は以前の投票を取り消す
+		var previous []models.PollVote
+		if err := tx.Where("poll_id = ? AND user_id = ?", poll.ID, userID).Find(&previous).Error; err != nil {
+			return err
+		}
+		if len(previous) > 0 {
+			previousOptionIDs := make([]uint, len(previous))
+			for i, vote := range previous {
+				previousOptionIDs[i] = vote.OptionID
+			}
+			if err := tx.Model(&models.PollOption{}).
+				Where("id IN ?", previousOptionIDs).
+				Update("votes_count", gorm.Expr("votes_count - 1")).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("poll_id = ? AND user_id = ?", poll.ID, userID).Delete(&models.PollVote{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Poll{}).Where("id = ?", poll.ID).
+				Update("voters_count", gorm.Expr("voters_count - 1")).Error; err != nil {
+				return err
+			}
+		}
+
+		votes := make([]models.PollVote, len(optionIDs))
+		for i, optionID := range optionIDs {
+			votes[i] = models.PollVote{PollID: poll.ID, UserID: userID, OptionID: optionID}
+		}
+		if err := tx.Create(&votes).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.PollOption{}).
+			Where("id IN ?", optionIDs).
+			Update("votes_count", gorm.Expr("votes_count + 1")).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts := []models.Post{post}
+	applyPolls(db, posts, &userID)
+	return posts[0].Poll, nil
+}
+
+// validatePollChoice 選択肢がアンケートのものか、単一選択で複数選んでいないかチェック
+func validatePollChoice(poll *models.Poll, optionIDs []uint) error {
+	if len(optionIDs) == 0 {
+		return errors.New("poll choice is required")
+	}
+	if !poll.MultipleChoice && len(optionIDs) > 1 {
+		return errors.New("poll allows only one choice")
+	}
+
+	valid := make(map[uint]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		valid[option.ID] = true
+	}
+	seen := make(map[uint]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if !valid[optionID] || seen[optionID] {
+			return errors.New("poll choice is invalid")
+		}
+		seen[optionID] = true
+	}
+	return nil
+}
+
+// applyPolls - 投稿のアンケートと閲覧者の投票状態を一括設定（N+1解消）
+func applyPolls(db *gorm.DB, posts []models.Post, userID *uint) {
+	if len(posts) == 0 {
+		return
+	}
+
+	postIDs := make([]uint, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	var polls []models.Poll
+	if err := db.Where("post_id IN ?", postIDs).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Find(&polls).Error; err != nil {
+		fmt.Printf("Warning: failed to load polls: %v\n", err)
+		return
+	}
+	if len(polls) == 0 {
+		return
+	}
+
+	// IN句で閲覧者の投票を一括取得
+	votedOptions := make(map[uint]map[uint]bool)
+	if userID != nil {
+		pollIDs := make([]uint, len(polls))
+		for i, poll := range polls {
+			pollIDs[i] = poll.ID
+		}
+		var votes []models.PollVote
+		db.Where("poll_id IN ? AND user_id = ?", pollIDs, *userID).Find(&votes)
+		for _, vote := range votes {
+			if votedOptions[vote.PollID] == nil {
+				votedOptions[vote.PollID] = make(map[uint]bool)
+			}
+			votedOptions[vote.PollID][vote.OptionID] = true
+		}
+	}
+
+	now := time.Now()
+	pollMap := make(map[uint]*models.Poll, len(polls))
+	for i := range polls {
+		preparePollForViewer(&polls[i], votedOptions[polls[i].ID], now)
+		pollMap[polls[i].PostID] = &polls[i]
+	}
+
+	for i := range posts {
+		if poll, ok := pollMap[posts[i].ID]; ok {
+			posts[i].Poll = poll
+		}
+	}
+}
+
+// applyPostPoll - 単一投稿のアンケートを設定
+func applyPostPoll(db *gorm.DB, post *models.Post, userID *uint) {
+	posts := []models.Post{*post}
+	applyPolls(db, posts, userID)
+	post.Poll = posts[0].Poll
+}
+
+// preparePollForViewer - 閲覧者の投票状態を設定し、投票済みまたは締切後の場合のみ結果を公開する
+func preparePollForViewer(poll *models.Poll, votedOptionIDs map[uint]bool, now time.Time) {
+	poll.IsClosed = !now.Before(poll.ClosesAt)
+	poll.HasVoted = len(votedOptionIDs) > 0
+	showResults := poll.HasVoted || poll.IsClosed
+
+	poll.Voters = nil
+	if showResults {
+		voters := poll.VotersCount
+		poll.Voters = &voters
+	}
+
+	for i := range poll.Options {
+		option := &poll.Options[i]
+		option.IsVoted = votedOptionIDs[option.ID]
+		option.Votes = nil
+		if showResults {
+			votes := option.VotesCount
+			option.Votes = &votes
+		}
+	}
+}
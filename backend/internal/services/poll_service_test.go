@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestPollInput_Validate(t *testing.T) {
+	closesAt := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		input   PollInput
+		wantErr string
+	}{
+		{"Valid single choice", PollInput{Options: []string{"はい", "いいえ"}, ClosesAt: closesAt}, ""},
+		{"Valid four options", PollInput{Options: []string{"A", "B", "C", "D"}, MultipleChoice: true, ClosesAt: closesAt}, ""},
+		{"Too few options", PollInput{Options: []string{"A"}, ClosesAt: closesAt}, "poll must have 2 to 4 options"},
+		{"Too many options", PollInput{Options: []string{"A", "B", "C", "D", "E"}, ClosesAt: closesAt}, "poll must have 2 to 4 options"},
+		{"Empty option", PollInput{Options: []string{"A", "  "}, ClosesAt: closesAt}, "poll option cannot be empty"},
+		{"Option too long", PollInput{Options: []string{"A", strings.Repeat("あ", PollOptionMaxLength+1)}, ClosesAt: closesAt}, "poll option must be at most 25 characters"},
+		{"Duplicate options", PollInput{Options: []string{"A", " A "}, ClosesAt: closesAt}, "poll options must be unique"},
+		{"Closing time in the past", PollInput{Options: []string{"A", "B"}, ClosesAt: time.Now().Add(-time.Minute)}, "poll closing time must be in the future"},
+		{"Duration too long", PollInput{Options: []string{"A", "B"}, ClosesAt: time.Now().Add(PollMaxDuration + time.Hour)}, "poll duration is too long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreparePollForViewer(t *testing.T) {
+	now := time.Now()
+	newPoll := func(closesAt time.Time) *models.Poll {
+		return &models.Poll{
+			ClosesAt:    closesAt,
+			VotersCount: 3,
+			Options: []models.PollOption{
+				{ID: 1, VotesCount: 2},
+				{ID: 2, VotesCount: 1},
+			},
+		}
+	}
+
+	t.Run("Results hidden before voting", func(t *testing.T) {
+		poll := newPoll(now.Add(time.Hour))
+		preparePollForViewer(poll, nil, now)
+
+		assert.False(t, poll.IsClosed)
+		assert.False(t, poll.HasVoted)
+		assert.Nil(t, poll.Voters)
+		assert.Nil(t, poll.Options[0].Votes)
+	})
+
+	t.Run("Results shown after voting", func(t *testing.T) {
+		poll := newPoll(now.Add(time.Hour))
+		preparePollForViewer(poll, map[uint]bool{2: true}, now)
+
+		assert.True(t, poll.HasVoted)
+		require.NotNil(t, poll.Voters)
+		assert.Equal(t, int64(3), *poll.Voters)
+		require.NotNil(t, poll.Options[0].Votes)
+		assert.Equal(t, int64(2), *poll.Options[0].Votes)
+		assert.False(t, poll.Options[0].IsVoted)
+		assert.True(t, poll.Options[1].IsVoted)
+	})
+
+	t.Run("Results shown after closing", func(t *testing.T) {
+		poll := newPoll(now.Add(-time.Hour))
+		preparePollForViewer(poll, nil, now)
+
+		assert.True(t, poll.IsClosed)
+		assert.False(t, poll.HasVoted)
+		require.NotNil(t, poll.Options[1].Votes)
+		assert.Equal(t, int64(1), *poll.Options[1].Votes)
+	})
+}
+
+func TestPollService_Vote(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+	service := &PollService{db: db}
+	closesAt := time.Now().Add(24 * time.Hour)
+
+	t.Run("Success - Vote reveals results in feeds", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		voter := testutil.CreateTestUser(t, db, "voter@example.com", "voter", "password123")
+		post, err := CreatePostWithPoll(author.ID, "どちらが好き？", PollInput{Options: []string{"犬", "猫"}, ClosesAt: closesAt}, models.PostVisibilityPublic)
+		require.NoError(t, err)
+		require.NotNil(t, post.Poll)
+		require.Len(t, post.Poll.Options, 2)
+
+		timeline, _, _, err := GetTimeline(&voter.ID, "all", 20, nil)
+		require.NoError(t, err)
+		require.Len(t, timeline, 1)
+		require.NotNil(t, timeline[0].Poll)
+		assert.False(t, timeline[0].Poll.HasVoted)
+		assert.Nil(t, timeline[0].Poll.Options[0].Votes, "投票前は結果を返さないべき")
+
+		poll, err := service.Vote(ctx, voter.ID, post.ID, []uint{post.Poll.Options[1].ID})
+		require.NoError(t, err)
+		assert.True(t, poll.HasVoted)
+		require.NotNil(t, poll.Options[1].Votes)
+		assert.Equal(t, int64(1), *poll.Options[1].Votes)
+		assert.True(t, poll.Options[1].IsVoted)
+
+		timeline, _, _, err = GetTimeline(&voter.ID, "all", 20, nil)
+		require.NoError(t, err)
+		assert.True(t, timeline[0].Poll.HasVoted)
+		require.NotNil(t, timeline[0].Poll.Voters)
+		assert.Equal(t, int64(1), *timeline[0].Poll.Voters)
+
+		// 投票していない閲覧者には引き続き結果を返さない
+		anonymous, err := GetPostByID(post.ID, nil)
+		require.NoError(t, err)
+		assert.Nil(t, anonymous.Poll.Voters)
+	})
+
+	t.Run("Success - Changing vote before closing replaces previous vote", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		voter := testutil.CreateTestUser(t, db, "voter@example.com", "voter", "password123")
+		post, err := CreatePostWithPoll(author.ID, "複数選択", PollInput{Options: []string{"A", "B", "C"}, MultipleChoice: true, ClosesAt: closesAt}, models.PostVisibilityPublic)
+		require.NoError(t, err)
+		options := post.Poll.Options
+
+		_, err = service.Vote(ctx, voter.ID, post.ID, []uint{options[0].ID, options[1].ID})
+		require.NoError(t, err)
+		poll, err := service.Vote(ctx, voter.ID, post.ID, []uint{options[2].ID})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), *poll.Voters, "投票し直しても投票者数は増えないべき")
+		assert.Equal(t, int64(0), *poll.Options[0].Votes)
+		assert.Equal(t, int64(0), *poll.Options[1].Votes)
+		assert.Equal(t, int64(1), *poll.Options[2].Votes)
+	})
+
+	t.Run("Error - Invalid choices", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		voter := testutil.CreateTestUser(t, db, "voter@example.com", "voter", "password123")
+		post, err := CreatePostWithPoll(author.ID, "単一選択", PollInput{Options: []string{"A", "B"}, ClosesAt: closesAt}, models.PostVisibilityPublic)
+		require.NoError(t, err)
+		options := post.Poll.Options
+
+		_, err = service.Vote(ctx, voter.ID, post.ID, []uint{options[0].ID, options[1].ID})
+		assert.EqualError(t, err, "poll allows only one choice")
+
+		_, err = service.Vote(ctx, voter.ID, post.ID, []uint{99999})
+		assert.EqualError(t, err, "poll choice is invalid")
+
+		plain := testutil.CreateTestPost(t, db, author.ID, "アンケートなし")
+		_, err = service.Vote(ctx, voter.ID, plain.ID, []uint{options[0].ID})
+		assert.EqualError(t, err, "poll not found")
+	})
+
+	t.Run("Error - Cannot vote after closing", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		voter := testutil.CreateTestUser(t, db, "voter@example.com", "voter", "password123")
+		post, err := CreatePostWithPoll(author.ID, "締切済み", PollInput{Options: []string{"A", "B"}, ClosesAt: closesAt}, models.PostVisibilityPublic)
+		require.NoError(t, err)
+		_, err = service.Vote(ctx, voter.ID, post.ID, []uint{post.Poll.Options[0].ID})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Model(&models.Poll{}).Where("id = ?", post.Poll.ID).
+			Update("closes_at", time.Now().Add(-time.Minute)).Error)
+
+		_, err = service.Vote(ctx, voter.ID, post.ID, []uint{post.Poll.Options[1].ID})
+		assert.EqualError(t, err, "poll closed")
+
+		closed, err := GetPostByID(post.ID, nil)
+		require.NoError(t, err)
+		assert.True(t, closed.Poll.IsClosed)
+		require.NotNil(t, closed.Poll.Options[0].Votes, "締切後は投票していなくても結果を返すべき")
+		assert.Equal(t, int64(1), *closed.Poll.Options[0].Votes)
+	})
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+// newTestMultipartFiles multipartで送信されたファイルのヘッダーを作成
+func newTestMultipartFiles(t *testing.T, files map[string][]byte, order []string) []*multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, name := range order {
+		part, err := writer.CreateFormFile("files", name)
+		require.NoError(t, err)
+		_, err = part.Write(files[name])
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(32 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["files"]
+}
+
+func TestMediaService_CreatePostWithMedia(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, newTestImage(10, 10)))
+
+	newService := func(t *testing.T) (*MediaService, string) {
+		root := t.TempDir()
+		storage, err := NewLocalStorageService(root, "http://localhost:8080")
+		require.NoError(t, err)
+		return &MediaService{db: db, storage: storage}, root
+	}
+
+	countFiles := func(t *testing.T, root string) int {
+		count := 0
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				count++
+			}
+			return nil
+		})
+		return count
+	}
+
+	t.Run("Success - Creates post with drafts and new files in order", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service, _ := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		drafts, err := service.UploadDraftMedia(ctx, user.ID, newTestMultipartFiles(t,
+			map[string][]byte{"a.png": pngData.Bytes(), "b.png": pngData.Bytes()}, []string{"a.png", "b.png"}))
+		require.NoError(t, err)
+		require.Len(t, drafts, 2)
+
+		post := &models.Post{UserID: user.ID, Content: "メディア付き投稿"}
+		files := newTestMultipartFiles(t, map[string][]byte{"c.png": pngData.Bytes()}, []string{"c.png"})
+		mediaList, err := service.CreatePostWithMedia(ctx, post, PostMediaInput{
+			Files:    files,
+			MediaIDs: []uint{drafts[1].ID, drafts[0].ID},
+		})
+		require.NoError(t, err)
+		require.NotZero(t, post.ID)
+		require.Len(t, mediaList, 3)
+
+		var saved []models.Media
+		require.NoError(t, db.Where("post_id = ?", post.ID).Order("order_index ASC").Find(&saved).Error)
+		require.Len(t, saved, 3)
+		assert.Equal(t, drafts[1].ID, saved[0].ID, "下書きは指定順に並ぶべき")
+		assert.Equal(t, drafts[0].ID, saved[1].ID)
+		assert.Equal(t, 2, saved[2].OrderIndex)
+		require.NotNil(t, saved[2].UserID)
+		assert.Equal(t, user.ID, *saved[2].UserID)
+	})
+
+	t.Run("Error - Another user's draft rolls back post and uploaded files", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service, root := newService(t)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		drafts, err := service.UploadDraftMedia(ctx, owner.ID, newTestMultipartFiles(t,
+			map[string][]byte{"a.png": pngData.Bytes()}, []string{"a.png"}))
+		require.NoError(t, err)
+		draftFiles := countFiles(t, root)
+
+		post := &models.Post{UserID: other.ID, Content: "他人の下書きを使う投稿"}
+		files := newTestMultipartFiles(t, map[string][]byte{"b.png": pngData.Bytes()}, []string{"b.png"})
+		_, err = service.CreatePostWithMedia(ctx, post, PostMediaInput{Files: files, MediaIDs: []uint{drafts[0].ID}})
+		assert.EqualError(t, err, "media not found")
+
+		var postCount int64
+		db.Model(&models.Post{}).Count(&postCount)
+		assert.Equal(t, int64(0), postCount, "投稿は作成されないべき")
+
+		var draft models.Media
+		require.NoError(t, db.First(&draft, drafts[0].ID).Error)
+		assert.Nil(t, draft.PostID, "下書きは紐付けられないべき")
+		assert.Equal(t, draftFiles, countFiles(t, root), "今回アップロードしたファイルは削除されるべき")
+	})
+
+	t.Run("Error - Invalid file uploads nothing", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service, root := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := &models.Post{UserID: user.ID, Content: "不正なファイル付き投稿"}
+		files := newTestMultipartFiles(t,
+			map[string][]byte{"a.png": pngData.Bytes(), "b.png": []byte("not an image")}, []string{"a.png", "b.png"})
+		_, err := service.CreatePostWithMedia(ctx, post, PostMediaInput{Files: files})
+		require.Error(t, err)
+
+		var postCount int64
+		db.Model(&models.Post{}).Count(&postCount)
+		assert.Equal(t, int64(0), postCount)
+		assert.Equal(t, 0, countFiles(t, root), "先にアップロードしたファイルも削除されるべき")
+	})
+
+	t.Run("Error - More than 4 media", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service, _ := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := &models.Post{UserID: user.ID, Content: "メディアが多すぎる投稿"}
+		_, err := service.CreatePostWithMedia(ctx, post, PostMediaInput{MediaIDs: []uint{1, 2, 3, 4, 5}})
+		assert.EqualError(t, err, "maximum 4 media files allowed")
+	})
+}
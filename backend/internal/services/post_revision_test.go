@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+// TestUpdatePost_History - 投稿編集時の履歴保存のテスト
+func TestUpdatePost_History(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	t.Run("Success - Each edit saves previous content", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "testuser", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "first")
+
+		_, err := UpdatePost(post.ID, user.ID, "second")
+		testutil.AssertNoError(t, err, "First edit should succeed")
+		updated, err := UpdatePost(post.ID, user.ID, "third")
+		testutil.AssertNoError(t, err, "Second edit should succeed")
+
+		testutil.AssertEqual(t, "third", updated.Content, "Content should be updated")
+		testutil.AssertEqual(t, 2, updated.EditCount, "Edit count should be 2")
+		testutil.AssertTrue(t, updated.EditedAt != nil, "EditedAt should be set")
+
+		history, err := GetPostHistory(post.ID, nil)
+		testutil.AssertNoError(t, err, "GetPostHistory should not return error")
+		testutil.AssertEqual(t, 2, len(history), "Should have 2 prior versions")
+		testutil.AssertEqual(t, "second", history[0].Content, "Newest prior version should come first")
+		testutil.AssertEqual(t, 2, history[0].Version, "Version should be 2")
+		testutil.AssertEqual(t, "first", history[1].Content, "Original content should be kept")
+		testutil.AssertEqual(t, 1, history[1].Version, "Original should be version 1")
+	})
+
+	t.Run("Success - Unchanged content does not create revision", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "testuser", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "same")
+
+		updated, err := UpdatePost(post.ID, user.ID, "same")
+		testutil.AssertNoError(t, err, "UpdatePost should not return error")
+		testutil.AssertEqual(t, 0, updated.EditCount, "Edit count should not change")
+		testutil.AssertTrue(t, updated.EditedAt == nil, "EditedAt should not be set")
+
+		var count int64
+		db.Model(&models.PostRevision{}).Where("post_id = ?", post.ID).Count(&count)
+		testutil.AssertEqual(t, int64(0), count, "No revision should be saved")
+	})
+
+	t.Run("Success - Hashtags follow current revision", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "testuser", "password123")
+		post, err := CreatePost(user.ID, "#golang 入門")
+		testutil.AssertNoError(t, err, "CreatePost should not return error")
+
+		updated, err := UpdatePost(post.ID, user.ID, "#rust 入門")
+		testutil.AssertNoError(t, err, "UpdatePost should not return error")
+
+		testutil.AssertEqual(t, 1, len(updated.Hashtags), "Should have 1 hashtag")
+		testutil.AssertEqual(t, "rust", updated.Hashtags[0].Name, "Hashtag should match current content")
+	})
+
+	t.Run("Error - History of non-existent post", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		_, err := GetPostHistory(99999, nil)
+
+		testutil.AssertError(t, err, "Should return error for non-existent post")
+		testutil.AssertEqual(t, "post not found", err.Error(), "Error should be post not found")
+	})
+
+	t.Run("Error - History of private account post hidden from non-followers", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		db.Model(owner).Update("is_protected", true)
+		post := testutil.CreateTestPost(t, db, owner.ID, "鍵アカウントの投稿")
+
+		_, err := GetPostHistory(post.ID, &other.ID)
+		testutil.AssertError(t, err, "Non-follower should not see history")
+
+		_, err = GetPostHistory(post.ID, &owner.ID)
+		testutil.AssertNoError(t, err, "Owner should see history")
+	})
+}
+
+func TestIsEditWindowExpired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		window    time.Duration
+		expected  bool
+	}{
+		{"No window", now.Add(-24 * time.Hour), 0, false},
+		{"Within window", now.Add(-10 * time.Minute), 30 * time.Minute, false},
+		{"After window", now.Add(-31 * time.Minute), 30 * time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.AssertEqual(t, tt.expected, isEditWindowExpired(tt.createdAt, tt.window, now), "Result should match")
+		})
+	}
+}
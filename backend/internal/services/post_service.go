@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/yourusername/sns-backend/internal/config"
 	"github.com/yourusername/sns-backend/internal/database"
 	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/realtime"
 	"github.com/yourusername/sns-backend/internal/utils"
 	"gorm.io/gorm"
 )
@@ -74,6 +77,13 @@ func (s *PostService) GetPostByID(ctx context.Context, postID uint, userID *uint
 		return nil, err
 	}
 
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(s.db.WithContext(ctx), &post, userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
 	// いいね数・コメント数を集計
 	s.db.WithContext(ctx).Model(&models.PostLike{}).Where("post_id = ?", post.ID).Count(&post.LikesCount)
 	s.db.WithContext(ctx).Model(&models.Comment{}).Where("post_id = ?", post.ID).Count(&post.CommentsCount)
@@ -85,6 +95,16 @@ func (s *PostService) GetPostByID(ctx context.Context, postID uint, userID *uint
 		post.IsLiked = count > 0
 	}
 
+	// メンションを設定
+	post.Mentions = mentionsByPostID(s.db.WithContext(ctx), []uint{post.ID})[post.ID]
+
+	// リポスト数・参照先投稿・アンケートを設定
+	applyPostRepostInfo(s.db.WithContext(ctx), &post, userID)
+	applyPostPoll(s.db.WithContext(ctx), &post, userID)
+
+	// メディアの表示URLを設定
+	applyMediaURLs(ctx, []models.Post{post})
+
 	return &post, nil
 }
 
@@ -98,16 +118,8 @@ func GetTimeline(userID *uint, timelineType string, limit int, cursor *string) (
 	}
 
 	// サブクエリを使用した集計で N+1 問題を解消
-	type PostWithCounts struct {
-		models.Post
-		LikesCount    int64 `gorm:"column:likes_count"`
-		CommentsCount int64 `gorm:"column:comments_count"`
-	}
-
 	query := db.Model(&models.Post{}).
-		Select(`posts.*,
-			(SELECT COUNT(*) FROM post_likes WHERE post_likes.post_id = posts.id) as likes_count,
-			(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) as comments_count`).
+		Select(postCountsSelect).
 		Preload("User").
 		Preload("Media")
 
@@ -118,17 +130,28 @@ func GetTimeline(userID *uint, timelineType string, limit int, cursor *string) (
 			Where("follows.follower_id = ?", *userID)
 	}
 
+	// 下書き・予約投稿と、元投稿が削除されたリポストは表示しない
+	query = query.Where(publishedPostCondition).Where(availableRepostCondition)
+
+	// ブロック・ミュートしているユーザーの投稿（リポスト元を含む）は表示しない
+	query = excludeHiddenUsers(query, "posts.user_id", userID)
+	query = excludeHiddenReposts(query, userID)
+
+	// フォローしていない鍵アカウントの投稿と、公開範囲外の投稿は表示しない
+	query = excludeProtectedAuthors(query, "posts.user_id", userID)
+	query = excludeInvisiblePosts(query, userID)
+
 	// カーソルベースページネーション
 	if cursor != nil && *cursor != "" {
 		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
 		if err == nil {
-			query = query.Where("posts.id < ?", cursorID)
+			query = beforePostCursor(query, cursorID)
 		}
 	}
 
 	// 取得件数+1を取得して、次のページがあるか判定
-	var results []PostWithCounts
-	if err := query.Order("posts.created_at DESC").Limit(limit + 1).Find(&results).Error; err != nil {
+	var results []postWithCounts
+	if err := query.Order(postFeedOrder).Limit(limit + 1).Find(&results).Error; err != nil {
 		return nil, false, "", err
 	}
 
@@ -137,18 +160,8 @@ func GetTimeline(userID *uint, timelineType string, limit int, cursor *string) (
 		results = results[:limit]
 	}
 
-	// PostWithCounts から models.Post に変換し、集計結果を設定
-	posts := make([]models.Post, len(results))
-	for i := range results {
-		// 埋め込みフィールドを含めて全てコピー
-		posts[i] = results[i].Post
-		// 集計結果を明示的に設定
-		posts[i].LikesCount = results[i].LikesCount
-		posts[i].CommentsCount = results[i].CommentsCount
-		// Preloadされたリレーションもコピーされている
-		posts[i].User = results[i].Post.User
-		posts[i].Media = results[i].Post.Media
-	}
+	// postWithCounts から models.Post に変換し、集計結果を設定
+	posts := toPostsWithCounts(results)
 
 	// 次のカーソル
 	nextCursor := ""
@@ -156,43 +169,18 @@ func GetTimeline(userID *uint, timelineType string, limit int, cursor *string) (
 		nextCursor = fmt.Sprintf("%d", posts[len(posts)-1].ID)
 	}
 
-	// ログインユーザーのいいね状態を一括取得（N+1解消）
-	if userID != nil && len(posts) > 0 {
-		postIDs := make([]uint, len(posts))
-		for i, post := range posts {
-			postIDs[i] = post.ID
-		}
+	// ログインユーザーのいいね・ブックマーク状態を一括取得（N+1解消）
+	applyViewerStates(db, posts, userID)
 
-		// IN句で一括取得
-		var likedPosts []models.PostLike
-		db.Where("post_id IN ? AND user_id = ?", postIDs, *userID).Find(&likedPosts)
+	// メンション・アンケートを一括取得
+	applyMentions(db, posts)
+	applyPolls(db, posts, userID)
 
-		// マップ化して高速検索
-		likedMap := make(map[uint]bool)
-		for _, like := range likedPosts {
-			likedMap[like.PostID] = true
-		}
-
-		// 投稿にいいね状態を設定
-		for i := range posts {
-			posts[i].IsLiked = likedMap[posts[i].ID]
-		}
+	// リポスト元・引用元の投稿を一括取得
+	applyReferencedPosts(db, posts, userID)
 
-		// ブックマーク状態を一括取得（Phase 2）
-		var bookmarkedPosts []models.Bookmark
-		db.Where("post_id IN ? AND user_id = ?", postIDs, *userID).Find(&bookmarkedPosts)
-
-		// マップ化して高速検索
-		bookmarkedMap := make(map[uint]bool)
-		for _, bookmark := range bookmarkedPosts {
-			bookmarkedMap[bookmark.PostID] = true
-		}
-
-		// 投稿にブックマーク状態を設定
-		for i := range posts {
-			posts[i].IsBookmarked = bookmarkedMap[posts[i].ID]
-		}
-	}
+	// メディアの表示URLを設定
+	applyMediaURLs(context.Background(), posts)
 
 	return posts, hasMore, nextCursor, nil
 }
@@ -209,6 +197,13 @@ func GetPostByID(postID uint, userID *uint) (*models.Post, error) {
 		return nil, err
 	}
 
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, &post, userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
 	// いいね数・コメント数を集計
 	db.Model(&models.PostLike{}).Where("post_id = ?", post.ID).Count(&post.LikesCount)
 	db.Model(&models.Comment{}).Where("post_id = ?", post.ID).Count(&post.CommentsCount)
@@ -225,39 +220,218 @@ func GetPostByID(postID uint, userID *uint) (*models.Post, error) {
 		post.IsBookmarked = bookmarkCount > 0
 	}
 
+	// メンションを設定
+	post.Mentions = mentionsByPostID(db, []uint{post.ID})[post.ID]
+
+	// リポスト数・参照先投稿・アンケートを設定
+	applyPostRepostInfo(db, &post, userID)
+	applyPostPoll(db, &post, userID)
+
+	// メディアの表示URLを設定
+	applyMediaURLs(context.Background(), []models.Post{post})
+
 	return &post, nil
 }
 
-// CreatePost - 投稿を作成
+// CreatePost - 投稿を作成（全員に公開）
 func CreatePost(userID uint, content string) (*models.Post, error) {
+	return createPost(userID, content, models.PostVisibilityPublic, nil, PostMediaInput{}, nil)
+}
+
+// CreatePostWithMedia - メディア付きの投稿を作成（投稿とメディアは同一トランザクションで保存）
+func CreatePostWithMedia(userID uint, content string, media PostMediaInput, visibility string) (*models.Post, error) {
+	return createPost(userID, content, visibility, nil, media, nil)
+}
+
+// CreatePostWithPoll - アンケート付きの投稿を作成（投稿とアンケートは同一トランザクションで保存）
+func CreatePostWithPoll(userID uint, content string, poll PollInput, visibility string) (*models.Post, error) {
+	if err := poll.Validate(); err != nil {
+		return nil, err
+	}
+	return createPost(userID, content, visibility, nil, PostMediaInput{}, &poll)
+}
+
+// CreateQuotePost - 引用投稿を作成（全員に公開）
+func CreateQuotePost(userID, quotedPostID uint, content string) (*models.Post, error) {
+	return CreateQuotePostWithMedia(userID, quotedPostID, content, PostMediaInput{}, models.PostVisibilityPublic)
+}
+
+// CreateQuotePostWithMedia - メディア付きの引用投稿を作成
+func CreateQuotePostWithMedia(userID, quotedPostID uint, content string, media PostMediaInput, visibility string) (*models.Post, error) {
+	db := database.GetDB()
+
+	// 引用元の投稿を取得（リポストを引用した場合は元投稿を引用する）
+	quoted, err := resolveOriginalPost(db, quotedPostID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 閲覧できない鍵アカウントの投稿は引用不可
+	if canView, err := canViewPost(db, quoted, &userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
+	// フォロワー限定・メンション限定の投稿は公開範囲外に広まるため引用不可
+	if quoted.Visibility != models.PostVisibilityPublic {
+		return nil, errors.New("cannot quote non-public post")
+	}
+
+	// ブロック関係にある場合は引用不可
+	blocked, err := isBlockedBetween(db, userID, quoted.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("blocked")
+	}
+
+	post, err := createPost(userID, content, visibility, &quoted.ID, media, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 引用元の投稿者へ通知
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(context.Background(), quoted.UserID, userID, models.NotificationTypeQuote, &post.ID, nil); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	return post, nil
+}
+
+// createPost - 投稿を作成（quoteOfIDを指定すると引用投稿、mediaを指定するとメディア付き、pollを指定するとアンケート付き）
+func createPost(userID uint, content, visibility string, quoteOfID *uint, media PostMediaInput, poll *PollInput) (*models.Post, error) {
 	db := database.GetDB()
 
 	// バリデーション
 	if err := utils.ValidatePostContent(content); err != nil {
 		return nil, err
 	}
+	visibility, err := normalizePostVisibility(visibility)
+	if err != nil {
+		return nil, err
+	}
 
 	post := &models.Post{
-		UserID:  userID,
-		Content: content,
+		UserID:     userID,
+		Content:    content,
+		Visibility: visibility,
+		QuoteOfID:  quoteOfID,
+	}
+	// アンケートは関連として投稿と同時に作成される
+	if poll != nil {
+		post.Poll = poll.toModel()
 	}
 
-	if err := db.Create(post).Error; err != nil {
+	ctx := context.Background()
+	if media.IsEmpty() {
+		if err := db.Create(post).Error; err != nil {
+			return nil, err
+		}
+	} else if _, err := NewMediaService().CreatePostWithMedia(ctx, post, media); err != nil {
 		return nil, err
 	}
 
+	// ハッシュタグ・メンション処理
+	processPublishedPost(ctx, post)
+
+	// ユーザー情報・ハッシュタグ・メディアをプリロード
+	db.Preload("User").Preload("Hashtags").Preload("Media", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).First(post, post.ID)
+	post.Mentions = mentionsByPostID(db, []uint{post.ID})[post.ID]
+	applyPostRepostInfo(db, post, &userID)
+	applyPostPoll(db, post, &userID)
+	applyMediaURLs(ctx, []models.Post{*post})
+
+	// フォロワーへリアルタイム配信
+	publishPostCreated(post)
+
+	return post, nil
+}
+
+// processPublishedPost - 公開した投稿のハッシュタグ・メンションを処理（予約投稿は公開時に呼び出す）
+// 失敗はログに記録するが、投稿の公開は続行する
+func processPublishedPost(ctx context.Context, post *models.Post) {
 	// ハッシュタグ処理（Phase 2）
-	ctx := context.Background()
 	hashtagService := NewHashtagService()
-	if err := hashtagService.ProcessHashtags(ctx, post.ID, content); err != nil {
-		// ハッシュタグ処理エラーはログに記録するが、投稿作成は続行
+	if err := hashtagService.ProcessHashtags(ctx, post.ID, post.Content); err != nil {
 		fmt.Printf("Warning: failed to process hashtags: %v\n", err)
 	}
 
-	// ユーザー情報とハッシュタグをプリロード
-	db.Preload("User").Preload("Hashtags").First(post, post.ID)
+	// メンション処理・通知
+	mentionService := NewMentionService()
+	if added, _, err := mentionService.ProcessMentions(ctx, post.ID, post.Content); err != nil {
+		fmt.Printf("Warning: failed to process mentions: %v\n", err)
+	} else {
+		mentionService.NotifyMentions(ctx, post, added, nil)
+	}
+}
 
-	return post, nil
+// resolveOriginalPost - 投稿を取得（リポストの場合はリポスト元の投稿を返す）
+func resolveOriginalPost(db *gorm.DB, postID uint) (*models.Post, error) {
+	var post models.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	// 下書き・予約投稿はリポスト・引用できない
+	if !post.IsPublished() {
+		return nil, errors.New("post not found")
+	}
+
+	if post.RepostOfID == nil {
+		return &post, nil
+	}
+
+	var original models.Post
+	if err := db.First(&original, *post.RepostOfID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+	return &original, nil
+}
+
+// publishPostCreated - 新規投稿をフォロワーへ配信
+func publishPostCreated(post *models.Post) {
+	hub := realtime.GetHub()
+	if !hub.HasSubscribers() {
+		return
+	}
+
+	// メンション限定の投稿はフォロワーへ配信しない（メンションされたユーザーには通知で届く）
+	if post.Visibility == models.PostVisibilityMentioned {
+		return
+	}
+
+	db := database.GetDB()
+
+	// 投稿者（リポストの場合はリポスト元の投稿者も）をブロック・ミュートしているフォロワーには配信しない
+	query := db.Model(&models.Follow{}).Where("following_id = ?", post.UserID)
+	query = excludeViewersHiding(query, "follows.follower_id", post.UserID)
+	if post.RepostOfID != nil {
+		var original models.Post
+		if err := db.Unscoped().Select("user_id").First(&original, *post.RepostOfID).Error; err != nil {
+			fmt.Printf("Warning: failed to get repost original for stream: %v\n", err)
+			return
+		}
+		query = excludeViewersHiding(query, "follows.follower_id", original.UserID)
+	}
+
+	var followerIDs []uint
+	if err := query.Pluck("follower_id", &followerIDs).Error; err != nil {
+		fmt.Printf("Warning: failed to get followers for stream: %v\n", err)
+		return
+	}
+
+	hub.Publish(followerIDs, realtime.EventPostCreated, post)
 }
 
 // UpdatePost - 投稿を更新
@@ -282,29 +456,138 @@ func UpdatePost(postID, userID uint, content string) (*models.Post, error) {
 		return nil, errors.New("unauthorized")
 	}
 
-	post.Content = content
-	if err := db.Save(&post).Error; err != nil {
-		return nil, err
+	// リポストは本文を持たないため編集不可
+	if post.RepostOfID != nil {
+		return nil, errors.New("cannot edit repost")
+	}
+
+	// 下書き・予約投稿は履歴を残さずScheduledPostServiceで編集する
+	if !post.IsPublished() {
+		return nil, errors.New("cannot edit unpublished post")
+	}
+
+	// 編集可能期間のチェック
+	if isEditWindowExpired(post.CreatedAt, postEditWindow(), time.Now()) {
+		return nil, errors.New("edit window expired")
+	}
+
+	// 本文が変わらない場合は履歴を残さない
+	if post.Content == content {
+		db.Preload("User").Preload("Hashtags").First(&post, post.ID)
+		post.Mentions = mentionsByPostID(db, []uint{post.ID})[post.ID]
+		applyPostRepostInfo(db, &post, &userID)
+		applyPostPoll(db, &post, &userID)
+		return &post, nil
 	}
 
-	// ハッシュタグの再処理（Phase 2）
 	ctx := context.Background()
-	hashtagService := NewHashtagService()
-	// 既存のハッシュタグ関連を削除
-	if err := hashtagService.RemovePostHashtags(ctx, post.ID); err != nil {
-		fmt.Printf("Warning: failed to remove hashtags: %v\n", err)
+	now := time.Now()
+	// 履歴の保存・本文の更新・ハッシュタグの再処理を1つのトランザクションで行い、
+	// ハッシュタグが常に現在の版の本文と一致するようにする
+	err := db.Transaction(func(tx *gorm.DB) error {
+		replacedVersionAt := post.CreatedAt
+		if post.EditedAt != nil {
+			replacedVersionAt = *post.EditedAt
+		}
+		revision := models.PostRevision{
+			PostID:     post.ID,
+			Version:    post.EditCount + 1,
+			Content:    post.Content,
+			CreatedAt:  replacedVersionAt,
+			ReplacedAt: now,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		// 同時編集で履歴の版が重複しないよう、読み込んだ時点の編集回数を条件に更新
+		result := tx.Model(&models.Post{}).
+			Where("id = ? AND edit_count = ?", post.ID, post.EditCount).
+			Updates(map[string]interface{}{
+				"content":    content,
+				"edited_at":  now,
+				"edit_count": post.EditCount + 1,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("post was modified concurrently")
+		}
+
+		// ハッシュタグの再処理（Phase 2）
+		hashtagService := &HashtagService{db: tx}
+		if err := hashtagService.RemovePostHashtags(ctx, post.ID); err != nil {
+			return err
+		}
+		return hashtagService.ProcessHashtags(ctx, post.ID, content)
+	})
+	if err != nil {
+		return nil, err
 	}
-	// 新しいハッシュタグを処理
-	if err := hashtagService.ProcessHashtags(ctx, post.ID, content); err != nil {
-		fmt.Printf("Warning: failed to process hashtags: %v\n", err)
+	post.Content = content
+	post.EditedAt = &now
+	post.EditCount++
+
+	// メンションの再処理（追加されたユーザーにのみ通知）
+	mentionService := NewMentionService()
+	if added, removed, err := mentionService.ProcessMentions(ctx, post.ID, content); err != nil {
+		fmt.Printf("Warning: failed to process mentions: %v\n", err)
+	} else {
+		mentionService.NotifyMentions(ctx, &post, added, removed)
 	}
 
 	// ユーザー情報とハッシュタグをプリロード
 	db.Preload("User").Preload("Hashtags").First(&post, post.ID)
+	post.Mentions = mentionsByPostID(db, []uint{post.ID})[post.ID]
+	applyPostRepostInfo(db, &post, &userID)
+	applyPostPoll(db, &post, &userID)
 
 	return &post, nil
 }
 
+// postEditWindow - 投稿後に編集できる期間（0で無制限）
+func postEditWindow() time.Duration {
+	if config.AppConfig == nil {
+		return 0
+	}
+	return config.AppConfig.PostEditWindow
+}
+
+// isEditWindowExpired - 編集可能期間を過ぎているか
+func isEditWindowExpired(createdAt time.Time, window time.Duration, now time.Time) bool {
+	return window > 0 && now.Sub(createdAt) > window
+}
+
+// GetPostHistory - 投稿の編集履歴を取得（新しい版から順に、現在の版は含まない）
+func GetPostHistory(postID uint, userID *uint) ([]models.PostRevision, error) {
+	db := database.GetDB()
+
+	var post models.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	// 閲覧できない鍵アカウントの投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, &post, userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
+	revisions := []models.PostRevision{}
+	if err := db.Where("post_id = ?", post.ID).
+		Order("version DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
 // DeletePost - 投稿を削除（論理削除）
 func DeletePost(postID, userID uint) error {
 	db := database.GetDB()
@@ -322,16 +605,26 @@ func DeletePost(postID, userID uint) error {
 		return errors.New("unauthorized")
 	}
 
-	// 論理削除
-	if err := db.Delete(&post).Error; err != nil {
+	// 論理削除（プロフィールに固定されていれば固定も解除）
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&post).Error; err != nil {
+			return err
+		}
+		return clearPinnedPost(tx, post.ID)
+	}); err != nil {
 		return err
 	}
 
+	// 添付メディアとストレージ上のオブジェクトを削除（失敗しても投稿削除は成功させる）
+	if err := NewMediaService().DeleteMediaByPostID(context.Background(), post.ID); err != nil {
+		fmt.Printf("Warning: failed to delete media for post %d: %v\n", post.ID, err)
+	}
+
 	return nil
 }
 
 // GetUserPosts - ユーザーの投稿一覧を取得
-func GetUserPosts(username string, limit int, cursor *string) ([]models.Post, bool, string, error) {
+func GetUserPosts(username string, userID *uint, limit int, cursor *string) ([]models.Post, bool, string, error) {
 	db := database.GetDB()
 
 	// ユーザーを取得
@@ -343,31 +636,46 @@ func GetUserPosts(username string, limit int, cursor *string) ([]models.Post, bo
 		return nil, false, "", err
 	}
 
+	// 鍵アカウントの場合は承認済みフォロワーのみ閲覧可能
+	canView, err := canViewUserContent(db, &user, userID)
+	if err != nil {
+		return nil, false, "", err
+	}
+	if !canView {
+		return nil, false, "", errors.New("account is protected")
+	}
+
 	// サブクエリを使用した集計で N+1 問題を解消
-	type PostWithCounts struct {
-		models.Post
-		LikesCount    int64 `gorm:"column:likes_count"`
-		CommentsCount int64 `gorm:"column:comments_count"`
+	// （フォロワー限定・メンション限定の投稿は閲覧者が公開範囲に含まれる場合のみ）
+	userPostsQuery := func() *gorm.DB {
+		query := db.Model(&models.Post{}).
+			Select(postCountsSelect).
+			Where("posts.user_id = ?", user.ID).
+			Where(publishedPostCondition).
+			Where(availableRepostCondition).
+			Preload("User").
+			Preload("Media")
+		return excludeInvisiblePosts(query, userID)
 	}
+	query := userPostsQuery()
 
-	query := db.Model(&models.Post{}).
-		Select(`posts.*,
-			(SELECT COUNT(*) FROM post_likes WHERE post_likes.post_id = posts.id) as likes_count,
-			(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) as comments_count`).
-		Where("user_id = ?", user.ID).
-		Preload("User").
-		Preload("Media")
+	// 固定された投稿は通常の並び順から除外し、最初のページの先頭にのみ表示する
+	if user.PinnedPostID != nil {
+		query = query.Where("posts.id <> ?", *user.PinnedPostID)
+	}
 
 	// カーソルベースページネーション
+	isFirstPage := true
 	if cursor != nil && *cursor != "" {
 		cursorID, err := strconv.ParseUint(*cursor, 10, 64)
 		if err == nil {
-			query = query.Where("id < ?", cursorID)
+			query = beforePostCursor(query, cursorID)
+			isFirstPage = false
 		}
 	}
 
-	var results []PostWithCounts
-	if err := query.Order("created_at DESC").Limit(limit + 1).Find(&results).Error; err != nil {
+	var results []postWithCounts
+	if err := query.Order(postFeedOrder).Limit(limit + 1).Find(&results).Error; err != nil {
 		return nil, false, "", err
 	}
 
@@ -376,7 +684,81 @@ func GetUserPosts(username string, limit int, cursor *string) ([]models.Post, bo
 		results = results[:limit]
 	}
 
-	// PostWithCounts から models.Post に変換し、集計結果を設定
+	nextCursor := ""
+	if hasMore && len(results) > 0 {
+		nextCursor = fmt.Sprintf("%d", results[len(results)-1].ID)
+	}
+
+	// 固定された投稿を先頭に追加（カーソルには含めない）
+	hasPinned := false
+	if isFirstPage && user.PinnedPostID != nil {
+		var pinned []postWithCounts
+		if err := userPostsQuery().Where("posts.id = ?", *user.PinnedPostID).Limit(1).Find(&pinned).Error; err != nil {
+			return nil, false, "", err
+		}
+		if len(pinned) > 0 {
+			results = append(pinned, results...)
+			hasPinned = true
+		}
+	}
+
+	// postWithCounts から models.Post に変換し、集計結果を設定
+	posts := toPostsWithCounts(results)
+	if hasPinned {
+		posts[0].IsPinned = true
+	}
+
+	// ログインユーザーのいいね・ブックマーク状態を一括取得
+	applyViewerStates(db, posts, userID)
+
+	// メンション・アンケートを一括取得
+	applyMentions(db, posts)
+	applyPolls(db, posts, userID)
+
+	// リポスト元・引用元の投稿を一括取得
+	applyReferencedPosts(db, posts, userID)
+
+	// メディアの表示URLを設定
+	applyMediaURLs(context.Background(), posts)
+
+	return posts, hasMore, nextCursor, nil
+}
+
+// postCountsSelect - いいね数・コメント数をサブクエリで集計するSELECT句
+const postCountsSelect = `posts.*,
+			(SELECT COUNT(*) FROM post_likes WHERE post_likes.post_id = posts.id) as likes_count,
+			(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) as comments_count,
+			(SELECT COUNT(*) FROM posts AS reposts WHERE reposts.repost_of_id = posts.id AND reposts.deleted_at IS NULL) as reposts_count,
+			(SELECT COUNT(*) FROM posts AS quotes WHERE quotes.quote_of_id = posts.id AND quotes.deleted_at IS NULL) as quotes_count`
+
+// availableRepostCondition - 元投稿が削除されたリポストを除外するWHERE句
+const availableRepostCondition = `(posts.repost_of_id IS NULL OR EXISTS (
+			SELECT 1 FROM posts AS originals WHERE originals.id = posts.repost_of_id AND originals.deleted_at IS NULL))`
+
+// publishedPostCondition - 下書き・予約投稿を除外するWHERE句
+const publishedPostCondition = "posts.status = '" + models.PostStatusPublished + "'"
+
+// postFeedOrder - フィードの並び順（beforePostCursorと対応）
+const postFeedOrder = "posts.created_at DESC, posts.id DESC"
+
+// beforePostCursor - カーソルの投稿より後ろ（postFeedOrderの順）の投稿に絞り込む
+// 予約投稿は公開時にcreated_atを更新するため、IDの大小だけでは並び順と一致しない
+func beforePostCursor(query *gorm.DB, cursorID uint64) *gorm.DB {
+	return query.Where(`(posts.created_at, posts.id) < (
+			SELECT cursor_posts.created_at, cursor_posts.id FROM posts AS cursor_posts WHERE cursor_posts.id = ?)`, cursorID)
+}
+
+// postWithCounts - 集計結果を含むスキャン用構造体
+type postWithCounts struct {
+	models.Post
+	LikesCount    int64 `gorm:"column:likes_count"`
+	CommentsCount int64 `gorm:"column:comments_count"`
+	RepostsCount  int64 `gorm:"column:reposts_count"`
+	QuotesCount   int64 `gorm:"column:quotes_count"`
+}
+
+// toPostsWithCounts - postWithCounts から models.Post に変換し、集計結果を設定
+func toPostsWithCounts(results []postWithCounts) []models.Post {
 	posts := make([]models.Post, len(results))
 	for i := range results {
 		// 埋め込みフィールドを含めて全てコピー
@@ -384,15 +766,133 @@ func GetUserPosts(username string, limit int, cursor *string) ([]models.Post, bo
 		// 集計結果を明示的に設定
 		posts[i].LikesCount = results[i].LikesCount
 		posts[i].CommentsCount = results[i].CommentsCount
+		posts[i].RepostsCount = results[i].RepostsCount
+		posts[i].QuotesCount = results[i].QuotesCount
 		// Preloadされたリレーションもコピーされている
 		posts[i].User = results[i].Post.User
 		posts[i].Media = results[i].Post.Media
 	}
+	return posts
+}
 
-	nextCursor := ""
-	if hasMore && len(posts) > 0 {
-		nextCursor = fmt.Sprintf("%d", posts[len(posts)-1].ID)
+// applyViewerStates - ログインユーザーのいいね・ブックマーク状態を一括設定（N+1解消）
+func applyViewerStates(db *gorm.DB, posts []models.Post, userID *uint) {
+	if userID == nil || len(posts) == 0 {
+		return
 	}
 
-	return posts, hasMore, nextCursor, nil
+	postIDs := make([]uint, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	// IN句で一括取得
+	var likedPosts []models.PostLike
+	db.Where("post_id IN ? AND user_id = ?", postIDs, *userID).Find(&likedPosts)
+
+	// マップ化して高速検索
+	likedMap := make(map[uint]bool)
+	for _, like := range likedPosts {
+		likedMap[like.PostID] = true
+	}
+
+	// ブックマーク状態を一括取得
+	var bookmarkedPosts []models.Bookmark
+	db.Where("post_id IN ? AND user_id = ?", postIDs, *userID).Find(&bookmarkedPosts)
+
+	bookmarkedMap := make(map[uint]bool)
+	for _, bookmark := range bookmarkedPosts {
+		bookmarkedMap[bookmark.PostID] = true
+	}
+
+	// リポスト状態を一括取得
+	var repostedIDs []uint
+	db.Model(&models.Post{}).Where("repost_of_id IN ? AND user_id = ?", postIDs, *userID).Pluck("repost_of_id", &repostedIDs)
+
+	repostedMap := make(map[uint]bool)
+	for _, id := range repostedIDs {
+		repostedMap[id] = true
+	}
+
+	// 投稿にいいね・ブックマーク・リポスト状態を設定
+	for i := range posts {
+		posts[i].IsLiked = likedMap[posts[i].ID]
+		posts[i].IsBookmarked = bookmarkedMap[posts[i].ID]
+		posts[i].IsReposted = repostedMap[posts[i].ID]
+	}
+}
+
+// applyReferencedPosts - リポスト元・引用元の投稿を一括設定（元投稿が削除済みの場合はスタブ）
+func applyReferencedPosts(db *gorm.DB, posts []models.Post, userID *uint) {
+	idSet := make(map[uint]bool)
+	var ids []uint
+	for _, post := range posts {
+		for _, id := range []*uint{post.RepostOfID, post.QuoteOfID} {
+			if id != nil && !idSet[*id] {
+				idSet[*id] = true
+				ids = append(ids, *id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// 削除済み・閲覧できない鍵アカウント・ブロック/ミュートしているユーザーの投稿は取得されない（スタブになる）
+	var results []postWithCounts
+	query := db.Model(&models.Post{}).
+		Select(postCountsSelect).
+		Where("posts.id IN ?", ids).
+		Preload("User").
+		Preload("Media")
+	query = excludeProtectedAuthors(query, "posts.user_id", userID)
+	query = excludeHiddenUsers(query, "posts.user_id", userID)
+	if err := query.Find(&results).Error; err != nil {
+		fmt.Printf("Warning: failed to load referenced posts: %v\n", err)
+		return
+	}
+
+	originals := toPostsWithCounts(results)
+	applyViewerStates(db, originals, userID)
+	applyMentions(db, originals)
+	applyPolls(db, originals, userID)
+	applyMediaURLs(db.Statement.Context, originals)
+
+	originalMap := make(map[uint]models.Post, len(originals))
+	for _, original := range originals {
+		originalMap[original.ID] = original
+	}
+
+	referenced := func(id uint) *models.Post {
+		if original, ok := originalMap[id]; ok {
+			return &original
+		}
+		return &models.Post{ID: id, Unavailable: true}
+	}
+
+	for i := range posts {
+		if posts[i].RepostOfID != nil {
+			posts[i].RepostOf = referenced(*posts[i].RepostOfID)
+		}
+		if posts[i].QuoteOfID != nil {
+			posts[i].QuotedPost = referenced(*posts[i].QuoteOfID)
+		}
+	}
+}
+
+// applyPostRepostInfo - 単一投稿のリポスト数・リポスト状態・参照先投稿を設定
+func applyPostRepostInfo(db *gorm.DB, post *models.Post, userID *uint) {
+	db.Model(&models.Post{}).Where("repost_of_id = ?", post.ID).Count(&post.RepostsCount)
+	db.Model(&models.Post{}).Where("quote_of_id = ?", post.ID).Count(&post.QuotesCount)
+
+	if userID != nil {
+		var count int64
+		db.Model(&models.Post{}).Where("repost_of_id = ? AND user_id = ?", post.ID, *userID).Count(&count)
+		post.IsReposted = count > 0
+	}
+
+	posts := []models.Post{*post}
+	applyReferencedPosts(db, posts, userID)
+	post.RepostOf = posts[0].RepostOf
+	post.QuotedPost = posts[0].QuotedPost
 }
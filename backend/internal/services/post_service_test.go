@@ -183,7 +183,7 @@ func TestGetUserPosts(t *testing.T) {
 		testutil.CreateTestPost(t, db, user.ID, "User's post 2")
 		testutil.CreateTestPost(t, db, otherUser.ID, "Other user's post")
 
-		posts, hasMore, nextCursor, err := GetUserPosts("testuser", 10, nil)
+		posts, hasMore, nextCursor, err := GetUserPosts("testuser", nil, 10, nil)
 
 		testutil.AssertNoError(t, err, "GetUserPosts should not return error")
 		testutil.AssertEqual(t, 2, len(posts), "Should return 2 posts")
@@ -194,7 +194,7 @@ func TestGetUserPosts(t *testing.T) {
 	t.Run("Error - Get posts for non-existent user", func(t *testing.T) {
 		testutil.CleanupTestDB(t, db)
 
-		_, _, _, err := GetUserPosts("nonexistentuser", 10, nil)
+		_, _, _, err := GetUserPosts("nonexistentuser", nil, 10, nil)
 
 		testutil.AssertError(t, err, "Should return error for non-existent user")
 	})
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// postVisibilityCondition - 閲覧者が投稿の公開範囲に含まれるかのWHERE句
+// フィードの絞り込み（excludeInvisiblePosts）と単一投稿の閲覧可否（isPostVisible）の両方がこの条件を使う
+// @viewer は閲覧者のユーザーID（未ログインの場合は0で、全員に公開された投稿のみ一致する）
+const postVisibilityCondition = `(posts.visibility = '` + models.PostVisibilityPublic + `'
+			OR posts.user_id = @viewer
+			OR EXISTS (SELECT 1 FROM post_mentions WHERE post_mentions.post_id = posts.id AND post_mentions.user_id = @viewer)
+			OR (posts.visibility = '` + models.PostVisibilityFollowers + `'
+				AND EXISTS (SELECT 1 FROM follows WHERE follows.follower_id = @viewer AND follows.following_id = posts.user_id)))`
+
+// excludeInvisiblePosts - 閲覧者が公開範囲に含まれない投稿を除外する
+func excludeInvisiblePosts(query *gorm.DB, viewerID *uint) *gorm.DB {
+	var viewer uint
+	if viewerID != nil {
+		viewer = *viewerID
+	}
+	return query.Where(postVisibilityCondition, map[string]interface{}{"viewer": viewer})
+}
+
+// isPostVisible - 閲覧者が投稿の公開範囲に含まれるかチェック
+func isPostVisible(db *gorm.DB, postID uint, viewerID *uint) (bool, error) {
+	var count int64
+	if err := excludeInvisiblePosts(db.Model(&models.Post{}).Where("posts.id = ?", postID), viewerID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// normalizePostVisibility - 公開範囲を検証する（未指定の場合は全員に公開）
+func normalizePostVisibility(visibility string) (string, error) {
+	switch visibility {
+	case "":
+		return models.PostVisibilityPublic, nil
+	case models.PostVisibilityPublic, models.PostVisibilityFollowers, models.PostVisibilityMentioned:
+		return visibility, nil
+	}
+	return "", errors.New("invalid visibility")
+}
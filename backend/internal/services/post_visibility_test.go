@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestNormalizePostVisibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"Empty defaults to public", "", models.PostVisibilityPublic, false},
+		{"Public", models.PostVisibilityPublic, models.PostVisibilityPublic, false},
+		{"Followers", models.PostVisibilityFollowers, models.PostVisibilityFollowers, false},
+		{"Mentioned", models.PostVisibilityMentioned, models.PostVisibilityMentioned, false},
+		{"Unknown", "private", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizePostVisibility(tt.input)
+			if tt.wantErr {
+				assert.EqualError(t, err, "invalid visibility")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPostVisibility(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+	follower := testutil.CreateTestUser(t, db, "follower@example.com", "follower", "password123")
+	mentioned := testutil.CreateTestUser(t, db, "mentioned@example.com", "mentioned", "password123")
+	stranger := testutil.CreateTestUser(t, db, "stranger@example.com", "stranger", "password123")
+	testutil.CreateTestFollow(t, db, follower.ID, author.ID)
+
+	// 公開範囲ごとに @mentioned を含む投稿を作成
+	posts := make(map[string]*models.Post)
+	for _, visibility := range []string{models.PostVisibilityPublic, models.PostVisibilityFollowers, models.PostVisibilityMentioned} {
+		post, err := CreatePostWithMedia(author.ID, "@mentioned "+visibility+" #visibility", PostMediaInput{}, visibility)
+		require.NoError(t, err)
+		assert.Equal(t, visibility, post.Visibility)
+		posts[visibility] = post
+	}
+
+	viewers := map[string]*uint{
+		"anonymous": nil,
+		"author":    &author.ID,
+		"follower":  &follower.ID,
+		"mentioned": &mentioned.ID,
+		"stranger":  &stranger.ID,
+	}
+
+	tests := []struct {
+		visibility string
+		viewer     string
+		want       bool
+	}{
+		{models.PostVisibilityPublic, "anonymous", true},
+		{models.PostVisibilityPublic, "author", true},
+		{models.PostVisibilityPublic, "follower", true},
+		{models.PostVisibilityPublic, "mentioned", true},
+		{models.PostVisibilityPublic, "stranger", true},
+
+		{models.PostVisibilityFollowers, "anonymous", false},
+		{models.PostVisibilityFollowers, "author", true},
+		{models.PostVisibilityFollowers, "follower", true},
+		{models.PostVisibilityFollowers, "mentioned", true},
+		{models.PostVisibilityFollowers, "stranger", false},
+
+		{models.PostVisibilityMentioned, "anonymous", false},
+		{models.PostVisibilityMentioned, "author", true},
+		{models.PostVisibilityMentioned, "follower", false},
+		{models.PostVisibilityMentioned, "mentioned", true},
+		{models.PostVisibilityMentioned, "stranger", false},
+	}
+
+	// フィードに含まれる投稿ID
+	containsPost := func(list []models.Post, postID uint) bool {
+		for _, post := range list {
+			if post.ID == postID {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.visibility+" post viewed by "+tt.viewer, func(t *testing.T) {
+			post := posts[tt.visibility]
+			viewerID := viewers[tt.viewer]
+
+			visible, err := isPostVisible(db, post.ID, viewerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, visible, "isPostVisible")
+
+			_, err = GetPostByID(post.ID, viewerID)
+			if tt.want {
+				assert.NoError(t, err, "GetPostByID")
+			} else {
+				assert.EqualError(t, err, "post not found", "GetPostByID")
+			}
+
+			timeline, _, _, err := GetTimeline(viewerID, "all", 20, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, containsPost(timeline, post.ID), "GetTimeline")
+
+			userPosts, _, _, err := GetUserPosts(author.Username, viewerID, 20, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, containsPost(userPosts, post.ID), "GetUserPosts")
+
+			var currentUserID uint
+			if viewerID != nil {
+				currentUserID = *viewerID
+			}
+			tagged, _, _, err := NewHashtagService().GetPostsByHashtag(context.Background(), "visibility", currentUserID, 20, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, containsPost(tagged, post.ID), "GetPostsByHashtag")
+
+			_, _, _, err = GetCommentsByPostID(post.ID, viewerID, 20, nil)
+			if tt.want {
+				assert.NoError(t, err, "GetCommentsByPostID")
+			} else {
+				assert.Error(t, err, "GetCommentsByPostID")
+			}
+
+			_, _, _, err = GetLikesByPostID(post.ID, viewerID, 20, nil)
+			if tt.want {
+				assert.NoError(t, err, "GetLikesByPostID")
+			} else {
+				assert.Error(t, err, "GetLikesByPostID")
+			}
+		})
+	}
+
+	t.Run("Error - Strangers cannot like, comment or bookmark restricted posts", func(t *testing.T) {
+		post := posts[models.PostVisibilityFollowers]
+
+		assert.EqualError(t, LikePost(stranger.ID, post.ID), "post not found")
+		_, err := CreateComment(stranger.ID, post.ID, "コメント")
+		assert.EqualError(t, err, "post not found")
+		assert.EqualError(t, NewBookmarkService().BookmarkPost(context.Background(), stranger.ID, post.ID), "post not found")
+	})
+
+	t.Run("Success - Bookmarks hide posts that are no longer visible", func(t *testing.T) {
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		testutil.CreateTestFollow(t, db, other.ID, author.ID)
+		post := posts[models.PostVisibilityFollowers]
+
+		ctx := context.Background()
+		bookmarkService := NewBookmarkService()
+		require.NoError(t, bookmarkService.BookmarkPost(ctx, other.ID, post.ID))
+
+		bookmarks, _, _, err := bookmarkService.GetBookmarks(ctx, other.ID, nil, 20, nil)
+		require.NoError(t, err)
+		assert.True(t, containsPost(bookmarks, post.ID))
+
+		// フォロー解除後は公開範囲外になる
+		require.NoError(t, db.Where("follower_id = ? AND following_id = ?", other.ID, author.ID).Delete(&models.Follow{}).Error)
+		bookmarks, _, _, err = bookmarkService.GetBookmarks(ctx, other.ID, nil, 20, nil)
+		require.NoError(t, err)
+		assert.False(t, containsPost(bookmarks, post.ID))
+	})
+
+	t.Run("Error - Restricted posts cannot be reposted or quoted", func(t *testing.T) {
+		ctx := context.Background()
+		for _, visibility := range []string{models.PostVisibilityFollowers, models.PostVisibilityMentioned} {
+			post := posts[visibility]
+
+			_, err := NewRepostService().Repost(ctx, author.ID, post.ID)
+			assert.EqualError(t, err, "cannot repost non-public post")
+			_, err = CreateQuotePost(mentioned.ID, post.ID, "引用")
+			assert.EqualError(t, err, "cannot quote non-public post")
+		}
+
+		_, err := NewRepostService().Repost(ctx, stranger.ID, posts[models.PostVisibilityMentioned].ID)
+		assert.EqualError(t, err, "post not found", "閲覧できない投稿の存在を明かさないべき")
+	})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// RepostService リポストサービス
+type RepostService struct {
+	db *gorm.DB
+}
+
+// NewRepostService RepostServiceのコンストラクタ
+func NewRepostService() *RepostService {
+	return &RepostService{
+		db: database.GetDB(),
+	}
+}
+
+// Repost 投稿をリポスト（リポストをリポストした場合は元投稿をリポストする）
+// @param ctx コンテキスト
+// @param userID リポストするユーザーID
+// @param postID リポスト対象の投稿ID
+// @return 作成されたリポスト, error
+func (s *RepostService) Repost(ctx context.Context, userID, postID uint) (*models.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := s.db.WithContext(ctx)
+
+	original, err := resolveOriginalPost(db, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 閲覧できない投稿は存在しないものとして扱う
+	if canView, err := canViewPost(db, original, &userID); err != nil {
+		return nil, err
+	} else if !canView {
+		return nil, errors.New("post not found")
+	}
+
+	// フォロワー限定・メンション限定の投稿は本人を含めリポスト不可
+	if original.Visibility != models.PostVisibilityPublic {
+		return nil, errors.New("cannot repost non-public post")
+	}
+
+	// 鍵アカウントの投稿は本人以外リポスト不可
+	var owner models.User
+	if err := db.Select("id", "is_protected").First(&owner, original.UserID).Error; err != nil {
+		return nil, err
+	}
+	if owner.IsProtected && owner.ID != userID {
+		return nil, errors.New("cannot repost protected post")
+	}
+
+	// ブロック関係にある場合はリポスト不可
+	blocked, err := isBlockedBetween(db, userID, original.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("blocked")
+	}
+
+	// 既にリポスト済みかチェック
+	reposted, err := hasReposted(db, userID, original.ID)
+	if err != nil {
+		return nil, err
+	}
+	if reposted {
+		return nil, errors.New("already reposted")
+	}
+
+	repost := &models.Post{
+		UserID:     userID,
+		RepostOfID: &original.ID,
+	}
+	if err := db.Create(repost).Error; err != nil {
+		// 同時にリポストされた場合は一意インデックスで弾かれる
+		if reposted, findErr := hasReposted(db, userID, original.ID); findErr == nil && reposted {
+			return nil, errors.New("already reposted")
+		}
+		return nil, err
+	}
+
+	// 元投稿の投稿者へ通知（通知の失敗はリポストを失敗させない）
+	notificationService := NewNotificationService()
+	if err := notificationService.Notify(ctx, original.UserID, userID, models.NotificationTypeRepost, &original.ID, nil); err != nil {
+		fmt.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	// ユーザー情報・リポスト元をプリロード
+	db.Preload("User").First(repost, repost.ID)
+	applyPostRepostInfo(db, repost, &userID)
+
+	// フォロワーへリアルタイム配信
+	publishPostCreated(repost)
+
+	return repost, nil
+}
+
+// Unrepost リポストを取り消す（Repostと同様に、リポストのIDを指定した場合は元投稿のリポストを取り消す）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID リポスト元の投稿ID、またはリポストの投稿ID
+// @return error
+func (s *RepostService) Unrepost(ctx context.Context, userID, postID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := s.db.WithContext(ctx)
+
+	// 元投稿が削除済みでも取り消せるよう、削除済みの投稿も含めて解決する
+	var target models.Post
+	if err := db.Unscoped().Select("id", "repost_of_id").First(&target, postID).Error; err == nil && target.RepostOfID != nil {
+		postID = *target.RepostOfID
+	}
+
+	// リポストは本文を持たないため物理削除する（再リポストを可能にする）
+	result := db.Unscoped().
+		Where("user_id = ? AND repost_of_id = ?", userID, postID).
+		Delete(&models.Post{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("repost not found")
+	}
+
+	// 未読の通知を取り消す
+	notificationService := NewNotificationService()
+	var original models.Post
+	if err := db.Unscoped().First(&original, postID).Error; err == nil {
+		if err := notificationService.RemoveNotification(ctx, original.UserID, userID, models.NotificationTypeRepost, &original.ID); err != nil {
+			fmt.Printf("Warning: failed to remove notification: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// hasReposted - ユーザーが投稿をリポスト済みか
+func hasReposted(db *gorm.DB, userID, postID uint) (bool, error) {
+	var count int64
+	if err := db.Model(&models.Post{}).
+		Where("user_id = ? AND repost_of_id = ?", userID, postID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestRepostService_Repost(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewRepostService()
+	ctx := context.Background()
+
+	t.Run("Success - Repost appears in follower timeline with attribution", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		follower := testutil.CreateTestUser(t, db, "follower@example.com", "follower", "password123")
+		testutil.CreateTestFollow(t, db, follower.ID, reposter.ID)
+
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		repost, err := service.Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+		require.NotNil(t, repost.RepostOfID)
+		assert.Equal(t, original.ID, *repost.RepostOfID)
+
+		posts, _, _, err := GetTimeline(&follower.ID, "following", 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, reposter.ID, posts[0].User.ID, "リポストしたユーザーが表示されるべき")
+		require.NotNil(t, posts[0].RepostOf)
+		assert.Equal(t, original.ID, posts[0].RepostOf.ID)
+		assert.Equal(t, "元の投稿", posts[0].RepostOf.Content)
+		assert.Equal(t, int64(1), posts[0].RepostOf.RepostsCount)
+	})
+
+	t.Run("Success - Repost count and viewer state", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		_, err := service.Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		post, err := GetPostByID(original.ID, &reposter.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), post.RepostsCount)
+		assert.True(t, post.IsReposted)
+	})
+
+	t.Run("Success - Reposting a repost targets the original", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		user1 := testutil.CreateTestUser(t, db, "user1@example.com", "user1", "password123")
+		user2 := testutil.CreateTestUser(t, db, "user2@example.com", "user2", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		repost, err := service.Repost(ctx, user1.ID, original.ID)
+		require.NoError(t, err)
+
+		repost2, err := service.Repost(ctx, user2.ID, repost.ID)
+		require.NoError(t, err)
+		assert.Equal(t, original.ID, *repost2.RepostOfID)
+	})
+
+	t.Run("Success - Repost notifies original author", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		_, err := service.Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		var count int64
+		db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", author.ID, models.NotificationTypeRepost).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Error - Already reposted", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		_, err := service.Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		_, err = service.Repost(ctx, reposter.ID, original.ID)
+		assert.EqualError(t, err, "already reposted")
+	})
+
+	t.Run("Error - Concurrent reposts create a single repost", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		const workers = 8
+		errs := make(chan error, workers)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.Repost(ctx, reposter.ID, original.ID)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.EqualError(t, err, "already reposted")
+		}
+		assert.Equal(t, 1, succeeded)
+
+		var count int64
+		db.Model(&models.Post{}).Where("repost_of_id = ?", original.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Error - Post not found", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+
+		_, err := service.Repost(ctx, reposter.ID, 99999)
+		assert.EqualError(t, err, "post not found")
+	})
+}
+
+func TestRepostService_Unrepost(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewRepostService()
+	ctx := context.Background()
+
+	t.Run("Success - Unrepost and repost again", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		_, err := service.Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		err = service.Unrepost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		post, err := GetPostByID(original.ID, &reposter.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), post.RepostsCount)
+		assert.False(t, post.IsReposted)
+
+		_, err = service.Repost(ctx, reposter.ID, original.ID)
+		assert.NoError(t, err, "取り消し後は再度リポストできるべき")
+	})
+
+	t.Run("Success - Unrepost by the repost's own ID", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		repost, err := service.Repost(ctx, reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		// タイムラインのリポストカードから取り消す場合はリポスト自身のIDが渡される
+		require.NoError(t, service.Unrepost(ctx, reposter.ID, repost.ID))
+
+		var count int64
+		db.Model(&models.Post{}).Where("repost_of_id = ?", original.ID).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Error - Repost not found", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		err := service.Unrepost(ctx, author.ID, original.ID)
+		assert.EqualError(t, err, "repost not found")
+	})
+}
+
+func TestCreateQuotePost(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	t.Run("Success - Quote embeds original and counts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		quoter := testutil.CreateTestUser(t, db, "quoter@example.com", "quoter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		quote, err := CreateQuotePost(quoter.ID, original.ID, "これは面白い")
+		require.NoError(t, err)
+		require.NotNil(t, quote.QuotedPost)
+		assert.Equal(t, original.ID, quote.QuotedPost.ID)
+		assert.False(t, quote.QuotedPost.Unavailable)
+
+		post, err := GetPostByID(original.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), post.QuotesCount)
+	})
+
+	t.Run("Success - Deleted original shows unavailable stub", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		quoter := testutil.CreateTestUser(t, db, "quoter@example.com", "quoter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		quote, err := CreateQuotePost(quoter.ID, original.ID, "これは面白い")
+		require.NoError(t, err)
+		_, err = NewRepostService().Repost(context.Background(), quoter.ID, original.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, DeletePost(original.ID, author.ID))
+
+		post, err := GetPostByID(quote.ID, nil)
+		require.NoError(t, err)
+		require.NotNil(t, post.QuotedPost)
+		assert.True(t, post.QuotedPost.Unavailable)
+		assert.Empty(t, post.QuotedPost.Content)
+
+		// 元投稿が削除されたリポストはタイムラインに表示しない
+		posts, _, _, err := GetUserPosts(quoter.Username, nil, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, quote.ID, posts[0].ID)
+	})
+
+	t.Run("Error - Quoted post not found", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		quoter := testutil.CreateTestUser(t, db, "quoter@example.com", "quoter", "password123")
+
+		_, err := CreateQuotePost(quoter.ID, 99999, "引用")
+		assert.EqualError(t, err, "post not found")
+	})
+
+	t.Run("Error - Repost cannot be edited", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		author := testutil.CreateTestUser(t, db, "author@example.com", "author", "password123")
+		reposter := testutil.CreateTestUser(t, db, "reposter@example.com", "reposter", "password123")
+		original := testutil.CreateTestPost(t, db, author.ID, "元の投稿")
+
+		repost, err := NewRepostService().Repost(context.Background(), reposter.ID, original.ID)
+		require.NoError(t, err)
+
+		_, err = UpdatePost(repost.ID, reposter.ID, "編集")
+		assert.EqualError(t, err, "cannot edit repost")
+	})
+}
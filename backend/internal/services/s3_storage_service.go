@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/yourusername/sns-backend/internal/config"
+)
+
+// S3StorageService S3互換ストレージ（AWS S3 / MinIO）
+type S3StorageService struct {
+	client        *minio.Client
+	bucket        string
+	publicBaseURL string
+}
+
+// NewS3StorageService S3StorageServiceのコンストラクタ（バケットが存在しない場合は作成）
+func NewS3StorageService(cfg *config.Config) (*S3StorageService, error) {
+	if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+		return nil, fmt.Errorf("S3_ENDPOINT and S3_BUCKET must be configured")
+	}
+
+	client, err := minio.New(cfg.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.S3AccessKeyID, cfg.S3SecretAccessKey, ""),
+		Secure: cfg.S3UseSSL,
+		Region: cfg.S3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, cfg.S3Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket: %v", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.S3Bucket, minio.MakeBucketOptions{Region: cfg.S3Region}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %v", err)
+		}
+	}
+
+	return &S3StorageService{
+		client:        client,
+		bucket:        cfg.S3Bucket,
+		publicBaseURL: strings.TrimRight(cfg.S3PublicBaseURL, "/"),
+	}, nil
+}
+
+// UploadFile S3互換ストレージにファイルをアップロード
+func (s *S3StorageService) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
+	objectPath := newObjectPath(fileHeader.Filename)
+
+	_, err := s.client.PutObject(ctx, s.bucket, objectPath, file, fileHeader.Size, minio.PutObjectOptions{
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		UserMetadata: map[string]string{
+			"uploaded-at":   time.Now().Format(time.RFC3339),
+			"original-name": url.QueryEscape(fileHeader.Filename),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %v", err)
+	}
+
+	return objectPath, nil
+}
+
+// SignedURL 署名付きURLを生成（公開URLが設定されている場合はそのまま返す）
+func (s *S3StorageService) SignedURL(ctx context.Context, objectPath string, expiresAt time.Time) (string, error) {
+	if s.publicBaseURL != "" {
+		return s.publicBaseURL + "/" + objectPath, nil
+	}
+
+	signedURL, err := s.client.PresignedGetObject(ctx, s.bucket, objectPath, time.Until(expiresAt), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signed URL: %v", err)
+	}
+
+	return signedURL.String(), nil
+}
+
+// DeleteFile S3互換ストレージからファイルを削除（存在しないオブジェクトの削除はS3側でも成功扱い）
+func (s *S3StorageService) DeleteFile(ctx context.Context, objectPath string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, objectPath, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
+
+	return nil
+}
+
+// ListObjects S3互換ストレージ上のオブジェクトを列挙
+func (s *S3StorageService) ListObjects(ctx context.Context, prefix string) ([]StorageObject, error) {
+	var objects []StorageObject
+
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %v", object.Err)
+		}
+		objects = append(objects, StorageObject{
+			Path:      object.Key,
+			UpdatedAt: object.LastModified,
+		})
+	}
+
+	return objects, nil
+}
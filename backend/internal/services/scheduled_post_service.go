@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// unpublishedPostStatuses 下書き・予約投稿の状態
+var unpublishedPostStatuses = []string{models.PostStatusDraft, models.PostStatusScheduled}
+
+// ScheduledPostService 下書き・予約投稿サービス
+// 下書き・予約投稿は公開されるまでフィードに表示せず、ハッシュタグ・メンションも公開時に処理する
+type ScheduledPostService struct {
+	db *gorm.DB
+}
+
+// NewScheduledPostService ScheduledPostServiceのコンストラクタ
+func NewScheduledPostService() *ScheduledPostService {
+	return &ScheduledPostService{
+		db: database.GetDB(),
+	}
+}
+
+// StartPostScheduler 予約投稿の定期公開を開始（intervalが0以下の場合は何もしない）
+func StartPostScheduler(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	service := NewScheduledPostService()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				published, err := service.PublishDue(ctx)
+				if err != nil {
+					fmt.Printf("Warning: post scheduler failed: %v\n", err)
+					continue
+				}
+				if published > 0 {
+					fmt.Printf("Post scheduler published %d scheduled posts\n", published)
+				}
+			}
+		}
+	}()
+}
+
+// CreateDraft 下書き・予約投稿を作成
+// @param ctx コンテキスト
+// @param userID 投稿者のユーザーID
+// @param content 投稿内容
+// @param publishAt 公開日時（nilの場合は下書き）
+// @param media 添付メディア
+// @return 作成された投稿, error
+func (s *ScheduledPostService) CreateDraft(ctx context.Context, userID uint, content string, publishAt *time.Time, media PostMediaInput) (*models.Post, error) {
+	if err := utils.ValidatePostContent(content); err != nil {
+		return nil, err
+	}
+	if err := validatePublishAt(publishAt); err != nil {
+		return nil, err
+	}
+
+	post := &models.Post{
+		UserID:    userID,
+		Content:   content,
+		Status:    draftStatus(publishAt),
+		PublishAt: publishAt,
+	}
+
+	if media.IsEmpty() {
+		if err := s.db.WithContext(ctx).Create(post).Error; err != nil {
+			return nil, err
+		}
+	} else if _, err := NewMediaService().CreatePostWithMedia(ctx, post, media); err != nil {
+		return nil, err
+	}
+
+	return s.GetDraft(ctx, userID, post.ID)
+}
+
+// ListDrafts 自分の下書き・予約投稿の一覧を取得（予約投稿を公開日時の早い順、続けて下書きを新しい順）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @return 投稿リスト, error
+func (s *ScheduledPostService) ListDrafts(ctx context.Context, userID uint) ([]models.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	posts := []models.Post{}
+	if err := s.draftQuery(ctx, userID).
+		Order("publish_at ASC NULLS LAST").
+		Order("created_at DESC").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	applyMediaURLs(ctx, posts)
+	return posts, nil
+}
+
+// GetDraft 自分の下書き・予約投稿を取得
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID 投稿ID
+// @return 投稿, error
+func (s *ScheduledPostService) GetDraft(ctx context.Context, userID, postID uint) (*models.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var post models.Post
+	if err := s.draftQuery(ctx, userID).First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	applyMediaURLs(ctx, []models.Post{post})
+	return &post, nil
+}
+
+// UpdateDraft 下書き・予約投稿の本文と公開日時を更新（publishAtをnilにすると下書きに戻す）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID 投稿ID
+// @param content 投稿内容
+// @param publishAt 公開日時
+// @return 更新された投稿, error
+func (s *ScheduledPostService) UpdateDraft(ctx context.Context, userID, postID uint, content string, publishAt *time.Time) (*models.Post, error) {
+	if err := utils.ValidatePostContent(content); err != nil {
+		return nil, err
+	}
+	if err := validatePublishAt(publishAt); err != nil {
+		return nil, err
+	}
+
+	// 公開処理と競合した場合は公開済みとして扱う
+	result := s.db.WithContext(ctx).Model(&models.Post{}).
+		Where("id = ? AND user_id = ? AND status IN ?", postID, userID, unpublishedPostStatuses).
+		Updates(map[string]interface{}{
+			"content":    content,
+			"status":     draftStatus(publishAt),
+			"publish_at": publishAt,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("post not found")
+	}
+
+	return s.GetDraft(ctx, userID, postID)
+}
+
+// CancelDraft 下書き・予約投稿を取り消す（投稿と添付メディアを削除）
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID 投稿ID
+// @return error
+func (s *ScheduledPostService) CancelDraft(ctx context.Context, userID, postID uint) error {
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ? AND status IN ?", postID, userID, unpublishedPostStatuses).
+		Delete(&models.Post{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("post not found")
+	}
+
+	if err := NewMediaService().DeleteMediaByPostID(ctx, postID); err != nil {
+		fmt.Printf("Warning: failed to delete media for post %d: %v\n", postID, err)
+	}
+	return nil
+}
+
+// PublishDraft 下書き・予約投稿をすぐに公開
+// @param ctx コンテキスト
+// @param userID ユーザーID
+// @param postID 投稿ID
+// @return 公開された投稿, error
+func (s *ScheduledPostService) PublishDraft(ctx context.Context, userID, postID uint) (*models.Post, error) {
+	post, err := s.GetDraft(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	published, err := s.publish(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if !published {
+		return nil, errors.New("post not found")
+	}
+
+	return GetPostByID(post.ID, &userID)
+}
+
+// PublishDue 公開日時を過ぎた予約投稿を公開
+// @param ctx コンテキスト
+// @return 公開した件数, error
+func (s *ScheduledPostService) PublishDue(ctx context.Context) (int, error) {
+	var posts []models.Post
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND publish_at <= ?", models.PostStatusScheduled, time.Now()).
+		Order("publish_at ASC").
+		Find(&posts).Error; err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range posts {
+		published, err := s.publish(ctx, &posts[i])
+		if err != nil {
+			fmt.Printf("Warning: failed to publish scheduled post %d: %v\n", posts[i].ID, err)
+			continue
+		}
+		if published {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// publish 投稿を公開済みにし、ハッシュタグ・メンションの処理とフォロワーへの配信を行う
+// タイムラインで公開時点の投稿として並ぶよう、created_atを公開日時に更新する
+// 編集・取り消し・他の公開処理と競合した場合はfalseを返す
+func (s *ScheduledPostService) publish(ctx context.Context, post *models.Post) (bool, error) {
+	now := time.Now()
+	result := s.db.WithContext(ctx).Model(&models.Post{}).
+		Where("id = ? AND status = ?", post.ID, post.Status).
+		Updates(map[string]interface{}{
+			"status":     models.PostStatusPublished,
+			"publish_at": nil,
+			"created_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	post.Status = models.PostStatusPublished
+	post.PublishAt = nil
+	post.CreatedAt = now
+
+	// ハッシュタグ・メンション処理
+	processPublishedPost(ctx, post)
+
+	// フォロワーへリアルタイム配信
+	s.db.WithContext(ctx).Preload("User").Preload("Media", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).First(post, post.ID)
+	publishPostCreated(post)
+
+	return true, nil
+}
+
+// draftQuery 自分の下書き・予約投稿を取得するクエリ
+func (s *ScheduledPostService) draftQuery(ctx context.Context, userID uint) *gorm.DB {
+	return s.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, unpublishedPostStatuses).
+		Preload("User").
+		Preload("Media", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC")
+		})
+}
+
+// validatePublishAt 公開日時が未来かチェック（nilの場合は下書きなのでチェックしない）
+func validatePublishAt(publishAt *time.Time) error {
+	if publishAt != nil && !publishAt.After(time.Now()) {
+		return errors.New("publish_at must be in the future")
+	}
+	return nil
+}
+
+// draftStatus 公開日時の有無から下書き・予約投稿の状態を決める
+func draftStatus(publishAt *time.Time) string {
+	if publishAt == nil {
+		return models.PostStatusDraft
+	}
+	return models.PostStatusScheduled
+}
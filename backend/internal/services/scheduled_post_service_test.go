@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestScheduledPostService(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+	service := &ScheduledPostService{db: db}
+	future := time.Now().Add(time.Hour)
+
+	t.Run("Success - Drafts and scheduled posts stay out of feeds", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		_, err := service.CreateDraft(ctx, user.ID, "下書き #golang", nil, PostMediaInput{})
+		require.NoError(t, err)
+		scheduled, err := service.CreateDraft(ctx, user.ID, "予約投稿 #golang", &future, PostMediaInput{})
+		require.NoError(t, err)
+		assert.Equal(t, models.PostStatusScheduled, scheduled.Status)
+
+		timeline, _, _, err := GetTimeline(&other.ID, "all", 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, timeline)
+
+		userPosts, _, _, err := GetUserPosts(user.Username, &user.ID, 20, nil)
+		require.NoError(t, err)
+		assert.Empty(t, userPosts, "本人のプロフィールにも表示しないべき")
+
+		tagged, _, _, err := NewHashtagService().GetPostsByHashtag(ctx, "golang", other.ID, 20, 0)
+		require.NoError(t, err)
+		assert.Empty(t, tagged)
+
+		_, err = GetPostByID(scheduled.ID, &other.ID)
+		assert.EqualError(t, err, "post not found")
+		_, err = GetPostByID(scheduled.ID, &user.ID)
+		assert.NoError(t, err, "本人は閲覧できるべき")
+
+		drafts, err := service.ListDrafts(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, drafts, 2)
+		assert.Equal(t, scheduled.ID, drafts[0].ID, "予約投稿が下書きより先に並ぶべき")
+	})
+
+	t.Run("Success - Scheduler publishes due posts with hashtags", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		scheduled, err := service.CreateDraft(ctx, user.ID, "予約投稿 #golang", &future, PostMediaInput{})
+		require.NoError(t, err)
+		later := testutil.CreateTestPost(t, db, user.ID, "予約後に作成した投稿")
+
+		// 公開日時前は公開しない
+		published, err := service.PublishDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, published)
+
+		require.NoError(t, db.Model(&models.Post{}).Where("id = ?", scheduled.ID).
+			Update("publish_at", time.Now().Add(-time.Minute)).Error)
+		published, err = service.PublishDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+
+		timeline, hasMore, nextCursor, err := GetTimeline(nil, "all", 1, nil)
+		require.NoError(t, err)
+		require.Len(t, timeline, 1)
+		assert.Equal(t, scheduled.ID, timeline[0].ID, "公開時点の投稿として先頭に並ぶべき")
+		assert.True(t, hasMore)
+
+		// IDの小さい予約投稿をカーソルにしても、後から作成した投稿が次のページに続くべき
+		timeline, _, _, err = GetTimeline(nil, "all", 1, &nextCursor)
+		require.NoError(t, err)
+		require.Len(t, timeline, 1)
+		assert.Equal(t, later.ID, timeline[0].ID)
+
+		tagged, _, _, err := NewHashtagService().GetPostsByHashtag(ctx, "golang", 0, 20, 0)
+		require.NoError(t, err)
+		assert.Len(t, tagged, 1, "ハッシュタグは公開時に処理されるべき")
+
+		drafts, err := service.ListDrafts(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, drafts)
+	})
+
+	t.Run("Success - Update, publish now and cancel drafts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		draft, err := service.CreateDraft(ctx, user.ID, "下書き", nil, PostMediaInput{})
+		require.NoError(t, err)
+		assert.Equal(t, models.PostStatusDraft, draft.Status)
+
+		updated, err := service.UpdateDraft(ctx, user.ID, draft.ID, "予約に変更", &future)
+		require.NoError(t, err)
+		assert.Equal(t, "予約に変更", updated.Content)
+		assert.Equal(t, models.PostStatusScheduled, updated.Status)
+
+		published, err := service.PublishDraft(ctx, user.ID, draft.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PostStatusPublished, published.Status)
+		assert.Nil(t, published.PublishAt)
+
+		// 公開済みの投稿は下書きとして編集・取り消しできない
+		_, err = service.UpdateDraft(ctx, user.ID, draft.ID, "編集", nil)
+		assert.EqualError(t, err, "post not found")
+		assert.EqualError(t, service.CancelDraft(ctx, user.ID, draft.ID), "post not found")
+
+		another, err := service.CreateDraft(ctx, user.ID, "取り消す予約投稿", &future, PostMediaInput{})
+		require.NoError(t, err)
+		require.NoError(t, service.CancelDraft(ctx, user.ID, another.ID))
+		_, err = service.GetDraft(ctx, user.ID, another.ID)
+		assert.EqualError(t, err, "post not found")
+	})
+
+	t.Run("Error - Other users cannot access or interact with drafts", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		draft, err := service.CreateDraft(ctx, owner.ID, "下書き", nil, PostMediaInput{})
+		require.NoError(t, err)
+
+		_, err = service.GetDraft(ctx, other.ID, draft.ID)
+		assert.EqualError(t, err, "post not found")
+		_, err = service.UpdateDraft(ctx, other.ID, draft.ID, "編集", nil)
+		assert.EqualError(t, err, "post not found")
+		assert.EqualError(t, service.CancelDraft(ctx, other.ID, draft.ID), "post not found")
+
+		assert.EqualError(t, LikePost(other.ID, draft.ID), "post not found")
+		_, err = NewRepostService().Repost(ctx, other.ID, draft.ID)
+		assert.EqualError(t, err, "post not found")
+		_, err = UpdatePost(draft.ID, owner.ID, "通常の編集")
+		assert.EqualError(t, err, "cannot edit unpublished post")
+	})
+
+	t.Run("Error - Publish time in the past", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		past := time.Now().Add(-time.Minute)
+
+		_, err := service.CreateDraft(ctx, user.ID, "過去の予約", &past, PostMediaInput{})
+		assert.EqualError(t, err, "publish_at must be in the future")
+	})
+}
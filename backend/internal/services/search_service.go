@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// SearchService 検索サービス
+type SearchService struct {
+	db *gorm.DB
+}
+
+// NewSearchService SearchServiceのコンストラクタ
+func NewSearchService() *SearchService {
+	return &SearchService{
+		db: database.GetDB(),
+	}
+}
+
+// SearchPosts 投稿を検索（関連度順、カーソルベースページネーション対応）
+// 検索語・フレーズは部分一致（大文字小文字を区別しない）、from:/#tag/since:/until: で絞り込み
+// カーソルは「関連度:投稿ID」形式
+func (s *SearchService) SearchPosts(ctx context.Context, rawQuery string, userID *uint, limit int, cursor *string) ([]models.Post, bool, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, false, "", errors.New("limit must be greater than 0")
+	}
+
+	q := utils.ParseSearchQuery(rawQuery)
+	if q.IsEmpty() {
+		return nil, false, "", errors.New("search query is required")
+	}
+
+	// 関連度（pg_trgmのword_similarity、本文の検索語がない場合は一定）
+	rankExpr := "0::real"
+	var rankArgs []interface{}
+	if text := q.Text(); text != "" {
+		rankExpr = "word_similarity(?, posts.content)"
+		rankArgs = []interface{}{text}
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.Post{}).
+		Select(postCountsSelect+", "+rankExpr+" AS search_rank", rankArgs...).
+		Where("posts.repost_of_id IS NULL"). // リポストは本文を持たないため対象外
+		Where(publishedPostCondition).
+		Preload("User").
+		Preload("Media")
+
+	// フォローしていない鍵アカウントの投稿と、公開範囲外の投稿は対象外
+	query = excludeProtectedAuthors(query, "posts.user_id", userID)
+	query = excludeInvisiblePosts(query, userID)
+
+	// ブロック・ミュートしているユーザーの投稿は対象外
+	query = excludeHiddenUsers(query, "posts.user_id", userID)
+
+	// 検索語・フレーズ（すべて含む投稿）
+	for _, term := range append(append([]string{}, q.Phrases...), q.Terms...) {
+		query = query.Where("posts.content ILIKE ?", "%"+utils.EscapeLike(term)+"%")
+	}
+
+	// from:username
+	if q.From != "" {
+		query = query.Where("posts.user_id IN (SELECT id FROM users WHERE username = ? AND deleted_at IS NULL)", q.From)
+	}
+
+	// #tag（すべてのハッシュタグを含む投稿）
+	for _, tag := range q.Hashtags {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM post_hashtags
+			INNER JOIN hashtags ON hashtags.id = post_hashtags.hashtag_id
+			WHERE post_hashtags.post_id = posts.id AND hashtags.name = ?)`, tag)
+	}
+
+	// 日付フィルター
+	if q.Since != nil {
+		query = query.Where("posts.created_at >= ?", *q.Since)
+	}
+	if q.Until != nil {
+		query = query.Where("posts.created_at < ?", *q.Until)
+	}
+
+	// カーソルベースページネーション（関連度・IDの降順）
+	if cursor != nil && *cursor != "" {
+		if cursorRank, cursorID, err := parseSearchCursor(*cursor); err == nil {
+			args := append([]interface{}{}, rankArgs...)
+			args = append(args, cursorRank)
+			args = append(args, rankArgs...)
+			args = append(args, cursorRank, cursorID)
+			query = query.Where(
+				fmt.Sprintf("(%s < ? OR (%s = ? AND posts.id < ?))", rankExpr, rankExpr),
+				args...,
+			)
+		}
+	}
+
+	type postWithRank struct {
+		postWithCounts
+		SearchRank float32 `gorm:"column:search_rank"`
+	}
+
+	var results []postWithRank
+	if err := query.Order("search_rank DESC").Order("posts.id DESC").Limit(limit + 1).Find(&results).Error; err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(results) > limit
+	if hasMore {
+		results = results[:limit]
+	}
+
+	counts := make([]postWithCounts, len(results))
+	for i := range results {
+		counts[i] = results[i].postWithCounts
+	}
+	posts := toPostsWithCounts(counts)
+
+	nextCursor := ""
+	if hasMore && len(results) > 0 {
+		last := results[len(results)-1]
+		nextCursor = formatSearchCursor(last.SearchRank, last.ID)
+	}
+
+	// ログインユーザーのいいね・ブックマーク状態を一括取得
+	applyViewerStates(s.db.WithContext(ctx), posts, userID)
+	applyMentions(s.db.WithContext(ctx), posts)
+	applyPolls(s.db.WithContext(ctx), posts, userID)
+	applyReferencedPosts(s.db.WithContext(ctx), posts, userID)
+	applyMediaURLs(ctx, posts)
+
+	return posts, hasMore, nextCursor, nil
+}
+
+// SearchUsers ユーザーを前方一致で検索（メンション補完用）
+// ユーザー名・表示名の前方一致、閲覧者がフォロー中のユーザー → フォロワー数の多い順に並べる
+func (s *SearchService) SearchUsers(ctx context.Context, rawQuery string, userID *uint, limit int) ([]*models.PublicUser, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+
+	prefix := strings.TrimPrefix(strings.TrimSpace(rawQuery), "@")
+	if prefix == "" {
+		return nil, errors.New("search query is required")
+	}
+	pattern := utils.EscapeLike(prefix) + "%"
+
+	// 未ログインの場合はフォロー状態を常にfalseとして扱う
+	var viewerID uint
+	if userID != nil {
+		viewerID = *userID
+	}
+
+	type userWithCounts struct {
+		models.User
+		FollowersCount int  `gorm:"column:followers_count"`
+		FollowingCount int  `gorm:"column:following_count"`
+		IsFollowing    bool `gorm:"column:is_following"`
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.User{}).
+		Select(`users.*,
+			(SELECT COUNT(*) FROM follows WHERE follows.following_id = users.id) AS followers_count,
+			(SELECT COUNT(*) FROM follows WHERE follows.follower_id = users.id) AS following_count,
+			EXISTS (SELECT 1 FROM follows WHERE follows.follower_id = ? AND follows.following_id = users.id) AS is_following`, viewerID).
+		Where("users.status = ?", "approved").
+		Where("users.username ILIKE ? OR users.display_name ILIKE ?", pattern, pattern)
+
+	// ブロック・ミュートしているユーザー、閲覧者をブロックしているユーザーは候補に含めない
+	query = excludeHiddenUsers(query, "users.id", userID)
+
+	var results []userWithCounts
+	if err := query.Order("is_following DESC").
+		Order("followers_count DESC").
+		Order("users.username ASC").
+		Limit(limit).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*models.PublicUser, len(results))
+	for i := range results {
+		users[i] = results[i].User.ToPublicUser(userID)
+		users[i].FollowersCount = results[i].FollowersCount
+		users[i].FollowingCount = results[i].FollowingCount
+		if userID != nil && *userID != results[i].ID {
+			isFollowing := results[i].IsFollowing
+			users[i].IsFollowing = &isFollowing
+		}
+	}
+
+	return users, nil
+}
+
+// formatSearchCursor 検索用カーソルを生成（例: 0.5:123）
+func formatSearchCursor(rank float32, id uint) string {
+	return fmt.Sprintf("%s:%d", strconv.FormatFloat(float64(rank), 'g', -1, 32), id)
+}
+
+// parseSearchCursor 検索用カーソルを解析
+func parseSearchCursor(cursor string) (float32, uint64, error) {
+	sep := strings.LastIndex(cursor, ":")
+	if sep < 0 {
+		return 0, 0, errors.New("invalid cursor")
+	}
+
+	rank, err := strconv.ParseFloat(cursor[:sep], 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.ParseUint(cursor[sep+1:], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return float32(rank), id, nil
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestSearchService_SearchPosts(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewSearchService()
+	ctx := context.Background()
+
+	t.Run("Success - Japanese substring match", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		match := testutil.CreateTestPost(t, db, user.ID, "今日は東京でラーメンを食べました")
+		testutil.CreateTestPost(t, db, user.ID, "大阪でたこ焼きを食べました")
+
+		posts, hasMore, _, err := service.SearchPosts(ctx, "ラーメン", nil, 20, nil)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		require.Len(t, posts, 1)
+		assert.Equal(t, match.ID, posts[0].ID)
+		assert.Equal(t, "user", posts[0].User.Username, "ユーザー情報がプリロードされるべき")
+	})
+
+	t.Run("Success - All terms and phrases must match", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		match := testutil.CreateTestPost(t, db, user.ID, "新しい機能をリリースしました")
+		testutil.CreateTestPost(t, db, user.ID, "新しい 機能の説明")
+
+		posts, _, _, err := service.SearchPosts(ctx, `"新しい機能" リリース`, nil, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, match.ID, posts[0].ID)
+	})
+
+	t.Run("Success - Wildcards are matched literally", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		match := testutil.CreateTestPost(t, db, user.ID, "進捗は100%です")
+		testutil.CreateTestPost(t, db, user.ID, "進捗は1000です")
+
+		posts, _, _, err := service.SearchPosts(ctx, "100%", nil, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, match.ID, posts[0].ID)
+	})
+
+	t.Run("Success - from: and #tag filters", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		alice := testutil.CreateTestUser(t, db, "alice@example.com", "alice", "password123")
+		bob := testutil.CreateTestUser(t, db, "bob@example.com", "bob", "password123")
+		match, err := CreatePost(alice.ID, "Goの勉強中 #golang")
+		require.NoError(t, err)
+		_, err = CreatePost(alice.ID, "Goの勉強中")
+		require.NoError(t, err)
+		_, err = CreatePost(bob.ID, "Goの勉強中 #golang")
+		require.NoError(t, err)
+
+		posts, _, _, err := service.SearchPosts(ctx, "from:alice #GoLang", nil, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, match.ID, posts[0].ID)
+	})
+
+	t.Run("Success - Date filters", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		oldPost := testutil.CreateTestPost(t, db, user.ID, "古い投稿")
+		newPost := testutil.CreateTestPost(t, db, user.ID, "新しい投稿")
+		oldDate := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local)
+		require.NoError(t, db.Model(&models.Post{}).Where("id = ?", oldPost.ID).Update("created_at", oldDate).Error)
+
+		posts, _, _, err := service.SearchPosts(ctx, "投稿 since:2024-01-01 until:2024-01-31", nil, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, oldPost.ID, posts[0].ID)
+
+		posts, _, _, err = service.SearchPosts(ctx, "投稿 since:2024-02-01", nil, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, newPost.ID, posts[0].ID)
+	})
+
+	t.Run("Success - Viewer states and counts are filled", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "いいねされる投稿")
+		testutil.CreateTestLike(t, db, post.ID, user.ID)
+		testutil.CreateTestComment(t, db, post.ID, user.ID, "コメント")
+
+		posts, _, _, err := service.SearchPosts(ctx, "いいね", &user.ID, 20, nil)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.True(t, posts[0].IsLiked)
+		assert.False(t, posts[0].IsBookmarked)
+		assert.Equal(t, int64(1), posts[0].LikesCount)
+		assert.Equal(t, int64(1), posts[0].CommentsCount)
+	})
+
+	t.Run("Success - Pagination by cursor", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		for i := 0; i < 3; i++ {
+			testutil.CreateTestPost(t, db, user.ID, "ページネーションの投稿")
+		}
+
+		first, hasMore, nextCursor, err := service.SearchPosts(ctx, "ページネーション", nil, 2, nil)
+		require.NoError(t, err)
+		assert.True(t, hasMore)
+		require.Len(t, first, 2)
+
+		second, hasMore, _, err := service.SearchPosts(ctx, "ページネーション", nil, 2, &nextCursor)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		require.Len(t, second, 1)
+		assert.NotContains(t, []uint{first[0].ID, first[1].ID}, second[0].ID, "ページ間で重複しないべき")
+	})
+
+	t.Run("Error - Empty query", func(t *testing.T) {
+		_, _, _, err := service.SearchPosts(ctx, "  ", nil, 20, nil)
+		assert.EqualError(t, err, "search query is required")
+	})
+}
+
+func TestSearchCursor(t *testing.T) {
+	cursor := formatSearchCursor(0.4285714, 123)
+
+	rank, id, err := parseSearchCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, float32(0.4285714), rank, "関連度は往復で変化しないべき")
+	assert.Equal(t, uint64(123), id)
+
+	_, _, err = parseSearchCursor("invalid")
+	assert.Error(t, err)
+}
+
+func TestSearchService_SearchUsers(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	service := NewSearchService()
+	ctx := context.Background()
+
+	// 承認済みユーザーを作成
+	createApprovedUser := func(t *testing.T, email, username string) *models.User {
+		user := testutil.CreateTestUser(t, db, email, username, "password123")
+		require.NoError(t, db.Model(user).Update("status", "approved").Error)
+		return user
+	}
+
+	t.Run("Success - Prefix match on username and display name", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		alice := createApprovedUser(t, "alice@example.com", "alice")
+		bob := createApprovedUser(t, "bob@example.com", "bob")
+		displayName := "Alicia Bob"
+		require.NoError(t, db.Model(bob).Update("display_name", displayName).Error)
+		createApprovedUser(t, "malice@example.com", "malice")
+
+		users, err := service.SearchUsers(ctx, "@ali", nil, 10)
+		require.NoError(t, err)
+
+		usernames := make([]string, len(users))
+		for i, u := range users {
+			usernames[i] = u.Username
+		}
+		assert.ElementsMatch(t, []string{alice.Username, bob.Username}, usernames, "前方一致のみ対象とするべき")
+	})
+
+	t.Run("Success - Followed users first, then by follower count", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		viewer := createApprovedUser(t, "viewer@example.com", "viewer")
+		popular := createApprovedUser(t, "popular@example.com", "sam_popular")
+		followed := createApprovedUser(t, "followed@example.com", "sam_followed")
+		quiet := createApprovedUser(t, "quiet@example.com", "sam_quiet")
+		fan1 := createApprovedUser(t, "fan1@example.com", "fan1")
+		fan2 := createApprovedUser(t, "fan2@example.com", "fan2")
+
+		testutil.CreateTestFollow(t, db, fan1.ID, popular.ID)
+		testutil.CreateTestFollow(t, db, fan2.ID, popular.ID)
+		testutil.CreateTestFollow(t, db, viewer.ID, followed.ID)
+
+		users, err := service.SearchUsers(ctx, "sam", &viewer.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+
+		assert.Equal(t, followed.ID, users[0].ID, "フォロー中のユーザーが先頭になるべき")
+		assert.True(t, *users[0].IsFollowing)
+		assert.Equal(t, popular.ID, users[1].ID, "次にフォロワー数の多いユーザー")
+		assert.Equal(t, 2, users[1].FollowersCount)
+		assert.False(t, *users[1].IsFollowing)
+		assert.Equal(t, quiet.ID, users[2].ID)
+	})
+
+	t.Run("Success - Blocked users and blockers are excluded", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		viewer := createApprovedUser(t, "viewer@example.com", "viewer")
+		blocked := createApprovedUser(t, "blocked@example.com", "tom_blocked")
+		blocker := createApprovedUser(t, "blocker@example.com", "tom_blocker")
+		visible := createApprovedUser(t, "visible@example.com", "tom_visible")
+
+		blockService := NewBlockService()
+		require.NoError(t, blockService.BlockUser(ctx, viewer.ID, blocked.Username))
+		require.NoError(t, blockService.BlockUser(ctx, blocker.ID, viewer.Username))
+
+		users, err := service.SearchUsers(ctx, "tom", &viewer.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, users, 1, "ブロックしたユーザー・ブロックされたユーザーは候補に含めないべき")
+		assert.Equal(t, visible.ID, users[0].ID)
+	})
+
+	t.Run("Success - Pending users are excluded", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		testutil.CreateTestUser(t, db, "pending@example.com", "pending_user", "password123")
+
+		users, err := service.SearchUsers(ctx, "pending", nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("Success - Anonymous viewer has no follow state", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+
+		createApprovedUser(t, "carol@example.com", "carol")
+
+		users, err := service.SearchUsers(ctx, "car", nil, 10)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Nil(t, users[0].IsFollowing)
+		assert.Nil(t, users[0].Email, "メールアドレスは含めないべき")
+	})
+
+	t.Run("Error - Empty query", func(t *testing.T) {
+		_, err := service.SearchUsers(ctx, "@", nil, 10)
+		assert.EqualError(t, err, "search query is required")
+	})
+}
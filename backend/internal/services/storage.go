@@ -0,0 +1,133 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/sns-backend/internal/config"
+)
+
+// UploadObjectPrefix アップロードしたオブジェクトのパス接頭辞（ローカルドライバーの配信パスにも使用）
+const UploadObjectPrefix = "uploads"
+
+// ストレージドライバー
+const (
+	StorageDriverFirebase = "firebase"
+	StorageDriverLocal    = "local"
+	StorageDriverS3       = "s3"
+)
+
+// Storage メディアファイルの保存先（config.ConfigのStorageDriverで切り替え）
+type Storage interface {
+	// UploadFile ファイルをアップロードし、オブジェクトパスを返す
+	UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error)
+	// SignedURL オブジェクトの配信用URLを生成（公開ストレージの場合は有効期限なしのURL）
+	SignedURL(ctx context.Context, objectPath string, expiresAt time.Time) (string, error)
+	// DeleteFile オブジェクトを削除（存在しない場合はエラーにしない）
+	DeleteFile(ctx context.Context, objectPath string) error
+	// ListObjects 接頭辞に一致するオブジェクトを列挙（孤立オブジェクトの掃除に使用）
+	ListObjects(ctx context.Context, prefix string) ([]StorageObject, error)
+}
+
+// StorageObject ストレージ上のオブジェクト情報
+type StorageObject struct {
+	Path      string
+	UpdatedAt time.Time
+}
+
+// 各ドライバーがStorageを実装していることをコンパイル時に保証
+var (
+	_ Storage = (*FirebaseStorageService)(nil)
+	_ Storage = (*LocalStorageService)(nil)
+	_ Storage = (*S3StorageService)(nil)
+)
+
+var (
+	storageOnce     sync.Once
+	defaultStorage  Storage
+	defaultStoreErr error
+)
+
+// GetStorage 設定に応じたストレージのシングルトンインスタンスを取得
+func GetStorage() (Storage, error) {
+	storageOnce.Do(func() {
+		if config.AppConfig == nil {
+			defaultStoreErr = errors.New("config not loaded")
+			return
+		}
+		defaultStorage, defaultStoreErr = NewStorage(config.AppConfig)
+	})
+	return defaultStorage, defaultStoreErr
+}
+
+// NewStorage 設定からストレージドライバーを生成
+func NewStorage(cfg *config.Config) (Storage, error) {
+	// 型付きnilをインターフェースに格納しないよう、エラー時は明示的にnilを返す
+	switch cfg.StorageDriver {
+	case StorageDriverFirebase:
+		storage, err := InitFirebaseStorage()
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case StorageDriverLocal:
+		storage, err := NewLocalStorageService(cfg.LocalStorageRoot, cfg.LocalStorageBaseURL)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case StorageDriverS3:
+		storage, err := NewS3StorageService(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.StorageDriver)
+	}
+}
+
+// isUploadObjectPath アップロード用接頭辞配下の正規化されたオブジェクトパスか判定（パストラバーサル防止）
+func isUploadObjectPath(objectPath string) bool {
+	if !strings.HasPrefix(objectPath, UploadObjectPrefix+"/") {
+		return false
+	}
+	for _, segment := range strings.Split(objectPath, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// bytesFile メモリ上のデータをmultipart.Fileとして扱う
+type bytesFile struct {
+	*bytes.Reader
+}
+
+func (f bytesFile) Close() error { return nil }
+
+// uploadBytes メモリ上のデータをアップロード（画像処理で生成したバリアント等）
+func uploadBytes(ctx context.Context, storage Storage, filename, contentType string, data []byte) (string, error) {
+	header := &multipart.FileHeader{
+		Filename: filename,
+		Size:     int64(len(data)),
+		Header:   textproto.MIMEHeader{"Content-Type": {contentType}},
+	}
+	return storage.UploadFile(ctx, bytesFile{bytes.NewReader(data)}, header)
+}
+
+// newObjectPath オブジェクトパスを生成（UUID + 元のファイル拡張子）
+func newObjectPath(filename string) string {
+	ext := filepath.Ext(filename)
+	return fmt.Sprintf("%s/%s%s", UploadObjectPrefix, uuid.New().String(), ext)
+}
@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yourusername/sns-backend/internal/config"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// uploadSessionTTL 分割アップロードセッションの有効期限（開始から）
+	uploadSessionTTL = 24 * time.Hour
+	// UploadChunkMaxSize 1回のリクエストで受け付けるチャンクの最大サイズ
+	UploadChunkMaxSize = 5 * 1024 * 1024 // 5 MB
+)
+
+// UploadSessionService 再開可能な分割アップロードサービス
+// 開始 → チャンク送信（オフセット指定）→ 完了 の順に呼び出す。途中で切断されても受信済みのオフセットから再開できる
+type UploadSessionService struct {
+	db      *gorm.DB
+	media   *MediaService
+	tempDir string
+}
+
+// NewUploadSessionService UploadSessionServiceのコンストラクタ
+func NewUploadSessionService() *UploadSessionService {
+	tempDir := filepath.Join(os.TempDir(), "sns-uploads")
+	if config.AppConfig != nil && config.AppConfig.UploadTempDir != "" {
+		tempDir = config.AppConfig.UploadTempDir
+	}
+
+	return &UploadSessionService{
+		db:      database.GetDB(),
+		media:   NewMediaService(),
+		tempDir: tempDir,
+	}
+}
+
+// StartUploadSessionCleanup 期限切れセッションの定期削除を開始（intervalが0以下の場合は何もしない）
+func StartUploadSessionCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	service := NewUploadSessionService()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := service.CleanupExpired(ctx)
+				if err != nil {
+					fmt.Printf("Warning: upload session cleanup failed: %v\n", err)
+					continue
+				}
+				if deleted > 0 {
+					fmt.Printf("Upload session cleanup deleted %d expired sessions\n", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// CreateSession 分割アップロードを開始
+// 拡張子と申告サイズを検証し、空の一時ファイルを作成する
+// @param ctx コンテキスト
+// @param userID アップロードするユーザーID
+// @param filename ファイル名（拡張子でメディアタイプを判定）
+// @param totalSize ファイル全体のサイズ（バイト）
+// @param postID 完了時に紐付ける投稿ID（nilの場合は下書きメディア）
+// @return 作成されたセッション, error
+func (s *UploadSessionService) CreateSession(ctx context.Context, userID uint, filename string, totalSize int64, postID *uint) (*models.UploadSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	mediaType, _, err := s.media.getMediaType(filename)
+	if err != nil {
+		return nil, err
+	}
+	if totalSize <= 0 {
+		return nil, errors.New("invalid file size")
+	}
+	if err := s.media.validateFileSize(mediaType, totalSize); err != nil {
+		return nil, err
+	}
+
+	if postID != nil {
+		if err := s.checkPostAttachable(s.db.WithContext(ctx), userID, *postID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(s.tempDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	session := &models.UploadSession{
+		UserID:    userID,
+		PostID:    postID,
+		Filename:  filepath.Base(filename),
+		TotalSize: totalSize,
+		Status:    models.UploadSessionStatusUploading,
+		ExpiresAt: time.Now().Add(uploadSessionTTL),
+	}
+	if err := s.db.WithContext(ctx).Create(session).Error; err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(s.chunkPath(session.ID), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		s.db.WithContext(ctx).Delete(session)
+		return nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	file.Close()
+
+	return session, nil
+}
+
+// GetSession 自分の有効なセッションを取得（再開時に受信済みのオフセットを確認する）
+func (s *UploadSessionService) GetSession(ctx context.Context, userID, sessionID uint) (*models.UploadSession, error) {
+	var session models.UploadSession
+	if err := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ? AND expires_at > ?", sessionID, userID, time.Now()).
+		First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("upload session not found")
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// AppendChunk チャンクを一時ファイルに追記
+// offsetは受信済みのバイト数と一致する必要がある（不一致の場合は"offset mismatch"、現在のセッションも返す）
+// @param ctx コンテキスト
+// @param userID アップロードするユーザーID
+// @param sessionID セッションID
+// @param offset チャンクの開始位置
+// @param chunk チャンクのデータ（UploadChunkMaxSizeまで）
+// @return 更新後のセッション, error
+func (s *UploadSessionService) AppendChunk(ctx context.Context, userID, sessionID uint, offset int64, chunk io.Reader) (*models.UploadSession, error) {
+	session, err := s.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.UploadSessionStatusUploading {
+		return nil, errors.New("upload already completed")
+	}
+	if offset != session.ReceivedSize {
+		return session, errors.New("offset mismatch")
+	}
+
+	// 受信中はロックを取らないよう、チャンクはいったん個別の一時ファイルに書き込む
+	staged, err := os.CreateTemp(s.tempDir, fmt.Sprintf("%d.*.chunk", session.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write chunk: %w", err)
+	}
+	defer os.Remove(staged.Name())
+	defer staged.Close()
+
+	// 残りサイズとチャンク上限を超えるデータは受け付けない（上限+1バイトまで読めば超過を判定できる）
+	limit := min(session.TotalSize-offset, UploadChunkMaxSize)
+	written, err := io.Copy(staged, io.LimitReader(chunk, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write chunk: %w", err)
+	}
+	if written > limit {
+		return nil, errors.New("chunk too large")
+	}
+	if written == 0 {
+		return nil, errors.New("empty chunk")
+	}
+
+	// セッションの行ロックで同じセッションへの書き込みを直列化し、
+	// オフセットが一致した場合のみ一時ファイルへ追記する（同時送信は片方のみ反映する）
+	mismatch := false
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked models.UploadSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", session.ID).
+			First(&locked).Error; err != nil {
+			return err
+		}
+		if locked.ReceivedSize != offset || locked.Status != models.UploadSessionStatusUploading {
+			mismatch = true
+			return nil
+		}
+
+		if err := s.appendStagedChunk(session.ID, offset, staged); err != nil {
+			return err
+		}
+
+		return tx.Model(&locked).Update("received_size", offset+written).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if mismatch {
+		current, err := s.GetSession(ctx, userID, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return current, errors.New("offset mismatch")
+	}
+
+	session.ReceivedSize = offset + written
+	return session, nil
+}
+
+// CompleteSession アップロードを完了し、組み立てたファイルをストレージに保存してメディアを作成
+// 既に完了している場合は作成済みのメディアを返す（完了リクエストの再送に対応）
+// @param ctx コンテキスト
+// @param userID アップロードするユーザーID
+// @param sessionID セッションID
+// @return 作成されたメディア, error
+func (s *UploadSessionService) CompleteSession(ctx context.Context, userID, sessionID uint) (*models.Media, error) {
+	session, err := s.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status == models.UploadSessionStatusCompleted && session.MediaID != nil {
+		var media models.Media
+		if err := s.db.WithContext(ctx).First(&media, *session.MediaID).Error; err != nil {
+			return nil, err
+		}
+		if resolver := GetMediaURLResolver(); resolver != nil {
+			resolver.resolve(ctx, &media)
+		}
+		return &media, nil
+	}
+	if session.ReceivedSize != session.TotalSize {
+		return nil, errors.New("upload incomplete")
+	}
+
+	file, err := os.Open(s.chunkPath(session.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+
+	// 通常のアップロードと同じ検証（内容・実サイズ）と保存処理を行う
+	media, err := s.media.uploadFile(ctx, file, &multipart.FileHeader{
+		Filename: session.Filename,
+		Size:     session.TotalSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	media.UserID = &userID
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if session.PostID != nil {
+			// 開始後に投稿が削除された・枚数が上限に達した場合は紐付けない
+			if err := s.checkPostAttachable(tx, userID, *session.PostID); err != nil {
+				return err
+			}
+			var count int64
+			if err := tx.Model(&models.Media{}).Where("post_id = ?", *session.PostID).Count(&count).Error; err != nil {
+				return err
+			}
+			media.PostID = session.PostID
+			media.OrderIndex = int(count)
+		}
+
+		if err := tx.Create(media).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.UploadSession{}).
+			Where("id = ? AND status = ?", session.ID, models.UploadSessionStatusUploading).
+			Updates(map[string]interface{}{"status": models.UploadSessionStatusCompleted, "media_id": media.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("upload already completed")
+		}
+		return nil
+	})
+	if err != nil {
+		s.media.deleteObjects(ctx, []models.Media{*media})
+		return nil, err
+	}
+
+	// 組み立て済みの一時ファイルは不要
+	s.removeChunkFile(session.ID)
+
+	return media, nil
+}
+
+// CancelSession アップロードを中止し、セッションと一時ファイルを削除
+func (s *UploadSessionService) CancelSession(ctx context.Context, userID, sessionID uint) error {
+	session, err := s.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Delete(session).Error; err != nil {
+		return err
+	}
+	s.removeChunkFile(session.ID)
+
+	return nil
+}
+
+// CleanupExpired 期限切れのセッションと一時ファイルを削除
+// @return 削除したセッション数, error
+func (s *UploadSessionService) CleanupExpired(ctx context.Context) (int, error) {
+	var sessions []models.UploadSession
+	if err := s.db.WithContext(ctx).
+		Where("expires_at <= ?", time.Now()).
+		Find(&sessions).Error; err != nil {
+		return 0, err
+	}
+	if len(sessions) == 0 {
+		return 0, nil
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&sessions).Error; err != nil {
+		return 0, err
+	}
+	for _, session := range sessions {
+		s.removeChunkFile(session.ID)
+	}
+
+	return len(sessions), nil
+}
+
+// checkPostAttachable 投稿にメディアを追加できるか確認（本人の投稿で、添付が4枚未満）
+func (s *UploadSessionService) checkPostAttachable(db *gorm.DB, userID, postID uint) error {
+	var post models.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("post not found")
+		}
+		return err
+	}
+	if post.UserID != userID {
+		return errors.New("forbidden")
+	}
+
+	var count int64
+	if err := db.Model(&models.Media{}).Where("post_id = ?", postID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= 4 {
+		return errors.New("maximum 4 media files allowed")
+	}
+
+	return nil
+}
+
+// appendStagedChunk 受信済みのチャンクをセッションの一時ファイルのoffsetの位置へ書き込む
+// セッションの行ロックを保持した状態で呼び出す
+func (s *UploadSessionService) appendStagedChunk(sessionID uint, offset int64, staged *os.File) error {
+	file, err := os.OpenFile(s.chunkPath(sessionID), os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+
+	// 前回のリクエストが途中で失敗した場合に書き込まれた分を破棄
+	if err := file.Truncate(offset); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+	if _, err := staged.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+	if _, err := io.Copy(file, staged); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+	return nil
+}
+
+// chunkPath セッションの一時ファイルのパス
+func (s *UploadSessionService) chunkPath(sessionID uint) string {
+	return filepath.Join(s.tempDir, fmt.Sprintf("%d.part", sessionID))
+}
+
+// removeChunkFile 一時ファイルを削除（存在しない場合は無視）
+func (s *UploadSessionService) removeChunkFile(sessionID uint) {
+	if err := os.Remove(s.chunkPath(sessionID)); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Warning: failed to remove upload file for session %d: %v\n", sessionID, err)
+	}
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/sns-backend/internal/database"
+	"github.com/yourusername/sns-backend/internal/models"
+	"github.com/yourusername/sns-backend/internal/testutil"
+)
+
+func TestUploadSessionService(t *testing.T) {
+	// テストDBのセットアップ
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, db)
+	defer testutil.CleanupTestDB(t, db)
+
+	// グローバルDBを設定
+	database.DB = db
+
+	ctx := context.Background()
+
+	newService := func(t *testing.T) *UploadSessionService {
+		storage, err := NewLocalStorageService(t.TempDir(), "http://localhost:8080")
+		require.NoError(t, err)
+		return &UploadSessionService{
+			db:      db,
+			media:   &MediaService{db: db, storage: storage},
+			tempDir: t.TempDir(),
+		}
+	}
+
+	t.Run("Success - Uploads in chunks and attaches media to post", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		post := testutil.CreateTestPost(t, db, user.ID, "動画付き投稿")
+		data := newTestMP4(UploadChunkMaxSize + 1024)
+
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", int64(len(data)), &post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), session.ReceivedSize)
+
+		session, err = service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(data[:UploadChunkMaxSize]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(UploadChunkMaxSize), session.ReceivedSize)
+
+		session, err = service.AppendChunk(ctx, user.ID, session.ID, UploadChunkMaxSize, bytes.NewReader(data[UploadChunkMaxSize:]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), session.ReceivedSize)
+
+		media, err := service.CompleteSession(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "video", media.MediaType)
+		assert.Equal(t, int64(len(data)), media.FileSize)
+		require.NotNil(t, media.PostID)
+		assert.Equal(t, post.ID, *media.PostID)
+
+		var saved models.UploadSession
+		require.NoError(t, db.First(&saved, session.ID).Error)
+		assert.Equal(t, models.UploadSessionStatusCompleted, saved.Status)
+		_, err = os.Stat(service.chunkPath(session.ID))
+		assert.True(t, os.IsNotExist(err), "一時ファイルは削除されるべき")
+
+		// 完了リクエストの再送は同じメディアを返す
+		again, err := service.CompleteSession(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, media.ID, again.ID)
+	})
+
+	t.Run("Success - Resumes from received offset", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		data := newTestMP4(2048)
+
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", int64(len(data)), nil)
+		require.NoError(t, err)
+		_, err = service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(data[:1024]))
+		require.NoError(t, err)
+
+		// 同じチャンクの再送はオフセット不一致として現在位置を返す
+		current, err := service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(data[:1024]))
+		assert.EqualError(t, err, "offset mismatch")
+		require.NotNil(t, current)
+		assert.Equal(t, int64(1024), current.ReceivedSize)
+
+		_, err = service.AppendChunk(ctx, user.ID, session.ID, current.ReceivedSize, bytes.NewReader(data[1024:]))
+		require.NoError(t, err)
+
+		media, err := service.CompleteSession(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		assert.Nil(t, media.PostID, "投稿IDなしの場合は下書きメディアになるべき")
+		require.NotNil(t, media.UserID)
+		assert.Equal(t, user.ID, *media.UserID)
+	})
+
+	t.Run("Success - Concurrent chunks at the same offset keep the file consistent", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		data := newTestMP4(2048)
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", int64(len(data)), nil)
+		require.NoError(t, err)
+
+		// 長さと内容が異なるチャンクを同じオフセットへ同時に送信する
+		chunks := [][]byte{data[:1024], bytes.Repeat([]byte{0xFF}, 512)}
+		var wg sync.WaitGroup
+		for _, chunk := range chunks {
+			wg.Add(1)
+			go func(chunk []byte) {
+				defer wg.Done()
+				service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(chunk))
+			}(chunk)
+		}
+		wg.Wait()
+
+		current, err := service.GetSession(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		written, err := os.ReadFile(service.chunkPath(session.ID))
+		require.NoError(t, err)
+		require.Equal(t, current.ReceivedSize, int64(len(written)), "一時ファイルの長さは受信済みサイズと一致するべき")
+
+		// 反映された方のチャンクの内容がそのまま残る
+		var winner []byte
+		for _, chunk := range chunks {
+			if int64(len(chunk)) == current.ReceivedSize {
+				winner = chunk
+			}
+		}
+		assert.Equal(t, winner, written)
+
+		entries, err := os.ReadDir(service.tempDir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "受信途中の一時チャンクは残らないべき")
+	})
+
+	t.Run("Error - Declared size exceeds limit", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		_, err := service.CreateSession(ctx, user.ID, "movie.mp4", MaxVideoSize+1, nil)
+		require.Error(t, err)
+		var validationErr *MediaValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, MediaErrorFileTooLarge, validationErr.Code)
+	})
+
+	t.Run("Error - Chunk beyond declared size", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", 100, nil)
+		require.NoError(t, err)
+
+		_, err = service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(make([]byte, 200)))
+		assert.EqualError(t, err, "chunk too large")
+
+		current, err := service.GetSession(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), current.ReceivedSize)
+	})
+
+	t.Run("Error - Complete before all chunks arrive", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", 100, nil)
+		require.NoError(t, err)
+		_, err = service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(make([]byte, 50)))
+		require.NoError(t, err)
+
+		_, err = service.CompleteSession(ctx, user.ID, session.ID)
+		assert.EqualError(t, err, "upload incomplete")
+	})
+
+	t.Run("Error - Content does not match extension", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		data := []byte("<html>not a video</html>")
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", int64(len(data)), nil)
+		require.NoError(t, err)
+		_, err = service.AppendChunk(ctx, user.ID, session.ID, 0, bytes.NewReader(data))
+		require.NoError(t, err)
+
+		_, err = service.CompleteSession(ctx, user.ID, session.ID)
+		var validationErr *MediaValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, MediaErrorUnrecognizedContent, validationErr.Code)
+
+		var count int64
+		db.Model(&models.Media{}).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Error - Other users cannot use the session or post", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		owner := testutil.CreateTestUser(t, db, "owner@example.com", "owner", "password123")
+		other := testutil.CreateTestUser(t, db, "other@example.com", "other", "password123")
+		post := testutil.CreateTestPost(t, db, owner.ID, "本人の投稿")
+
+		_, err := service.CreateSession(ctx, other.ID, "movie.mp4", 100, &post.ID)
+		assert.EqualError(t, err, "forbidden")
+
+		session, err := service.CreateSession(ctx, owner.ID, "movie.mp4", 100, nil)
+		require.NoError(t, err)
+		_, err = service.AppendChunk(ctx, other.ID, session.ID, 0, bytes.NewReader(make([]byte, 100)))
+		assert.EqualError(t, err, "upload session not found")
+	})
+
+	t.Run("Success - Cleanup removes expired sessions and files", func(t *testing.T) {
+		testutil.CleanupTestDB(t, db)
+		service := newService(t)
+
+		user := testutil.CreateTestUser(t, db, "user@example.com", "user", "password123")
+		session, err := service.CreateSession(ctx, user.ID, "movie.mp4", 100, nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Model(session).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		_, err = service.GetSession(ctx, user.ID, session.ID)
+		assert.EqualError(t, err, "upload session not found", "期限切れのセッションは使えないべき")
+
+		deleted, err := service.CleanupExpired(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		_, err = os.Stat(filepath.Join(service.tempDir, filepath.Base(service.chunkPath(session.ID))))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
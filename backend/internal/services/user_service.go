@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/yourusername/sns-backend/internal/database"
 	"github.com/yourusername/sns-backend/internal/models"
@@ -39,6 +41,10 @@ func GetUserByUsername(username string, currentUserID *uint) (*models.PublicUser
 			Count(&followCount)
 		isFollowing := followCount > 0
 		publicUser.IsFollowing = &isFollowing
+
+		// 鍵アカウントへのフォローリクエスト中かチェック
+		followRequested := !isFollowing && hasPendingFollowRequest(db, *currentUserID, user.ID)
+		publicUser.FollowRequested = &followRequested
 	}
 
 	return publicUser, nil
@@ -65,6 +71,7 @@ func UpdateProfile(userID uint, updates map[string]interface{}) (*models.User, e
 		"website":      true,
 		"birth_date":   true,
 		"occupation":   true,
+		"is_protected": true,
 	}
 
 	filteredUpdates := make(map[string]interface{})
@@ -74,9 +81,19 @@ func UpdateProfile(userID uint, updates map[string]interface{}) (*models.User, e
 		}
 	}
 
+	isProtected, protectedUpdated := filteredUpdates["is_protected"].(bool)
+	unprotected := protectedUpdated && user.IsProtected && !isProtected
+
 	if err := db.Model(&user).Updates(filteredUpdates).Error; err != nil {
 		return nil, err
 	}
 
+	// 鍵アカウントを解除した場合は保留中のフォローリクエストをすべて承認
+	if unprotected {
+		if err := NewFollowRequestService().ApproveAllRequests(context.Background(), user.ID); err != nil {
+			fmt.Printf("Warning: failed to approve follow requests: %v\n", err)
+		}
+	}
+
 	return &user, nil
 }
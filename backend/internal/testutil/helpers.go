@@ -69,6 +69,25 @@ func CreateTestComment(t *testing.T, db *gorm.DB, postID, userID uint, content s
 	return comment
 }
 
+// CreateTestReply creates a test reply to a comment in the database
+func CreateTestReply(t *testing.T, db *gorm.DB, parent *models.Comment, userID uint, content string) *models.Comment {
+	t.Helper()
+
+	reply := &models.Comment{
+		PostID:   parent.PostID,
+		UserID:   userID,
+		ParentID: &parent.ID,
+		Depth:    parent.Depth + 1,
+		Content:  content,
+	}
+
+	if err := db.Create(reply).Error; err != nil {
+		t.Fatalf("Failed to create test reply: %v", err)
+	}
+
+	return reply
+}
+
 // CreateTestLike creates a test like in the database
 func CreateTestLike(t *testing.T, db *gorm.DB, postID, userID uint) *models.PostLike {
 	t.Helper()
@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/yourusername/sns-backend/internal/database"
 	"github.com/yourusername/sns-backend/internal/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -46,11 +47,46 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&models.Follow{},
 		&models.Hashtag{},
 		&models.PostHashtag{},
+		&models.Notification{},
+		&models.PostMention{},
+		&models.Block{},
+		&models.Mute{},
+		&models.FollowRequest{},
+		&models.Conversation{},
+		&models.ConversationMember{},
+		&models.Message{},
+		&models.UploadSession{},
+		&models.BookmarkCollection{},
+		&models.Bookmark{},
+		&models.PostRevision{},
+		&models.Poll{},
+		&models.PollOption{},
+		&models.PollVote{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
 
+	if err := database.MigrateMediaObjectPaths(db); err != nil {
+		t.Fatalf("Failed to migrate media object paths: %v", err)
+	}
+
+	if err := database.EnsureRepostUniqueIndex(db); err != nil {
+		t.Fatalf("Failed to create repost unique index: %v", err)
+	}
+
+	if err := database.MigrateConversationDirectKeys(db); err != nil {
+		t.Fatalf("Failed to migrate conversation direct keys: %v", err)
+	}
+
+	if err := database.MigrateBookmarkCollections(db); err != nil {
+		t.Fatalf("Failed to migrate bookmark collections: %v", err)
+	}
+
+	if err := database.EnsureSearchIndexes(db); err != nil {
+		t.Fatalf("Failed to create search indexes: %v", err)
+	}
+
 	log.Println("✅ Test database setup completed")
 
 	return db
@@ -62,13 +98,28 @@ func CleanupTestDB(t *testing.T, db *gorm.DB) {
 
 	// テーブルの順序に注意（外部キー制約のため）
 	tables := []interface{}{
+		&models.UploadSession{},
+		&models.Bookmark{},
+		&models.BookmarkCollection{},
+		&models.PostRevision{},
+		&models.PollVote{},
+		&models.PollOption{},
+		&models.Poll{},
+		&models.Notification{},
+		&models.PostMention{},
 		&models.PostLike{},
 		&models.Comment{},
 		&models.Media{},
+		&models.Message{},
+		&models.ConversationMember{},
+		&models.Conversation{},
 		&models.PostHashtag{},
 		&models.Hashtag{},
 		&models.Post{},
 		&models.Follow{},
+		&models.FollowRequest{},
+		&models.Block{},
+		&models.Mute{},
 		&models.User{},
 	}
 
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"regexp"
+)
+
+// mentionRegex @メンションの正規表現
+// ユーザー名は英数字とアンダースコアのみ（ValidateUsernameと同じ文字種）
+// メールアドレス（user@example.com）を誤検出しないよう、直前が英数字・アンダースコア・@でないことを条件にする
+var mentionRegex = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_@])@([a-zA-Z0-9_]+)`)
+
+// MaxMentionsPerPost 1投稿あたりのメンション上限
+const MaxMentionsPerPost = 10
+
+// ExtractMentions コンテンツから@メンションを抽出する
+// @param content 投稿内容
+// @return ユーザー名のスライス（@を除く、重複削除済み、最大10個）
+func ExtractMentions(content string) []string {
+	matches := mentionRegex.FindAllStringSubmatch(content, -1)
+
+	if len(matches) == 0 {
+		return []string{}
+	}
+
+	// 重複削除のためmapを使用
+	mentionSet := make(map[string]bool)
+	var mentions []string
+
+	for _, match := range matches {
+		if len(match) < 2 {
+			continue
+		}
+
+		// ユーザー名は大文字小文字を区別するためそのまま扱う
+		username := match[1]
+		if len(username) > MaxUsernameLength {
+			continue
+		}
+
+		// 既に存在しない場合のみ追加
+		if !mentionSet[username] {
+			mentionSet[username] = true
+			mentions = append(mentions, username)
+
+			// 最大10個に制限
+			if len(mentions) >= MaxMentionsPerPost {
+				break
+			}
+		}
+	}
+
+	return mentions
+}
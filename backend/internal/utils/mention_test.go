@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "単一のメンション",
+			content:  "こんにちは @alice さん",
+			expected: []string{"alice"},
+		},
+		{
+			name:     "複数のメンション",
+			content:  "@alice と @bob_123 に共有",
+			expected: []string{"alice", "bob_123"},
+		},
+		{
+			name:     "文頭と文末のメンション",
+			content:  "@alice よろしく @bob",
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "日本語の直後のメンション",
+			content:  "ありがとう@alice",
+			expected: []string{"alice"},
+		},
+		{
+			name:     "重複するメンション",
+			content:  "@alice @bob @alice",
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "メールアドレスは対象外",
+			content:  "連絡先は user@example.com です",
+			expected: []string{},
+		},
+		{
+			name:     "連続する@は対象外",
+			content:  "@@alice",
+			expected: []string{},
+		},
+		{
+			name:     "日本語のユーザー名は対象外",
+			content:  "@テスト さん",
+			expected: []string{},
+		},
+		{
+			name:     "句読点で区切られたメンション",
+			content:  "@alice、@bob。",
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "改行を含むテキスト",
+			content:  "First @alice\n@bob second",
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "10個を超えるメンション（最大10個に制限）",
+			content:  "@u01 @u02 @u03 @u04 @u05 @u06 @u07 @u08 @u09 @u10 @u11 @u12",
+			expected: []string{"u01", "u02", "u03", "u04", "u05", "u06", "u07", "u08", "u09", "u10"},
+		},
+		{
+			name:     "長すぎるユーザー名は対象外",
+			content:  "@abcdefghijklmnopqrstuvwxyz012345 @alice",
+			expected: []string{"alice"},
+		},
+		{
+			name:     "メンションなし",
+			content:  "これは普通のテキストです",
+			expected: []string{},
+		},
+		{
+			name:     "空文字列",
+			content:  "",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMentions(tt.content)
+			assert.Equal(t, tt.expected, result, "メンションの抽出結果が期待値と一致しません")
+		})
+	}
+}
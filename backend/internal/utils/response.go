@@ -20,6 +20,16 @@ func ErrorResponse(c echo.Context, statusCode int, message string) error {
 	})
 }
 
+// ErrorResponseWithCode - エラーコード付きのエラーレスポンス（クライアントが理由を判別する場合に使用）
+func ErrorResponseWithCode(c echo.Context, statusCode int, code, message string) error {
+	return c.JSON(statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
 // PaginationResponse - ページネーション付きレスポンス
 func PaginationResponse(c echo.Context, data interface{}, hasMore bool, nextCursor string, limit int) error {
 	return c.JSON(200, map[string]interface{}{
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SearchQuery 検索クエリの解析結果
+type SearchQuery struct {
+	Terms    []string   // 通常の検索語（すべて含む投稿に一致）
+	Phrases  []string   // "..." で囲まれたフレーズ（完全一致で含む投稿に一致）
+	From     string     // from:username
+	Hashtags []string   // #tag（#を除き小文字化済み）
+	Since    *time.Time // since:YYYY-MM-DD（その日の0時以降）
+	Until    *time.Time // until:YYYY-MM-DD（その日の終わりまで、排他的上限として翌日0時を保持）
+}
+
+// 検索クエリの上限
+const (
+	MaxSearchQueryLength = 200
+	MaxSearchTokens      = 10
+)
+
+const searchDateLayout = "2006-01-02"
+
+// ParseSearchQuery 検索クエリを解析する
+// 例: `"新しい機能" リリース from:alice #golang since:2024-01-01 until:2024-01-31`
+// 空白は半角・全角どちらも区切りとして扱う
+func ParseSearchQuery(raw string) SearchQuery {
+	var q SearchQuery
+
+	runes := []rune(strings.TrimSpace(raw))
+	if len(runes) > MaxSearchQueryLength {
+		runes = runes[:MaxSearchQueryLength]
+	}
+
+	tokens := 0
+	for i := 0; i < len(runes) && tokens < MaxSearchTokens; {
+		// 区切り文字をスキップ
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		// フレーズ（"..." または 「...」）
+		if closing, ok := phraseClosing(runes[i]); ok {
+			end := i + 1
+			for end < len(runes) && runes[end] != closing {
+				end++
+			}
+			phrase := strings.TrimSpace(string(runes[i+1 : end]))
+			if phrase != "" {
+				q.Phrases = append(q.Phrases, phrase)
+				tokens++
+			}
+			i = end + 1
+			continue
+		}
+
+		// 通常のトークン
+		end := i
+		for end < len(runes) && !unicode.IsSpace(runes[end]) {
+			end++
+		}
+		token := string(runes[i:end])
+		i = end
+
+		if q.applyOperator(token) {
+			tokens++
+			continue
+		}
+
+		q.Terms = append(q.Terms, token)
+		tokens++
+	}
+
+	return q
+}
+
+// IsEmpty 検索条件が1つも指定されていないか
+func (q SearchQuery) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0 && q.From == "" &&
+		len(q.Hashtags) == 0 && q.Since == nil && q.Until == nil
+}
+
+// Text 本文に対する検索語をスペース区切りで返す（ランキング用）
+func (q SearchQuery) Text() string {
+	parts := make([]string, 0, len(q.Phrases)+len(q.Terms))
+	parts = append(parts, q.Phrases...)
+	parts = append(parts, q.Terms...)
+	return strings.Join(parts, " ")
+}
+
+// applyOperator from:/since:/until:/#tag を解釈する（該当しない場合はfalse）
+func (q *SearchQuery) applyOperator(token string) bool {
+	lower := strings.ToLower(token)
+
+	switch {
+	case strings.HasPrefix(lower, "from:"):
+		username := strings.TrimPrefix(token[len("from:"):], "@")
+		if username == "" {
+			return false
+		}
+		q.From = username
+		return true
+	case strings.HasPrefix(lower, "since:"):
+		t, err := time.ParseInLocation(searchDateLayout, token[len("since:"):], time.Local)
+		if err != nil {
+			return false
+		}
+		q.Since = &t
+		return true
+	case strings.HasPrefix(lower, "until:"):
+		t, err := time.ParseInLocation(searchDateLayout, token[len("until:"):], time.Local)
+		if err != nil {
+			return false
+		}
+		next := t.AddDate(0, 0, 1)
+		q.Until = &next
+		return true
+	case strings.HasPrefix(token, "#") || strings.HasPrefix(token, "＃"):
+		tags := ExtractHashtags("#" + strings.TrimLeft(token, "#＃"))
+		if len(tags) == 0 {
+			return false
+		}
+		q.Hashtags = append(q.Hashtags, tags[0])
+		return true
+	}
+
+	return false
+}
+
+// phraseClosing フレーズ開始文字に対応する終了文字を返す
+func phraseClosing(r rune) (rune, bool) {
+	switch r {
+	case '"':
+		return '"', true
+	case '「':
+		return '」', true
+	}
+	return 0, false
+}
+
+// EscapeLike LIKE句のワイルドカードをエスケープする
+func EscapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
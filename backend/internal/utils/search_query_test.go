@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	date := func(s string) *time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", s, time.Local)
+		return &d
+	}
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected SearchQuery
+	}{
+		{
+			name:     "単一の検索語",
+			raw:      "東京",
+			expected: SearchQuery{Terms: []string{"東京"}},
+		},
+		{
+			name:     "全角スペース区切り",
+			raw:      "東京　ラーメン",
+			expected: SearchQuery{Terms: []string{"東京", "ラーメン"}},
+		},
+		{
+			name:     "ダブルクォートのフレーズ",
+			raw:      `"新しい 機能" リリース`,
+			expected: SearchQuery{Phrases: []string{"新しい 機能"}, Terms: []string{"リリース"}},
+		},
+		{
+			name:     "鉤括弧のフレーズ",
+			raw:      "「今日の天気」",
+			expected: SearchQuery{Phrases: []string{"今日の天気"}},
+		},
+		{
+			name:     "閉じられていないフレーズ",
+			raw:      `"未完了のフレーズ`,
+			expected: SearchQuery{Phrases: []string{"未完了のフレーズ"}},
+		},
+		{
+			name:     "from演算子",
+			raw:      "from:alice Go",
+			expected: SearchQuery{From: "alice", Terms: []string{"Go"}},
+		},
+		{
+			name:     "from演算子（@付き）",
+			raw:      "from:@alice",
+			expected: SearchQuery{From: "alice"},
+		},
+		{
+			name:     "ハッシュタグ",
+			raw:      "#GoLang #プログラミング 入門",
+			expected: SearchQuery{Hashtags: []string{"golang", "プログラミング"}, Terms: []string{"入門"}},
+		},
+		{
+			name:     "全角シャープのハッシュタグ",
+			raw:      "＃テスト",
+			expected: SearchQuery{Hashtags: []string{"テスト"}},
+		},
+		{
+			name:     "日付フィルター",
+			raw:      "since:2024-01-01 until:2024-01-31",
+			expected: SearchQuery{Since: date("2024-01-01"), Until: date("2024-02-01")},
+		},
+		{
+			name:     "不正な日付は検索語として扱う",
+			raw:      "since:yesterday",
+			expected: SearchQuery{Terms: []string{"since:yesterday"}},
+		},
+		{
+			name:     "空のfromは検索語として扱う",
+			raw:      "from:",
+			expected: SearchQuery{Terms: []string{"from:"}},
+		},
+		{
+			name:     "空文字列",
+			raw:      "   ",
+			expected: SearchQuery{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseSearchQuery(tt.raw))
+		})
+	}
+}
+
+func TestParseSearchQuery_TokenLimit(t *testing.T) {
+	q := ParseSearchQuery("a b c d e f g h i j k l")
+	assert.Len(t, q.Terms, MaxSearchTokens, "検索語は最大10個に制限されるべき")
+}
+
+func TestSearchQuery_IsEmpty(t *testing.T) {
+	assert.True(t, ParseSearchQuery("").IsEmpty())
+	assert.False(t, ParseSearchQuery("from:alice").IsEmpty())
+	assert.False(t, ParseSearchQuery("#tag").IsEmpty())
+}
+
+func TestSearchQuery_Text(t *testing.T) {
+	q := ParseSearchQuery(`"新機能" リリース from:alice #go`)
+	assert.Equal(t, "新機能 リリース", q.Text())
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `100\%`, EscapeLike("100%"))
+	assert.Equal(t, `a\_b`, EscapeLike("a_b"))
+	assert.Equal(t, `c:\\path`, EscapeLike(`c:\path`))
+}
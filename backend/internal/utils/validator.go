@@ -51,6 +51,7 @@ const (
 	MaxUsernameLength = 30
 	MaxPostContent    = 280  // Twitterライク
 	MaxCommentContent = 500
+	MaxMessageContent = 1000 // ダイレクトメッセージ
 )
 
 // メールアドレスの正規表現（簡易版）
@@ -171,6 +172,21 @@ func ValidateCommentContent(content string) error {
 	return nil
 }
 
+// ValidateMessageContent - ダイレクトメッセージ内容のバリデーション（メディア添付時は本文なしを許可）
+func ValidateMessageContent(content string, hasMedia bool) error {
+	content = strings.TrimSpace(content)
+
+	if content == "" && !hasMedia {
+		return ErrEmptyContent
+	}
+
+	if utf8.RuneCountInString(content) > MaxMessageContent {
+		return errors.New("message content is too long (max 1000 characters)")
+	}
+
+	return nil
+}
+
 // containsDangerousPattern - 危険なパターンが含まれているかチェック
 func containsDangerousPattern(s string) bool {
 	for _, pattern := range dangerousPatterns {
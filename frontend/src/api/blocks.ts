@@ -0,0 +1,21 @@
+import { apiClient } from './client';
+
+// ブロック
+export const blockUser = async (username: string): Promise<void> => {
+  await apiClient.post(`/users/${username}/block`);
+};
+
+// ブロック解除
+export const unblockUser = async (username: string): Promise<void> => {
+  await apiClient.delete(`/users/${username}/block`);
+};
+
+// ミュート
+export const muteUser = async (username: string): Promise<void> => {
+  await apiClient.post(`/users/${username}/mute`);
+};
+
+// ミュート解除
+export const unmuteUser = async (username: string): Promise<void> => {
+  await apiClient.delete(`/users/${username}/mute`);
+};
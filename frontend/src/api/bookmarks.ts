@@ -4,15 +4,38 @@ export interface Bookmark {
   id: number;
   user_id: number;
   post_id: number;
+  collection_id: number | null;
   created_at: string;
 }
 
+export interface BookmarkCollection {
+  id: number;
+  user_id: number;
+  name: string;
+  is_default: boolean; // 既定のコレクション（名前変更・削除不可）
+  share_token: string | null; // 公開リンク用のトークン（nullの場合は非公開）
+  bookmarks_count: number;
+  created_at: string;
+  updated_at: string;
+}
+
 export interface BookmarksResponse {
   posts: any[]; // Post型を使用
   pagination: {
     has_more: boolean;
     next_cursor: string;
   };
+  collections: BookmarkCollection[];
+}
+
+export interface SharedBookmarkCollectionResponse {
+  collection: BookmarkCollection;
+  owner: any; // PublicUser型を使用
+  posts: any[]; // Post型を使用
+  pagination: {
+    has_more: boolean;
+    next_cursor: string;
+  };
 }
 
 /**
@@ -37,7 +60,81 @@ export const unbookmarkPost = async (postId: number): Promise<{ message: string
 export const getBookmarks = async (params?: {
   limit?: number;
   cursor?: string;
+  collection?: number;
 }): Promise<BookmarksResponse> => {
   const response = await apiClient.get('/bookmarks', { params });
   return response.data;
 };
+
+/**
+ * ブックマークコレクション一覧を取得（コレクションごとのブックマーク数を含む）
+ */
+export const getBookmarkCollections = async (): Promise<BookmarkCollection[]> => {
+  const response = await apiClient.get('/bookmarks/collections');
+  return response.data.data;
+};
+
+/**
+ * ブックマークコレクションを作成
+ */
+export const createBookmarkCollection = async (name: string): Promise<BookmarkCollection> => {
+  const response = await apiClient.post('/bookmarks/collections', { name });
+  return response.data.data;
+};
+
+/**
+ * ブックマークコレクションの名前を変更
+ */
+export const renameBookmarkCollection = async (
+  collectionId: number,
+  name: string
+): Promise<BookmarkCollection> => {
+  const response = await apiClient.put(`/bookmarks/collections/${collectionId}`, { name });
+  return response.data.data;
+};
+
+/**
+ * ブックマークコレクションを削除（含まれていたブックマークは既定のコレクションへ移る）
+ */
+export const deleteBookmarkCollection = async (collectionId: number): Promise<{ message: string }> => {
+  const response = await apiClient.delete(`/bookmarks/collections/${collectionId}`);
+  return response.data.data;
+};
+
+/**
+ * ブックマークを別のコレクションへ移動
+ */
+export const moveBookmark = async (postId: number, collectionId: number): Promise<{ message: string }> => {
+  const response = await apiClient.put(`/posts/${postId}/bookmark`, { collection_id: collectionId });
+  return response.data.data;
+};
+
+/**
+ * ブックマークコレクションの公開リンクを発行
+ */
+export const shareBookmarkCollection = async (collectionId: number): Promise<BookmarkCollection> => {
+  const response = await apiClient.post(`/bookmarks/collections/${collectionId}/share`);
+  return response.data.data;
+};
+
+/**
+ * ブックマークコレクションの公開リンクを無効化
+ */
+export const unshareBookmarkCollection = async (collectionId: number): Promise<{ message: string }> => {
+  const response = await apiClient.delete(`/bookmarks/collections/${collectionId}/share`);
+  return response.data.data;
+};
+
+/**
+ * 公開リンクから共有されたブックマークコレクションを取得
+ */
+export const getSharedBookmarkCollection = async (
+  token: string,
+  params?: {
+    limit?: number;
+    cursor?: string;
+  }
+): Promise<SharedBookmarkCollectionResponse> => {
+  const response = await apiClient.get(`/bookmarks/shared/${token}`, { params });
+  return response.data.data;
+};
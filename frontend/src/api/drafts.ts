@@ -0,0 +1,75 @@
+import { apiClient } from './client';
+import type { Post } from '../types/post';
+
+export interface CreateDraftRequest {
+  content: string;
+  publish_at?: string | null; // RFC3339（省略時は下書き）
+  media_ids?: number[];
+  files?: File[];
+}
+
+export interface UpdateDraftRequest {
+  content: string;
+  publish_at?: string | null; // 省略時は下書きに戻す
+}
+
+/**
+ * 下書き・予約投稿を作成
+ */
+export const createDraft = async (data: CreateDraftRequest): Promise<Post> => {
+  if (data.files?.length) {
+    const formData = new FormData();
+    formData.append('content', data.content);
+    if (data.publish_at) {
+      formData.append('publish_at', data.publish_at);
+    }
+    data.media_ids?.forEach((id) => formData.append('media_ids', id.toString()));
+    data.files.forEach((file) => formData.append('files', file));
+
+    const response = await apiClient.post('/drafts', formData, {
+      headers: {
+        'Content-Type': 'multipart/form-data',
+      },
+    });
+    return response.data.data;
+  }
+
+  const response = await apiClient.post('/drafts', {
+    content: data.content,
+    publish_at: data.publish_at,
+    media_ids: data.media_ids,
+  });
+  return response.data.data;
+};
+
+/**
+ * 下書き・予約投稿の一覧を取得
+ */
+export const getDrafts = async (): Promise<Post[]> => {
+  const response = await apiClient.get('/drafts');
+  return response.data.data;
+};
+
+/**
+ * 下書き・予約投稿を更新
+ */
+export const updateDraft = async (postId: number, data: UpdateDraftRequest): Promise<Post> => {
+  const response = await apiClient.put(`/drafts/${postId}`, data);
+  return response.data.data;
+};
+
+/**
+ * 下書き・予約投稿を取り消す
+ */
+export const cancelDraft = async (postId: number): Promise<{ message: string }> => {
+  const response = await apiClient.delete(`/drafts/${postId}`);
+  return response.data;
+};
+
+/**
+ * 下書き・予約投稿をすぐに公開
+ */
+export const publishDraft = async (postId: number): Promise<Post> => {
+  const response = await apiClient.post(`/drafts/${postId}/publish`);
+  return response.data.data;
+};
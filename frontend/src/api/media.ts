@@ -1,4 +1,5 @@
 import { apiClient } from './client';
+import type { MediaVariants } from '../types/post';
 
 export interface Media {
   id: number;
@@ -6,7 +7,11 @@ export interface Media {
   media_type: 'image' | 'video' | 'audio';
   media_url: string;
   file_size: number;
-  duration?: number;
+  duration?: number | null;
+  codec?: string;
+  width?: number | null;
+  height?: number | null;
+  variants?: MediaVariants;
   order_index: number;
   created_at: string;
 }
@@ -0,0 +1,53 @@
+import { apiClient } from './client';
+import type { PaginatedResponse } from '../types/api';
+import type { Conversation, Message } from '../types/message';
+
+// 会話一覧を取得
+export const getConversations = async (params?: {
+  limit?: number;
+  cursor?: string;
+}): Promise<PaginatedResponse<Conversation>> => {
+  const response = await apiClient.get('/conversations', { params });
+  return response.data;
+};
+
+// 1対1の会話を開始（既存の会話があればそれを返す）
+export const createConversation = async (username: string): Promise<Conversation> => {
+  const response = await apiClient.post('/conversations', { username });
+  return response.data.data;
+};
+
+// メッセージ履歴を取得
+export const getMessages = async (
+  conversationId: number,
+  params?: { limit?: number; cursor?: string }
+): Promise<PaginatedResponse<Message>> => {
+  const response = await apiClient.get(`/conversations/${conversationId}/messages`, { params });
+  return response.data;
+};
+
+// メッセージを送信（メディア添付時はmultipart/form-dataで送信）
+export const sendMessage = async (
+  conversationId: number,
+  content: string,
+  files: File[] = []
+): Promise<Message> => {
+  if (files.length === 0) {
+    const response = await apiClient.post(`/conversations/${conversationId}/messages`, { content });
+    return response.data.data;
+  }
+
+  const formData = new FormData();
+  formData.append('content', content);
+  files.forEach((file) => formData.append('files', file));
+
+  const response = await apiClient.post(`/conversations/${conversationId}/messages`, formData, {
+    headers: { 'Content-Type': 'multipart/form-data' },
+  });
+  return response.data.data;
+};
+
+// 会話を既読にする
+export const markConversationAsRead = async (conversationId: number): Promise<void> => {
+  await apiClient.put(`/conversations/${conversationId}/read`);
+};
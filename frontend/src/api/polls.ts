@@ -0,0 +1,12 @@
+import { apiClient } from './client';
+import type { Poll } from '../types/post';
+
+/**
+ * アンケートに投票（締切前であれば投票し直せる）
+ */
+export const votePoll = async (postId: number, optionIds: number[]): Promise<Poll> => {
+  const response = await apiClient.post(`/posts/${postId}/poll/votes`, {
+    option_ids: optionIds,
+  });
+  return response.data.data;
+};
@@ -1,5 +1,6 @@
 import { apiClient } from './openapi-client';
-import type { Post, CreatePostRequest, UpdatePostRequest } from '../types/post';
+import { apiClient as axiosClient } from './client';
+import type { Post, PostRevision, CreatePostRequest, UpdatePostRequest } from '../types/post';
 import type { PaginatedResponse } from '../types/api';
 import type { components } from '../types/schema';
 
@@ -75,6 +76,38 @@ export const getPost = async (postId: number): Promise<Post> => {
 
 // 投稿作成
 export const createPost = async (data: CreatePostRequest): Promise<Post> => {
+  // メディア付きの場合は投稿とメディアを1リクエストで送信（サーバー側で一括保存）
+  if (data.files?.length || data.media_ids?.length) {
+    const formData = new FormData();
+    formData.append('content', data.content);
+    if (data.quote_post_id) {
+      formData.append('quote_post_id', data.quote_post_id.toString());
+    }
+    if (data.visibility) {
+      formData.append('visibility', data.visibility);
+    }
+    data.media_ids?.forEach((id) => formData.append('media_ids', id.toString()));
+    data.files?.forEach((file) => formData.append('files', file));
+
+    const response = await axiosClient.post<BackendPostResponse>('/posts', formData, {
+      headers: {
+        'Content-Type': 'multipart/form-data',
+      },
+    });
+    return response.data.data;
+  }
+
+  // アンケート付き・公開範囲指定の場合（生成済みのスキーマに未反映のためaxiosで送信）
+  if (data.poll || data.visibility) {
+    const response = await axiosClient.post<BackendPostResponse>('/posts', {
+      content: data.content,
+      quote_post_id: data.quote_post_id,
+      poll: data.poll,
+      visibility: data.visibility,
+    });
+    return response.data.data;
+  }
+
   // CreatePostRequestSchema に変換
   const requestBody: CreatePostRequestSchema = {
     content: data.content,
@@ -115,6 +148,12 @@ export const updatePost = async (
   return (responseData as unknown as BackendPostResponse).data;
 };
 
+// 投稿の編集履歴取得（新しい版から順に）
+export const getPostHistory = async (postId: number): Promise<PostRevision[]> => {
+  const response = await axiosClient.get<{ data: PostRevision[] }>(`/posts/${postId}/history`);
+  return response.data.data;
+};
+
 // 投稿削除
 export const deletePost = async (postId: number): Promise<void> => {
   const { error } = await apiClient.DELETE('/posts/{id}', {
@@ -128,6 +167,16 @@ export const deletePost = async (postId: number): Promise<void> => {
   }
 };
 
+// 投稿をプロフィールに固定（既に固定している投稿は置き換えられる）
+export const pinPost = async (postId: number): Promise<void> => {
+  await axiosClient.post(`/posts/${postId}/pin`);
+};
+
+// 投稿の固定を解除
+export const unpinPost = async (postId: number): Promise<void> => {
+  await axiosClient.delete(`/posts/${postId}/pin`);
+};
+
 // 投稿にいいね
 export const likePost = async (postId: number): Promise<void> => {
   const { error } = await apiClient.POST('/posts/{id}/like', {
@@ -0,0 +1,13 @@
+import { apiClient } from './client';
+import type { Post } from '../types/post';
+
+// リポスト
+export const repostPost = async (postId: number): Promise<Post> => {
+  const response = await apiClient.post(`/posts/${postId}/repost`);
+  return response.data.data;
+};
+
+// リポスト取り消し
+export const unrepostPost = async (postId: number): Promise<void> => {
+  await apiClient.delete(`/posts/${postId}/repost`);
+};
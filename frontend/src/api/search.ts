@@ -0,0 +1,13 @@
+import { apiClient } from './client';
+import type { User } from '../types/user';
+
+/**
+ * ユーザーを前方一致で検索（@メンション補完用）
+ */
+export const searchUsers = async (params: {
+  q: string;
+  limit?: number;
+}): Promise<User[]> => {
+  const response = await apiClient.get('/search/users', { params });
+  return response.data.data;
+};
@@ -0,0 +1,116 @@
+import React, { useState } from 'react';
+import {
+  Box,
+  Button,
+  Checkbox,
+  FormControlLabel,
+  LinearProgress,
+  Typography,
+} from '@mui/material';
+import { formatDistanceToNow } from 'date-fns';
+import { ja } from 'date-fns/locale';
+import type { Poll } from '../../types/post';
+import { votePoll } from '../../api/polls';
+
+interface PollViewProps {
+  postId: number;
+  poll: Poll;
+}
+
+// 投稿に添付されたアンケート（投票済みまたは締切後は結果を表示）
+export const PollView: React.FC<PollViewProps> = ({ postId, poll: initialPoll }) => {
+  const [poll, setPoll] = useState(initialPoll);
+  const [selected, setSelected] = useState<number[]>([]);
+  const [isVoting, setIsVoting] = useState(false);
+
+  const showResults = poll.has_voted || poll.is_closed;
+  const totalVotes = poll.options.reduce((sum, option) => sum + (option.votes_count ?? 0), 0);
+
+  const submitVote = async (optionIds: number[]) => {
+    setIsVoting(true);
+    try {
+      setPoll(await votePoll(postId, optionIds));
+      setSelected([]);
+    } finally {
+      setIsVoting(false);
+    }
+  };
+
+  const toggleOption = (optionId: number) => {
+    setSelected((prev) =>
+      prev.includes(optionId) ? prev.filter((id) => id !== optionId) : [...prev, optionId]
+    );
+  };
+
+  return (
+    <Box sx={{ mt: 2 }} onClick={(e) => e.stopPropagation()}>
+      {poll.options.map((option) => {
+        if (showResults) {
+          const percent = totalVotes > 0 ? Math.round(((option.votes_count ?? 0) / totalVotes) * 100) : 0;
+          return (
+            <Box key={option.id} sx={{ mb: 1 }}>
+              <Box sx={{ display: 'flex', justifyContent: 'space-between' }}>
+                <Typography variant="body2" fontWeight={option.is_voted ? 'bold' : 'normal'}>
+                  {option.text}
+                  {option.is_voted && ' ✓'}
+                </Typography>
+                <Typography variant="body2" color="text.secondary">
+                  {percent}%
+                </Typography>
+              </Box>
+              <LinearProgress variant="determinate" value={percent} />
+            </Box>
+          );
+        }
+
+        if (poll.multiple_choice) {
+          return (
+            <FormControlLabel
+              key={option.id}
+              sx={{ display: 'flex' }}
+              control={
+                <Checkbox
+                  checked={selected.includes(option.id)}
+                  onChange={() => toggleOption(option.id)}
+                  disabled={isVoting}
+                />
+              }
+              label={option.text}
+            />
+          );
+        }
+
+        return (
+          <Button
+            key={option.id}
+            variant="outlined"
+            fullWidth
+            sx={{ mb: 1 }}
+            disabled={isVoting}
+            onClick={() => submitVote([option.id])}
+          >
+            {option.text}
+          </Button>
+        );
+      })}
+
+      {!showResults && poll.multiple_choice && (
+        <Button
+          variant="contained"
+          size="small"
+          disabled={isVoting || selected.length === 0}
+          onClick={() => submitVote(selected)}
+        >
+          投票する
+        </Button>
+      )}
+
+      <Typography variant="caption" color="text.secondary" sx={{ display: 'block', mt: 1 }}>
+        {poll.voters_count != null && `${poll.voters_count}票 · `}
+        {poll.is_closed
+          ? '締め切りました'
+          : `締め切りまで${formatDistanceToNow(new Date(poll.closes_at), { locale: ja })}`}
+      </Typography>
+    </Box>
+  );
+};
@@ -17,12 +17,21 @@ import {
   FavoriteBorder as FavoriteBorderIcon,
   ChatBubbleOutline as CommentIcon,
   Delete as DeleteIcon,
+  PushPin as PushPinIcon,
 } from '@mui/icons-material';
 import type { Post } from '../../types/post';
 import { useAuth } from '../../contexts/AuthContext';
 import { formatDistanceToNow } from 'date-fns';
 import { ja } from 'date-fns/locale';
 import { BookmarkButton } from './BookmarkButton';
+import { PollView } from './PollView';
+
+// 再生時間を m:ss 形式に整形
+const formatDuration = (seconds: number): string => {
+  const minutes = Math.floor(seconds / 60);
+  const rest = Math.floor(seconds % 60);
+  return `${minutes}:${rest.toString().padStart(2, '0')}`;
+};
 
 interface PostCardProps {
   post: Post;
@@ -79,6 +88,14 @@ export const PostCard: React.FC<PostCardProps> = ({
 
   return (
     <Card sx={{ mb: { xs: 1, sm: 2 } }} data-testid={`post-${post.id}`}>
+      {post.is_pinned && (
+        <Box sx={{ display: 'flex', alignItems: 'center', gap: 0.5, px: 2, pt: 1 }}>
+          <PushPinIcon fontSize="small" color="action" />
+          <Typography variant="caption" color="text.secondary">
+            固定された投稿
+          </Typography>
+        </Box>
+      )}
       <CardHeader
         avatar={
           <Avatar
@@ -113,6 +130,9 @@ export const PostCard: React.FC<PostCardProps> = ({
         subheader={
           <Typography variant="caption" color="text.secondary" sx={{ fontSize: { xs: '0.7rem', sm: '0.75rem' } }}>
             @{post.user.username} · {formatDate(post.created_at)}
+            {post.edited_at && ' · 編集済み'}
+            {post.visibility === 'followers' && ' · フォロワー限定'}
+            {post.visibility === 'mentioned' && ' · メンション限定'}
           </Typography>
         }
         sx={{ pb: { xs: 1, sm: 2 } }}
@@ -145,7 +165,7 @@ export const PostCard: React.FC<PostCardProps> = ({
               <Box key={media.id} sx={{ width: '100%' }}>
                 {media.media_type === 'image' && (
                   <img
-                    src={media.media_url}
+                    src={media.variants?.feed ?? media.media_url}
                     alt="Post media"
                     style={{
                       width: '100%',
@@ -169,10 +189,17 @@ export const PostCard: React.FC<PostCardProps> = ({
                 {media.media_type === 'audio' && (
                   <audio src={media.media_url} controls style={{ width: '100%' }} />
                 )}
+                {media.media_type !== 'image' && media.duration != null && (
+                  <Typography variant="caption" color="text.secondary">
+                    {formatDuration(media.duration)}
+                  </Typography>
+                )}
               </Box>
             ))}
           </Box>
         )}
+
+        {post.poll && <PollView postId={post.id} poll={post.poll} />}
       </CardContent>
 
       <CardActions disableSpacing sx={{ px: { xs: 1, sm: 2 }, py: { xs: 0.5, sm: 1 } }}>
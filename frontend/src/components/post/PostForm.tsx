@@ -1,4 +1,4 @@
-import React, { useState } from 'react';
+import React, { useRef, useState } from 'react';
 import { useForm } from 'react-hook-form';
 import {
   Box,
@@ -7,9 +7,19 @@ import {
   Paper,
   Typography,
   Alert,
+  List,
+  ListItemButton,
+  ListItemAvatar,
+  ListItemText,
+  Avatar,
 } from '@mui/material';
 import type { CreatePostRequest } from '../../types/post';
+import type { User } from '../../types/user';
 import { ImageUpload } from './ImageUpload';
+import { useUserSearch } from '../../hooks/useSearch';
+
+// カーソル直前の入力中メンション（例: "こんにちは @ali" → "ali"）
+const MENTION_PATTERN = /(^|\s)@([A-Za-z0-9_]*)$/;
 
 interface PostFormProps {
   onSubmit: (data: CreatePostRequest, files?: File[]) => Promise<void>;
@@ -24,24 +34,64 @@ export const PostForm: React.FC<PostFormProps> = ({
 }) => {
   const [error, setError] = useState<string>('');
   const [selectedFiles, setSelectedFiles] = useState<File[]>([]);
+  const [mentionQuery, setMentionQuery] = useState('');
+  const inputRef = useRef<HTMLTextAreaElement | null>(null);
 
   const {
     register,
     handleSubmit,
     formState: { errors },
     reset,
+    getValues,
+    setValue,
   } = useForm<CreatePostRequest>({
     defaultValues: {
       content: initialContent,
     },
   });
 
+  const { data: mentionCandidates } = useUserSearch(mentionQuery);
+
+  const contentField = register('content', {
+    required: '投稿内容を入力してください',
+    maxLength: {
+      value: 280,
+      message: '投稿は280文字以内で入力してください',
+    },
+  });
+
+  // カーソル位置から入力中のメンションを検出
+  const updateMentionQuery = (target: HTMLTextAreaElement) => {
+    const beforeCaret = target.value.slice(0, target.selectionStart ?? target.value.length);
+    const match = beforeCaret.match(MENTION_PATTERN);
+    setMentionQuery(match ? match[2] : '');
+  };
+
+  // 候補を選択したら入力中のメンションをユーザー名で置き換える
+  const handleSelectMention = (user: User) => {
+    const input = inputRef.current;
+    const content = getValues('content') || '';
+    const caret = input?.selectionStart ?? content.length;
+    const beforeCaret = content.slice(0, caret).replace(
+      MENTION_PATTERN,
+      (_, prefix: string) => `${prefix}@${user.username} `
+    );
+    setValue('content', beforeCaret + content.slice(caret), { shouldValidate: true });
+    setMentionQuery('');
+
+    if (input) {
+      input.focus();
+      requestAnimationFrame(() => input.setSelectionRange(beforeCaret.length, beforeCaret.length));
+    }
+  };
+
   const handleFormSubmit = async (data: CreatePostRequest) => {
     try {
       setError('');
       await onSubmit(data, selectedFiles);
       reset();
       setSelectedFiles([]);
+      setMentionQuery('');
     } catch (err: any) {
       setError(
         err.response?.data?.error?.message || '投稿に失敗しました'
@@ -68,13 +118,20 @@ export const PostForm: React.FC<PostFormProps> = ({
           rows={4}
           placeholder="いまどうしてる？"
           inputProps={{ 'data-testid': 'post-input' }}
-          {...register('content', {
-            required: '投稿内容を入力してください',
-            maxLength: {
-              value: 280,
-              message: '投稿は280文字以内で入力してください',
-            },
-          })}
+          {...contentField}
+          inputRef={(el) => {
+            contentField.ref(el);
+            inputRef.current = el;
+          }}
+          onChange={(e) => {
+            contentField.onChange(e);
+            updateMentionQuery(e.target as HTMLTextAreaElement);
+          }}
+          onBlur={(e) => {
+            contentField.onBlur(e);
+            // 候補クリックを受け付けるため少し遅らせて閉じる
+            setTimeout(() => setMentionQuery(''), 150);
+          }}
           error={!!errors.content}
           helperText={errors.content?.message}
           sx={{
@@ -85,6 +142,31 @@ export const PostForm: React.FC<PostFormProps> = ({
           }}
         />
 
+        {/* @メンション候補 */}
+        {mentionQuery && mentionCandidates && mentionCandidates.length > 0 && (
+          <Paper variant="outlined" sx={{ mt: -1, mb: 2 }} data-testid="mention-suggestions">
+            <List dense disablePadding>
+              {mentionCandidates.map((user) => (
+                <ListItemButton
+                  key={user.id}
+                  onMouseDown={(e) => e.preventDefault()}
+                  onClick={() => handleSelectMention(user)}
+                >
+                  <ListItemAvatar>
+                    <Avatar src={user.avatar_url || undefined} sx={{ width: 32, height: 32 }}>
+                      {user.username[0]?.toUpperCase()}
+                    </Avatar>
+                  </ListItemAvatar>
+                  <ListItemText
+                    primary={user.display_name || user.username}
+                    secondary={`@${user.username}`}
+                  />
+                </ListItemButton>
+              ))}
+            </List>
+          </Paper>
+        )}
+
         {/* 画像アップロード */}
         <Box sx={{ mb: 2 }}>
           <ImageUpload
@@ -5,17 +5,20 @@ import { useFollowUser, useUnfollowUser } from '../../hooks/useUsers';
 interface FollowButtonProps {
   username: string;
   isFollowing: boolean;
+  isRequested?: boolean;
 }
 
 export const FollowButton: React.FC<FollowButtonProps> = ({
   username,
   isFollowing,
+  isRequested = false,
 }) => {
   const followUser = useFollowUser();
   const unfollowUser = useUnfollowUser();
 
   const handleClick = () => {
-    if (isFollowing) {
+    // フォロー中・リクエスト済みの場合は解除（リクエストの取り消し）
+    if (isFollowing || isRequested) {
       unfollowUser.mutate(username);
     } else {
       followUser.mutate(username);
@@ -24,6 +27,20 @@ export const FollowButton: React.FC<FollowButtonProps> = ({
 
   const isLoading = followUser.isPending || unfollowUser.isPending;
 
+  if (isRequested && !isFollowing) {
+    return (
+      <Button
+        variant="outlined"
+        size="small"
+        onClick={handleClick}
+        disabled={isLoading}
+        data-testid="cancel-request-button"
+      >
+        リクエスト済み
+      </Button>
+    );
+  }
+
   return (
     <Button
       variant={isFollowing ? 'outlined' : 'contained'}
@@ -85,13 +85,14 @@ export const useUnbookmarkPost = () => {
 /**
  * ブックマーク一覧取得（無限スクロール対応）
  */
-export const useBookmarks = () => {
+export const useBookmarks = (collectionId?: number) => {
   return useInfiniteQuery({
-    queryKey: ['bookmarks'],
+    queryKey: ['bookmarks', collectionId],
     queryFn: ({ pageParam }) =>
       getBookmarks({
         limit: 20,
         cursor: pageParam,
+        collection: collectionId,
       }),
     getNextPageParam: (lastPage) => {
       return lastPage.pagination.has_more ? lastPage.pagination.next_cursor : undefined;
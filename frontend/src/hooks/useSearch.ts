@@ -0,0 +1,14 @@
+import { useQuery } from '@tanstack/react-query';
+import { searchUsers } from '../api/search';
+
+/**
+ * ユーザー検索（@メンション補完用）
+ */
+export const useUserSearch = (query: string, limit: number = 5) => {
+  return useQuery({
+    queryKey: ['search', 'users', query, limit],
+    queryFn: () => searchUsers({ q: query, limit }),
+    enabled: query.length > 0,
+    staleTime: 30 * 1000,
+  });
+};
@@ -11,7 +11,6 @@ import {
   useUnlikePost,
   useDeletePost,
 } from '../hooks/usePosts';
-import { useUploadMedia } from '../hooks/useMedia';
 import type { CreatePostRequest } from '../types/post';
 
 export const HomePage: React.FC = () => {
@@ -20,39 +19,15 @@ export const HomePage: React.FC = () => {
   const queryClient = useQueryClient();
   const { data: timeline, isLoading, error } = useTimeline();
   const createPost = useCreatePost();
-  const uploadMedia = useUploadMedia();
   const likePost = useLikePost();
   const unlikePost = useUnlikePost();
   const deletePost = useDeletePost();
 
   const handleCreatePost = async (data: CreatePostRequest, files?: File[]) => {
-    let createdPostId: number | null = null;
-
     try {
-      // まず投稿を作成（タイムライン更新は手動で行う）
-      const newPost = await createPost.mutateAsync(data);
-      createdPostId = newPost.id;
-
-      // 画像がある場合はアップロード
-      if (files && files.length > 0 && newPost) {
-        try {
-          // 画像アップロード完了後にタイムライン更新
-          await uploadMedia.mutateAsync({
-            postId: newPost.id,
-            files,
-          });
-        } catch (uploadError) {
-          // 画像アップロード失敗時は投稿を削除
-          console.error('Failed to upload media, deleting post:', uploadError);
-          if (createdPostId) {
-            await deletePost.mutateAsync(createdPostId);
-          }
-          throw new Error('画像のアップロードに失敗しました。投稿を取り消しました。');
-        }
-      } else {
-        // 画像がない場合は即座にタイムライン更新
-        queryClient.invalidateQueries({ queryKey: ['timeline'] });
-      }
+      // 投稿と画像を1リクエストで作成（失敗時はサーバー側でどちらも保存されない）
+      await createPost.mutateAsync({ ...data, files });
+      queryClient.invalidateQueries({ queryKey: ['timeline'] });
     } catch (error: any) {
       console.error('Failed to create post:', error);
       // エラーメッセージを整形
@@ -80,7 +55,7 @@ export const HomePage: React.FC = () => {
         {!isMobile && (
           <PostForm
             onSubmit={handleCreatePost}
-            isLoading={createPost.isPending || uploadMedia.isPending}
+            isLoading={createPost.isPending}
           />
         )}
 
@@ -108,7 +108,11 @@ export const UserProfilePage: React.FC = () => {
                   </Typography>
                 </Box>
                 {!isOwnProfile && (
-                  <FollowButton username={profile.username} isFollowing={profile.is_following ?? false} />
+                  <FollowButton
+                    username={profile.username}
+                    isFollowing={profile.is_following ?? false}
+                    isRequested={profile.follow_requested ?? false}
+                  />
                 )}
               </Box>
 
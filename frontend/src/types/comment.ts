@@ -5,6 +5,9 @@ export interface Comment {
   id: number;
   user: User;
   post_id: number;
+  parent_id: number | null;
+  depth: number;
+  replies_count: number;
   content: string;
   created_at: string;
   updated_at?: string;
@@ -0,0 +1,24 @@
+import type { User } from './user';
+import type { Media } from './post';
+
+// ダイレクトメッセージ型定義
+export interface Message {
+  id: number;
+  conversation_id: number;
+  sender_id: number;
+  content: string;
+  created_at: string;
+  sender: User;
+  media?: Media[];
+}
+
+// 会話型定義
+export interface Conversation {
+  id: number;
+  is_group: boolean;
+  participants: User[];
+  last_message?: Message;
+  unread_count: number;
+  created_at: string;
+  updated_at: string;
+}
@@ -1,15 +1,66 @@
 import type { User } from './user';
 
+// 画像のサイズ別バリアント
+export interface MediaVariants {
+  thumbnail: string;
+  feed: string;
+  original: string;
+}
+
 // メディア型定義
 export interface Media {
   id: number;
   media_type: 'image' | 'video' | 'audio';
   media_url: string;
   file_size: number;
-  duration?: number;
+  duration?: number | null; // 動画・音声の長さ（秒）
+  width?: number | null;
+  height?: number | null;
+  codec?: string;
+  variants?: MediaVariants;
   order_index: number;
 }
 
+// メンション型定義（ユーザー名→ユーザーID）
+export interface Mention {
+  user_id: number;
+  username: string;
+}
+
+// アンケートの選択肢（votes_countは投票済みまたは締切後のみ）
+export interface PollOption {
+  id: number;
+  position: number;
+  text: string;
+  votes_count: number | null;
+  is_voted: boolean;
+}
+
+// アンケート
+export interface Poll {
+  id: number;
+  post_id: number;
+  multiple_choice: boolean;
+  closes_at: string;
+  is_closed: boolean;
+  has_voted: boolean;
+  voters_count: number | null;
+  options: PollOption[];
+}
+
+// アンケート作成リクエスト
+export interface CreatePollRequest {
+  options: string[]; // 2〜4つ
+  multiple_choice?: boolean;
+  closes_at: string; // 最大7日後
+}
+
+// 投稿の公開範囲（public: 全員、followers: フォロワーのみ、mentioned: メンションしたユーザーのみ）
+export type PostVisibility = 'public' | 'followers' | 'mentioned';
+
+// 投稿の公開状態
+export type PostStatus = 'published' | 'draft' | 'scheduled';
+
 // 投稿型定義
 export interface Post {
   id: number;
@@ -20,14 +71,45 @@ export interface Post {
   comments_count: number;
   is_liked: boolean;
   is_bookmarked?: boolean;
+  mentions?: Mention[];
+  poll?: Poll;
+  reposts_count?: number;
+  quotes_count?: number;
+  is_reposted?: boolean;
+  repost_of_id?: number;
+  quote_of_id?: number;
+  repost_of?: Post; // リポスト元の投稿
+  quoted_post?: Post; // 引用元の投稿
+  unavailable?: boolean; // 元投稿が削除されている場合のスタブ
+  is_pinned?: boolean; // プロフィールに固定されている投稿（ユーザーの投稿一覧のみ）
+  visibility?: PostVisibility;
+  status?: PostStatus;
+  publish_at?: string | null; // 予約投稿の公開日時
+  edited_at?: string | null; // 最後に編集された日時
+  edit_count?: number;
   created_at: string;
   updated_at: string;
 }
 
+// 投稿の編集履歴（過去の版）
+export interface PostRevision {
+  id: number;
+  post_id: number;
+  version: number; // 1が最初の投稿
+  content: string;
+  created_at: string; // この版が投稿・編集された日時
+  replaced_at: string; // 次の版に置き換えられた日時
+}
+
 // 投稿作成リクエスト型
 export interface CreatePostRequest {
   content: string;
+  quote_post_id?: number;
   media_urls?: string[];
+  media_ids?: number[]; // アップロード済みの下書きメディアID
+  files?: File[]; // 投稿と同時にアップロードするファイル（multipartで送信）
+  poll?: CreatePollRequest; // アンケート（メディア・引用とは併用不可）
+  visibility?: PostVisibility; // 公開範囲（省略時はpublic）
 }
 
 // 投稿更新リクエスト型
@@ -14,6 +14,9 @@ export interface User {
   following_count: number;
   is_following?: boolean;
   is_followed_by?: boolean;
+  is_protected?: boolean;
+  follow_requested?: boolean;
+  pinned_post_id?: number | null; // プロフィールに固定している投稿
   created_at: string;
 }
 
@@ -26,4 +29,5 @@ export interface UpdateProfileRequest {
   website?: string;
   birth_date?: string;
   occupation?: string;
+  is_protected?: boolean;
 }